@@ -6,19 +6,30 @@ import (
 	"github.com/labstack/echo/v4"
 	"log"
 	"net/http"
+	"os"
 	"server/api"
 	"server/data/mysql"
+	domainmodel "server/pkg/domain/model"
 	"server/pkg/domain/service"
+	"server/pkg/infrastructure/machinetranslation"
 	"server/pkg/infrastructure/model"
 	inframysql "server/pkg/infrastructure/mysql"
 	"server/pkg/infrastructure/mysql/provider"
 	"server/pkg/infrastructure/mysql/query"
 	"server/pkg/infrastructure/mysql/repo"
 	"server/pkg/infrastructure/transport"
+	"slices"
+	"time"
 
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+const (
+	trashRetention          = 30 * 24 * time.Hour
+	userDataExportRetention = 7 * 24 * time.Hour
+	purgeInterval           = time.Hour
+)
+
 func main() {
 	log.Println("Starting server...")
 	e := echo.New()
@@ -35,6 +46,13 @@ func main() {
 	log.Println("Creating dependency container")
 	dependencyContainer := NewDependencyContainer(db)
 
+	err = dependencyContainer.UserDataExportService().FailPendingUserDataExports()
+	if err != nil {
+		panic(err)
+	}
+
+	go runPurge(dependencyContainer.TrashService(), dependencyContainer.UserDataExportService())
+
 	log.Println("Loading API")
 	public := transport.NewPublicAPI(
 		dependencyContainer.UserService(),
@@ -50,6 +68,11 @@ func main() {
 		dependencyContainer.BookAuthorService(),
 		dependencyContainer.GenreService(),
 		dependencyContainer.BookGenreService(),
+		dependencyContainer.UserRestrictionService(),
+		dependencyContainer.UserDataExportService(),
+		dependencyContainer.TagService(),
+		dependencyContainer.BookGlossaryService(),
+		dependencyContainer.MachineTranslationService(),
 
 		dependencyContainer.UserQueryService(),
 		dependencyContainer.BookQueryService(),
@@ -61,14 +84,42 @@ func main() {
 		dependencyContainer.UserBookFavouritesQueryService(),
 		dependencyContainer.AuthorQueryService(),
 		dependencyContainer.GenreQueryService(),
+		dependencyContainer.UserRestrictionQueryService(),
+		dependencyContainer.AuditLogQueryService(),
+		dependencyContainer.UserDataExportQueryService(),
+		dependencyContainer.TrashQueryService(),
+		dependencyContainer.BookCommentQueryService(),
+		dependencyContainer.TagQueryService(),
+		dependencyContainer.TranslationStatsQueryService(),
+		dependencyContainer.BookGlossaryQueryService(),
 
 		dependencyContainer.VerifyBookRequestProvider(),
+		dependencyContainer.UserDataExportProvider(),
 	)
 
 	log.Println("Creating endpoints")
 	api.RegisterHandlersWithBaseURL(e, public, "")
 
+	// Обёртка разбирает параметры запроса так же, как обработчики, зарегистрированные из спецификации
+	wrapper := &api.ServerInterfaceWrapper{Handler: public}
+
 	e.POST("/api/v1/verify-book-request/accept", public.AcceptVerifyBookRequest, MiddlewareRole(0))
+	e.POST("/api/v1/user/restriction", public.RestrictUser, MiddlewareRole(int(domainmodel.Admin), int(domainmodel.Moderator)))
+	e.DELETE("/api/v1/user/restriction", public.LiftUserRestriction, MiddlewareRole(int(domainmodel.Admin), int(domainmodel.Moderator)))
+	e.GET("/api/v1/user/:id/restriction", wrapper.ListUserRestrictions, MiddlewareRole(int(domainmodel.Admin), int(domainmodel.Moderator)))
+	e.GET("/api/v1/audit-log", wrapper.ListAuditLog, MiddlewareRole(int(domainmodel.Admin)))
+
+	e.POST("/api/v1/book/restore", public.RestoreBook, MiddlewareRole(int(domainmodel.Admin)))
+	e.POST("/api/v1/book-chapter/restore", public.RestoreBookChapter, MiddlewareRole(int(domainmodel.Admin)))
+	e.POST("/api/v1/author/restore", public.RestoreAuthor, MiddlewareRole(int(domainmodel.Admin)))
+	e.GET("/api/v1/author/duplicates", wrapper.ListAuthorDuplicates, MiddlewareRole(int(domainmodel.Admin)))
+	e.POST("/api/v1/author/merge", public.MergeAuthors, MiddlewareRole(int(domainmodel.Admin)))
+	e.POST("/api/v1/genre/restore", public.RestoreGenre, MiddlewareRole(int(domainmodel.Admin)))
+	e.GET("/api/v1/trash", wrapper.ListTrash, MiddlewareRole(int(domainmodel.Admin)))
+
+	e.GET("/api/v1/tag/pending", wrapper.ListPendingTags, MiddlewareRole(int(domainmodel.Admin), int(domainmodel.Moderator)))
+	e.POST("/api/v1/tag/approve", public.ApproveTag, MiddlewareRole(int(domainmodel.Admin), int(domainmodel.Moderator)))
+	e.POST("/api/v1/tag/reject", public.RejectTag, MiddlewareRole(int(domainmodel.Admin), int(domainmodel.Moderator)))
 
 	e.File("/api/v1/openapi.yaml", "./api/api.yaml")
 
@@ -99,6 +150,12 @@ type DependencyContainer struct {
 	bookAuthorService             service.BookAuthorService
 	genreService                  service.GenreService
 	bookGenreService              service.BookGenreService
+	userRestrictionService        service.UserRestrictionService
+	userDataExportService         service.UserDataExportService
+	trashService                  service.TrashService
+	tagService                    service.TagService
+	bookGlossaryService           service.BookGlossaryService
+	machineTranslationService     service.MachineTranslationService
 
 	userQueryService                   query.UserQueryService
 	bookQueryService                   query.BookQueryService
@@ -110,19 +167,27 @@ type DependencyContainer struct {
 	userBookFavouritesQueryService     query.UserBookFavouritesQueryService
 	authorQueryService                 query.AuthorQueryService
 	genreQueryService                  query.GenreQueryService
+	userRestrictionQueryService        query.UserRestrictionQueryService
+	auditLogQueryService               query.AuditLogQueryService
+	userDataExportQueryService         query.UserDataExportQueryService
+	trashQueryService                  query.TrashQueryService
+	bookCommentQueryService            query.BookCommentQueryService
+	tagQueryService                    query.TagQueryService
+	translationStatsQueryService       query.TranslationStatsQueryService
+	bookGlossaryQueryService           query.BookGlossaryQueryService
 
 	verifyBookRequestProvider provider.VerifyBookRequestProvider
+	userDataExportProvider    provider.UserDataExportProvider
 }
 
 func NewDependencyContainer(connection *sqlx.DB) *DependencyContainer {
+	auditLogRepository := repo.NewAuditLogRepository(connection)
+
 	userRepository := repo.NewUserRepository(connection)
-	userService := service.NewUserService(userRepository)
+	userService := service.NewUserService(userRepository, auditLogRepository)
 
 	bookRepository := repo.NewBookRepository(connection)
-	bookService := service.NewBookService(bookRepository)
-
-	bookChapterRepository := repo.NewBookChapterRepository(connection)
-	bookChapterService := service.NewBookChapterService(bookChapterRepository)
+	bookService := service.NewBookService(bookRepository, auditLogRepository)
 
 	bookChapterTranslationRepository := repo.NewBookChapterTranslationRepository(connection)
 	bookChapterTranslationService := service.NewBookChapterTranslationService(bookChapterTranslationRepository)
@@ -134,7 +199,10 @@ func NewDependencyContainer(connection *sqlx.DB) *DependencyContainer {
 	bookRatingService := service.NewBookRatingService(bookRatingRepository)
 
 	verifyBookRequestRepository := repo.NewVerifyBookRequestRepository(connection)
-	verifyBookRequestService := service.NewVerifyBookRequestService(verifyBookRequestRepository)
+	verifyBookRequestService := service.NewVerifyBookRequestService(verifyBookRequestRepository, bookRepository, auditLogRepository)
+
+	bookChapterRepository := repo.NewBookChapterRepository(connection)
+	bookChapterService := service.NewBookChapterService(bookChapterRepository, userRepository, verifyBookRequestRepository, auditLogRepository)
 
 	imageRepository := repo.NewImageRepository(connection)
 	imageService := service.NewImageService(imageRepository)
@@ -143,17 +211,33 @@ func NewDependencyContainer(connection *sqlx.DB) *DependencyContainer {
 	userBookFavouritesService := service.NewUserBookFavouritesService(userBookFavouritesRepository)
 
 	authorRepository := repo.NewAuthorRepository(connection)
-	authorService := service.NewAuthorService(authorRepository)
+	authorService := service.NewAuthorService(authorRepository, auditLogRepository)
 
 	bookAuthorRepository := repo.NewBookAuthorRepository(connection)
 	bookAuthorService := service.NewBookAuthorService(bookAuthorRepository)
 
 	genreRepository := repo.NewGenreRepository(connection)
-	genreService := service.NewGenreService(genreRepository)
+	genreService := service.NewGenreService(genreRepository, auditLogRepository)
 
 	bookGenreRepository := repo.NewBookGenreRepository(connection)
 	bookGenreService := service.NewBookGenreService(bookGenreRepository)
 
+	userRestrictionRepository := repo.NewUserRestrictionRepository(connection)
+	userRestrictionService := service.NewUserRestrictionService(userRestrictionRepository, userRepository, auditLogRepository)
+
+	userDataExportRepository := repo.NewUserDataExportRepository(connection)
+	userDataExportService := service.NewUserDataExportService(userDataExportRepository, userDataExportRetention)
+
+	trashService := service.NewTrashService(bookRepository, bookChapterRepository, authorRepository, genreRepository, trashRetention)
+
+	tagRepository := repo.NewTagRepository(connection)
+	tagService := service.NewTagService(tagRepository, bookRepository, auditLogRepository)
+
+	bookGlossaryRepository := repo.NewBookGlossaryRepository(connection)
+	bookGlossaryService := service.NewBookGlossaryService(bookGlossaryRepository, bookRepository, userRepository, verifyBookRequestRepository)
+
+	machineTranslationService := service.NewMachineTranslationService(newMachineTranslationProvider(), bookChapterRepository, bookRepository)
+
 	userQueryService := query.NewUserQueryService(connection)
 	bookQueryService := query.NewBookQueryService(connection)
 	bookChapterQueryService := query.NewBookChapterQueryService(connection)
@@ -164,8 +248,17 @@ func NewDependencyContainer(connection *sqlx.DB) *DependencyContainer {
 	userBookFavouritesQueryService := query.NewUserBookFavouritesQueryService(connection)
 	authorQueryService := query.NewAuthorQueryService(connection)
 	genreQueryService := query.NewGenreQueryService(connection)
+	userRestrictionQueryService := query.NewUserRestrictionQueryService(connection)
+	auditLogQueryService := query.NewAuditLogQueryService(connection)
+	userDataExportQueryService := query.NewUserDataExportQueryService(connection)
+	trashQueryService := query.NewTrashQueryService(connection)
+	bookCommentQueryService := query.NewBookCommentQueryService(connection)
+	tagQueryService := query.NewTagQueryService(connection)
+	translationStatsQueryService := query.NewTranslationStatsQueryService(connection)
+	bookGlossaryQueryService := query.NewBookGlossaryQueryService(connection)
 
 	verifyBookRequestProvider := provider.NewVerifyBookRequestProvider(connection)
+	userDataExportProvider := provider.NewUserDataExportProvider(connection)
 
 	return &DependencyContainer{
 		userService:                   userService,
@@ -181,6 +274,12 @@ func NewDependencyContainer(connection *sqlx.DB) *DependencyContainer {
 		bookAuthorService:             bookAuthorService,
 		genreService:                  genreService,
 		bookGenreService:              bookGenreService,
+		userRestrictionService:        userRestrictionService,
+		userDataExportService:         userDataExportService,
+		trashService:                  trashService,
+		tagService:                    tagService,
+		bookGlossaryService:           bookGlossaryService,
+		machineTranslationService:     machineTranslationService,
 
 		userQueryService:                   userQueryService,
 		bookQueryService:                   bookQueryService,
@@ -192,8 +291,17 @@ func NewDependencyContainer(connection *sqlx.DB) *DependencyContainer {
 		userBookFavouritesQueryService:     userBookFavouritesQueryService,
 		authorQueryService:                 authorQueryService,
 		genreQueryService:                  genreQueryService,
+		userRestrictionQueryService:        userRestrictionQueryService,
+		auditLogQueryService:               auditLogQueryService,
+		userDataExportQueryService:         userDataExportQueryService,
+		trashQueryService:                  trashQueryService,
+		bookCommentQueryService:            bookCommentQueryService,
+		tagQueryService:                    tagQueryService,
+		translationStatsQueryService:       translationStatsQueryService,
+		bookGlossaryQueryService:           bookGlossaryQueryService,
 
 		verifyBookRequestProvider: verifyBookRequestProvider,
+		userDataExportProvider:    userDataExportProvider,
 	}
 }
 
@@ -249,6 +357,30 @@ func (container *DependencyContainer) BookGenreService() service.BookGenreServic
 	return container.bookGenreService
 }
 
+func (container *DependencyContainer) UserRestrictionService() service.UserRestrictionService {
+	return container.userRestrictionService
+}
+
+func (container *DependencyContainer) UserDataExportService() service.UserDataExportService {
+	return container.userDataExportService
+}
+
+func (container *DependencyContainer) TrashService() service.TrashService {
+	return container.trashService
+}
+
+func (container *DependencyContainer) TagService() service.TagService {
+	return container.tagService
+}
+
+func (container *DependencyContainer) BookGlossaryService() service.BookGlossaryService {
+	return container.bookGlossaryService
+}
+
+func (container *DependencyContainer) MachineTranslationService() service.MachineTranslationService {
+	return container.machineTranslationService
+}
+
 func (container *DependencyContainer) UserQueryService() query.UserQueryService {
 	return container.userQueryService
 }
@@ -289,11 +421,75 @@ func (container *DependencyContainer) GenreQueryService() query.GenreQueryServic
 	return container.genreQueryService
 }
 
+func (container *DependencyContainer) UserRestrictionQueryService() query.UserRestrictionQueryService {
+	return container.userRestrictionQueryService
+}
+
+func (container *DependencyContainer) AuditLogQueryService() query.AuditLogQueryService {
+	return container.auditLogQueryService
+}
+
+func (container *DependencyContainer) UserDataExportQueryService() query.UserDataExportQueryService {
+	return container.userDataExportQueryService
+}
+
+func (container *DependencyContainer) TrashQueryService() query.TrashQueryService {
+	return container.trashQueryService
+}
+
+func (container *DependencyContainer) BookCommentQueryService() query.BookCommentQueryService {
+	return container.bookCommentQueryService
+}
+
+func (container *DependencyContainer) TagQueryService() query.TagQueryService {
+	return container.tagQueryService
+}
+
+func (container *DependencyContainer) TranslationStatsQueryService() query.TranslationStatsQueryService {
+	return container.translationStatsQueryService
+}
+
+func (container *DependencyContainer) BookGlossaryQueryService() query.BookGlossaryQueryService {
+	return container.bookGlossaryQueryService
+}
+
 func (container *DependencyContainer) VerifyBookRequestProvider() provider.VerifyBookRequestProvider {
 	return container.verifyBookRequestProvider
 }
 
-func MiddlewareRole(requiredRole int) echo.MiddlewareFunc {
+func (container *DependencyContainer) UserDataExportProvider() provider.UserDataExportProvider {
+	return container.userDataExportProvider
+}
+
+// runPurge раз в час очищает корзину и удаляет устаревшие выгрузки данных пользователей
+func runPurge(trashService service.TrashService, userDataExportService service.UserDataExportService) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		err := trashService.PurgeExpired()
+		if err != nil {
+			log.Printf("Failed to purge trash: %s", err)
+		}
+
+		err = userDataExportService.PurgeExpired()
+		if err != nil {
+			log.Printf("Failed to purge user data exports: %s", err)
+		}
+	}
+}
+
+// newMachineTranslationProvider подключает движок с API LibreTranslate, если задан MACHINE_TRANSLATION_URL,
+// иначе черновики переводятся локальной заглушкой
+func newMachineTranslationProvider() service.MachineTranslationProvider {
+	if url := os.Getenv("MACHINE_TRANSLATION_URL"); url != "" {
+		return machinetranslation.NewLibreTranslateProvider(url, os.Getenv("MACHINE_TRANSLATION_API_KEY"))
+	}
+
+	return machinetranslation.NewStubProvider()
+}
+
+func MiddlewareRole(requiredRoles ...int) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			tokenString := c.Request().Header.Get("Authorization")
@@ -310,7 +506,7 @@ func MiddlewareRole(requiredRole int) echo.MiddlewareFunc {
 				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
 			}
 
-			if claims.Role != requiredRole {
+			if !slices.Contains(requiredRoles, claims.Role) {
 				return echo.NewHTTPError(http.StatusForbidden, "Insufficient permissions")
 			}
 
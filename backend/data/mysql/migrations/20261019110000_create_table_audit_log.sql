@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_log
+(
+    audit_log_id BINARY(16)   NOT NULL,                -- UUID записи журнала
+    actor_id     BINARY(16) DEFAULT NULL,              -- UUID инициатора (NULL - неизвестен)
+    action       SMALLINT     NOT NULL,                -- Действие
+    entity_type  SMALLINT     NOT NULL,                -- Тип сущности
+    entity_id    BINARY(16)   NOT NULL,                -- UUID сущности
+    before_data  JSON DEFAULT NULL,                    -- Снимок сущности до действия
+    after_data   JSON DEFAULT NULL,                    -- Снимок сущности после действия
+    ip           VARCHAR(45)  NOT NULL,                -- IP адрес запроса
+    user_agent   VARCHAR(512) NOT NULL,                -- User-Agent запроса
+    created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,   -- Дата действия
+    PRIMARY KEY (audit_log_id),                        -- Первичный ключ
+    INDEX idx_audit_log_actor (actor_id, created_at),
+    INDEX idx_audit_log_entity (entity_type, entity_id, created_at),
+    INDEX idx_audit_log_created_at (created_at)
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_data_export
+(
+    user_data_export_id BINARY(16) NOT NULL,                -- UUID выгрузки данных пользователя
+    user_id             BINARY(16) NOT NULL,                -- UUID пользователя
+    status              SMALLINT   NOT NULL,                -- Статус выгрузки (в очереди, готова, ошибка)
+    archive             LONGBLOB DEFAULT NULL,              -- ZIP-архив с данными (NULL - ещё не готов)
+    created_at          DATETIME DEFAULT CURRENT_TIMESTAMP, -- Дата запроса
+    completed_at        DATETIME DEFAULT NULL,              -- Дата завершения
+    PRIMARY KEY (user_data_export_id),                      -- Первичный ключ
+    INDEX idx_user_data_export_user (user_id),
+    CONSTRAINT fk_user_data_export_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_data_export;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book
+    ADD COLUMN deleted_at DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалена)
+    ADD INDEX idx_book_deleted_at (deleted_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Удалённая глава сохраняет свой индекс, поэтому уникальность индекса поддерживается приложением
+ALTER TABLE book_chapter
+    DROP INDEX chapter_index,
+    ADD COLUMN deleted_at DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалена)
+    ADD INDEX idx_book_chapter_book_index (book_id, chapter_index),
+    ADD INDEX idx_book_chapter_deleted_at (deleted_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE author
+    ADD COLUMN deleted_at DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалён)
+    ADD INDEX idx_author_deleted_at (deleted_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE genre
+    ADD COLUMN deleted_at DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалён)
+    ADD INDEX idx_genre_deleted_at (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE genre
+    DROP INDEX idx_genre_deleted_at,
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE author
+    DROP INDEX idx_author_deleted_at,
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book_chapter
+    DROP INDEX idx_book_chapter_deleted_at,
+    DROP INDEX idx_book_chapter_book_index,
+    DROP COLUMN deleted_at,
+    ADD UNIQUE INDEX chapter_index (chapter_index);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book
+    DROP INDEX idx_book_deleted_at,
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book
+    ADD COLUMN published_at DATETIME DEFAULT NULL, -- Дата публикации книги (NULL - не опубликована)
+    ADD INDEX idx_book_published_at (published_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Точная дата публикации уже опубликованных книг неизвестна, используется дата миграции
+UPDATE book SET published_at = CURRENT_TIMESTAMP WHERE is_publish = 1;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book_chapter_translation
+    ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP; -- Дата создания перевода
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book_chapter_translation
+    DROP COLUMN created_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book
+    DROP INDEX idx_book_published_at,
+    DROP COLUMN published_at;
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE genre
+    ADD COLUMN parent_id BINARY(16) DEFAULT NULL, -- UUID родительского жанра (NULL - жанр верхнего уровня)
+    ADD CONSTRAINT fk_genre_parent FOREIGN KEY (parent_id) REFERENCES genre (genre_id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE tag
+(
+    tag_id       BINARY(16)  NOT NULL,                -- UUID тега
+    name         VARCHAR(64) NOT NULL,                -- Название тега в нормализованном виде
+    status       SMALLINT    NOT NULL,                -- Статус модерации (на рассмотрении, одобрен, отклонён)
+    suggested_by BINARY(16) DEFAULT NULL,             -- UUID предложившего тег пользователя
+    created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,  -- Дата предложения
+    PRIMARY KEY (tag_id),                             -- Первичный ключ
+    UNIQUE INDEX idx_tag_name (name),
+    INDEX idx_tag_status (status),
+    CONSTRAINT fk_tag_suggested_by FOREIGN KEY (suggested_by) REFERENCES user (user_id) ON DELETE SET NULL
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE book_tag
+(
+    book_id BINARY(16) NOT NULL,               -- UUID книги
+    tag_id  BINARY(16) NOT NULL,               -- UUID тега
+    user_id BINARY(16) NOT NULL,               -- UUID пользователя, отметившего книгу тегом
+    PRIMARY KEY (book_id, tag_id, user_id),    -- Композитный первичный ключ
+    INDEX idx_book_tag_tag (tag_id),
+    CONSTRAINT fk_book_tag_book FOREIGN KEY (book_id) REFERENCES book (book_id) ON DELETE CASCADE,
+    CONSTRAINT fk_book_tag_tag FOREIGN KEY (tag_id) REFERENCES tag (tag_id) ON DELETE CASCADE,
+    CONSTRAINT fk_book_tag_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE book_tag;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE tag;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE genre
+    DROP FOREIGN KEY fk_genre_parent,
+    DROP COLUMN parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE author
+    ADD COLUMN biography  TEXT         DEFAULT NULL, -- Биография автора
+    ADD COLUMN birth_year SMALLINT     DEFAULT NULL, -- Год рождения
+    ADD COLUMN death_year SMALLINT     DEFAULT NULL, -- Год смерти (NULL - автор жив или год неизвестен)
+    ADD COLUMN country    VARCHAR(255) DEFAULT NULL; -- Страна автора
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE author_alias
+(
+    author_id BINARY(16)   NOT NULL,  -- UUID автора
+    alias     VARCHAR(255) NOT NULL,  -- Псевдоним или другое написание имени автора
+    PRIMARY KEY (author_id, alias),   -- Композитный первичный ключ
+    INDEX idx_author_alias_alias (alias),
+    CONSTRAINT fk_author_alias_author FOREIGN KEY (author_id) REFERENCES author (author_id) ON DELETE CASCADE
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE author_alias;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE author
+    DROP COLUMN biography,
+    DROP COLUMN birth_year,
+    DROP COLUMN death_year,
+    DROP COLUMN country;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book_author
+    ADD COLUMN role     SMALLINT NOT NULL DEFAULT 0, -- Роль автора в книге (автор, соавтор, иллюстратор, редактор, переводчик оригинала)
+    ADD COLUMN position INT      NOT NULL DEFAULT 0, -- Порядок автора в списке авторов книги
+    ADD INDEX idx_book_author_position (book_id, position);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Существующие авторы нумеруются по фамилии, как они выводились раньше
+UPDATE book_author ba
+    INNER JOIN (
+        SELECT
+            ba2.book_id,
+            ba2.author_id,
+            ROW_NUMBER() OVER (PARTITION BY ba2.book_id ORDER BY a.second_name, a.first_name, a.author_id) - 1 AS position
+        FROM book_author ba2
+        INNER JOIN author a ON a.author_id = ba2.author_id
+    ) ordered ON ordered.book_id = ba.book_id AND ordered.author_id = ba.author_id
+SET ba.position = ordered.position;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book_author
+    DROP INDEX idx_book_author_position,
+    DROP COLUMN position,
+    DROP COLUMN role;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book
+    ADD COLUMN original_language VARCHAR(2) DEFAULT NULL, -- Код языка оригинала по ISO 639-1 (NULL - не указан)
+    ADD INDEX idx_book_original_language (original_language);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Все переводы до этой миграции делались на русский язык
+ALTER TABLE book_chapter_translation
+    ADD COLUMN language VARCHAR(2) NOT NULL DEFAULT 'ru', -- Код языка перевода по ISO 639-1
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (book_chapter_id, translator_id, language),
+    ADD INDEX idx_book_chapter_translation_language (language);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP PROCEDURE IF EXISTS check_only_ru_translations;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Откат удалил бы переводы на другие языки, поэтому их нужно перенести или удалить вручную
+CREATE PROCEDURE check_only_ru_translations()
+BEGIN
+    IF EXISTS (SELECT 1 FROM book_chapter_translation WHERE language <> 'ru') THEN
+        SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'book_chapter_translation has translations not into ru';
+    END IF;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CALL check_only_ru_translations();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP PROCEDURE check_only_ru_translations;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book_chapter_translation
+    DROP INDEX idx_book_chapter_translation_language,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (book_chapter_id, translator_id),
+    DROP COLUMN language;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book
+    DROP INDEX idx_book_original_language,
+    DROP COLUMN original_language;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE book_localization
+(
+    book_id     BINARY(16)   NOT NULL, -- UUID книги
+    language    VARCHAR(2)   NOT NULL, -- Код языка варианта по ISO 639-1
+    title       VARCHAR(255) NOT NULL, -- Заголовок книги на этом языке
+    description TEXT,                  -- Описание книги на этом языке
+    PRIMARY KEY (book_id, language),   -- Композитный первичный ключ
+    INDEX idx_book_localization_title (title),
+    CONSTRAINT fk_book_localization_book FOREIGN KEY (book_id) REFERENCES book (book_id) ON DELETE CASCADE
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE book_localization;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE preferred_translator
+(
+    user_id       BINARY(16) NOT NULL,  -- UUID читателя
+    book_id       BINARY(16) NOT NULL,  -- UUID книги
+    translator_id BINARY(16) NOT NULL,  -- UUID переводчика, чьи переводы читатель хочет читать
+    language      VARCHAR(2) DEFAULT NULL, -- Код языка перевода по ISO 639-1 (NULL - любой)
+    PRIMARY KEY (user_id, book_id),     -- Композитный первичный ключ
+    CONSTRAINT fk_preferred_translator_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_preferred_translator_book FOREIGN KEY (book_id) REFERENCES book (book_id) ON DELETE CASCADE,
+    CONSTRAINT fk_preferred_translator_translator FOREIGN KEY (translator_id) REFERENCES user (user_id)
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE preferred_translator;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book_chapter_translation
+    ADD COLUMN word_count INT NOT NULL DEFAULT 0, -- Число слов в тексте перевода
+    ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP; -- Дата последнего изменения перевода
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Приблизительный подсчёт по пробелам, точное значение записывается при следующем сохранении перевода
+UPDATE book_chapter_translation
+SET
+    word_count = IF(TRIM(text) = '', 0, LENGTH(TRIM(text)) - LENGTH(REPLACE(TRIM(text), ' ', '')) + 1),
+    updated_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book_chapter_translation
+    DROP COLUMN word_count,
+    DROP COLUMN updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NULL у переводов, сохранённых до появления разметки, такие переводы отрисовываются при чтении
+ALTER TABLE book_chapter_translation
+    ADD COLUMN html MEDIUMTEXT NULL AFTER text; -- Отрисованный и очищенный HTML перевода
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book_chapter_translation
+    DROP COLUMN html;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE book_glossary_term
+(
+    glossary_term_id BINARY(16)   NOT NULL, -- UUID термина
+    book_id          BINARY(16)   NOT NULL, -- UUID книги
+    language         VARCHAR(2)   NOT NULL, -- Код языка перевода по ISO 639-1
+    source_term      VARCHAR(255) NOT NULL, -- Термин или имя в оригинале
+    target_term      VARCHAR(255) NOT NULL, -- Принятый перевод термина
+    notes            TEXT,                  -- Пояснение для переводчиков (может быть NULL)
+    author_id        BINARY(16)   NOT NULL, -- UUID переводчика, добавившего термин
+    is_shared        TINYINT(1)   NOT NULL, -- Общий термин книги или личный термин переводчика
+    created_at       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Дата добавления термина
+    PRIMARY KEY (glossary_term_id),
+    INDEX idx_book_glossary_term_book (book_id, language),
+    CONSTRAINT fk_book_glossary_term_book FOREIGN KEY (book_id) REFERENCES book (book_id) ON DELETE CASCADE,
+    CONSTRAINT fk_book_glossary_term_author FOREIGN KEY (author_id) REFERENCES user (user_id)
+)
+    ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE book_glossary_term;
+-- +goose StatementEnd
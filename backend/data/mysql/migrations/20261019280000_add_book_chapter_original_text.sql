@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book_chapter
+    ADD COLUMN original_text MEDIUMTEXT NULL AFTER title; -- Текст главы на языке оригинала (может быть NULL)
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book_chapter
+    DROP COLUMN original_text;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE book
+    ADD COLUMN title_key VARCHAR(1024) NOT NULL DEFAULT '' AFTER title; -- Название в латинице без регистра и знаков препинания для поиска дубликатов
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE book
+    ADD FULLTEXT INDEX ftx_book_title_key (title_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book
+    DROP INDEX ftx_book_title_key,
+    DROP COLUMN title_key;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"server/pkg/domain/model"
+
+	"github.com/pressly/goose/v3"
+)
+
+// Транслитерацию нельзя выразить в SQL, поэтому ключи названий существующих книг заполняются из Go
+func init() {
+	goose.AddNamedMigrationContext("20261019290001_fill_book_title_key.go", upFillBookTitleKey, downFillBookTitleKey)
+}
+
+func upFillBookTitleKey(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT book_id, title FROM book`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type bookTitle struct {
+		bookID []byte
+		title  string
+	}
+
+	// Строки читаются целиком до обновлений: пока выборка открыта, соединение транзакции занято
+	var titles []bookTitle
+	for rows.Next() {
+		var title bookTitle
+		err = rows.Scan(&title.bookID, &title.title)
+		if err != nil {
+			return err
+		}
+		titles = append(titles, title)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, title := range titles {
+		_, err = tx.ExecContext(ctx, `UPDATE book SET title_key = ? WHERE book_id = ?`, model.NormalizeBookTitle(title.title), title.bookID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Колонку удаляет предыдущая миграция
+func downFillBookTitleKey(_ context.Context, _ *sql.Tx) error {
+	return nil
+}
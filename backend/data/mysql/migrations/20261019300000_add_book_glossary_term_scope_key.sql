@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Общие термины получают нулевой ключ области, личные - UUID автора, поэтому уникальный индекс
+-- не даёт завести два общих термина или два личных термина одного автора с одинаковым написанием
+ALTER TABLE book_glossary_term
+    ADD COLUMN scope_author_id BINARY(16) AS (IF(is_shared, 0x00000000000000000000000000000000, author_id)) STORED AFTER is_shared,
+    ADD UNIQUE INDEX uniq_book_glossary_term_scope (book_id, language, source_term, scope_author_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE book_glossary_term
+    DROP INDEX uniq_book_glossary_term_scope,
+    DROP COLUMN scope_author_id;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Выгрузки собираются в процессе сервера, поэтому до запуска после миграции ни одна ожидающая выгрузка не собирается
+UPDATE user_data_export SET status = 2, completed_at = NOW() WHERE status = 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- У пользователя может быть только одна ожидающая выгрузка, у готовых и неудачных выгрузок колонка NULL
+ALTER TABLE user_data_export
+    ADD COLUMN pending_user_id BINARY(16) AS (IF(status = 0, user_id, NULL)) STORED AFTER status,
+    ADD UNIQUE INDEX uniq_user_data_export_pending_user (pending_user_id),
+    ADD INDEX idx_user_data_export_completed_at (completed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_data_export
+    DROP INDEX idx_user_data_export_completed_at,
+    DROP INDEX uniq_user_data_export_pending_user,
+    DROP COLUMN pending_user_id;
+-- +goose StatementEnd
@@ -3,6 +3,7 @@ package mysql
 import (
 	"embed"
 	"log"
+	_ "server/data/mysql/migrations"
 	"server/pkg/infrastructure/mysql"
 
 	"github.com/pressly/goose/v3"
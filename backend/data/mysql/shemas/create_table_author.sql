@@ -6,7 +6,13 @@ CREATE TABLE authors
     second_name VARCHAR(255) NOT NULL, -- Фамилия автора
     middle_name VARCHAR(255),          -- Отчество автора
     nickname    VARCHAR(255),          -- Никнейм автора
+    biography   TEXT,                  -- Биография автора
+    birth_year  SMALLINT,              -- Год рождения
+    death_year  SMALLINT,              -- Год смерти (NULL - автор жив или год неизвестен)
+    country     VARCHAR(255),          -- Страна автора
+    deleted_at  DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалён)
     PRIMARY KEY (author_id),           -- Первичный ключ
+    INDEX idx_author_deleted_at (deleted_at),
     CONSTRAINT fk_author_avatar FOREIGN KEY (avatar_id) REFERENCES image (image_id)
 ) ENGINE=InnoDB
     CHARACTER SET = utf8mb4
@@ -0,0 +1,11 @@
+CREATE TABLE author_alias
+(
+    author_id BINARY(16)   NOT NULL,  -- UUID автора
+    alias     VARCHAR(255) NOT NULL,  -- Псевдоним или другое написание имени автора
+    PRIMARY KEY (author_id, alias),   -- Композитный первичный ключ
+    INDEX idx_author_alias_alias (alias),
+    CONSTRAINT fk_author_alias_author FOREIGN KEY (author_id) REFERENCES author (author_id) ON DELETE CASCADE
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
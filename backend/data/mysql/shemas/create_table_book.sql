@@ -4,8 +4,16 @@ CREATE TABLE book
     cover_id    BINARY(16),                          -- UUID обложки (может быть NULL)
     description TEXT,                                -- Описание книги
     title       VARCHAR(255) NOT NULL,               -- Заголовок книги
+    title_key   VARCHAR(1024) NOT NULL DEFAULT '',   -- Название в латинице без регистра и знаков препинания для поиска дубликатов
     is_publish  BOOLEAN      NOT NULL DEFAULT FALSE, -- Флаг опубликования книги
+    published_at DATETIME DEFAULT NULL,              -- Дата публикации книги (NULL - не опубликована)
+    original_language VARCHAR(2) DEFAULT NULL,       -- Код языка оригинала по ISO 639-1 (NULL - не указан)
+    deleted_at  DATETIME DEFAULT NULL,               -- Дата мягкого удаления (NULL - не удалена)
     PRIMARY KEY (book_id),                           -- Первичный ключ
+    INDEX idx_book_published_at (published_at),
+    INDEX idx_book_original_language (original_language),
+    INDEX idx_book_deleted_at (deleted_at),
+    FULLTEXT INDEX ftx_book_title_key (title_key),
     CONSTRAINT fk_cover FOREIGN KEY (cover_id) REFERENCES image (image_id)
 ) ENGINE=InnoDB
     CHARACTER SET = utf8mb4
@@ -2,7 +2,10 @@ CREATE TABLE book_author
 (
     book_id   BINARY(16),             -- UUID книги
     author_id BINARY(16),             -- UUID автора
+    role      SMALLINT NOT NULL DEFAULT 0, -- Роль автора в книге (автор, соавтор, иллюстратор, редактор, переводчик оригинала)
+    position  INT      NOT NULL DEFAULT 0, -- Порядок автора в списке авторов книги
     PRIMARY KEY (book_id, author_id), -- Композитный первичный ключ
+    INDEX idx_book_author_position (book_id, position),
     CONSTRAINT fk_author_book FOREIGN KEY (book_id) REFERENCES book (book_id),
     CONSTRAINT fk_author FOREIGN KEY (author_id) REFERENCES author (author_id)
 )
@@ -2,9 +2,13 @@ CREATE TABLE book_chapter
 (
     book_chapter_id BINARY(16) NOT NULL,   -- UUID главы книги
     book_id         BINARY(16) NOT NULL,   -- UUID книги
-    chapter_index   INT UNIQUE   NOT NULL, -- Индекс главы (уникальный)
+    chapter_index   INT          NOT NULL, -- Индекс главы (уникален среди неудалённых глав книги)
     title           VARCHAR(255) NOT NULL, -- Заголовок главы
+    original_text   MEDIUMTEXT NULL,       -- Текст главы на языке оригинала (может быть NULL)
+    deleted_at      DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалена)
     PRIMARY KEY (book_chapter_id),         -- Первичный ключ
+    INDEX idx_book_chapter_book_index (book_id, chapter_index),
+    INDEX idx_book_chapter_deleted_at (deleted_at),
     CONSTRAINT fk_book_chapter FOREIGN KEY (book_id) REFERENCES book (book_id)
 ) ENGINE=InnoDB
     CHARACTER SET = utf8mb4
@@ -2,8 +2,14 @@ CREATE TABLE book_chapter_translation
 (
     book_chapter_id BINARY(16) NOT NULL,          -- UUID главы книги
     translator_id   BINARY(16) NOT NULL,          -- UUID переводчика
-    text            TEXT NOT NULL,                -- Текст перевода
-    PRIMARY KEY (book_chapter_id, translator_id), -- Композитный первичный ключ
+    text            TEXT NOT NULL,                -- Текст перевода в разметке
+    html            MEDIUMTEXT NULL,              -- Отрисованный и очищенный HTML перевода
+    language        VARCHAR(2) NOT NULL,          -- Код языка перевода по ISO 639-1
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Дата создания перевода
+    word_count      INT NOT NULL DEFAULT 0,       -- Число слов в тексте перевода
+    updated_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP, -- Дата последнего изменения перевода
+    PRIMARY KEY (book_chapter_id, translator_id, language), -- Композитный первичный ключ
+    INDEX idx_book_chapter_translation_language (language),
     CONSTRAINT fk_book_chapter_translation FOREIGN KEY (book_chapter_id) REFERENCES book_chapter (book_chapter_id),
     CONSTRAINT fk_translator FOREIGN KEY (translator_id) REFERENCES user (user_id)
 ) ENGINE=InnoDB
@@ -0,0 +1,21 @@
+CREATE TABLE book_chapter_translation_annotation
+(
+    annotation_id   BINARY(16)    NOT NULL, -- UUID примечания
+    book_chapter_id BINARY(16)    NOT NULL, -- UUID главы книги
+    translator_id   BINARY(16)    NOT NULL, -- UUID переводчика
+    language        VARCHAR(2)    NOT NULL, -- Код языка перевода по ISO 639-1
+    paragraph_index INT           NOT NULL, -- Номер абзаца перевода, начиная с нуля
+    char_offset     INT           NOT NULL, -- Смещение в символах от начала абзаца
+    anchor_text     VARCHAR(1024) NOT NULL, -- Выделенный фрагмент текста, может быть пустым
+    anchor_prefix   VARCHAR(255)  NOT NULL, -- Текст перед фрагментом для поиска после правки перевода
+    text            TEXT          NOT NULL, -- Текст примечания
+    is_detached     TINYINT(1)    NOT NULL DEFAULT 0, -- Якорь не найден после правки перевода
+    created_at      DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Дата создания примечания
+    PRIMARY KEY (annotation_id),
+    INDEX idx_translation_annotation_translation (book_chapter_id, translator_id, language),
+    CONSTRAINT fk_translation_annotation_translation FOREIGN KEY (book_chapter_id, translator_id, language)
+        REFERENCES book_chapter_translation (book_chapter_id, translator_id, language) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE book_chapter_translation_vote
+(
+    book_chapter_id BINARY(16) NOT NULL, -- UUID главы книги
+    translator_id   BINARY(16) NOT NULL, -- UUID переводчика
+    language        VARCHAR(2) NOT NULL, -- Код языка перевода по ISO 639-1
+    user_id         BINARY(16) NOT NULL, -- UUID проголосовавшего пользователя
+    value           SMALLINT   NOT NULL, -- Голос: 1 - за, -1 - против
+    PRIMARY KEY (book_chapter_id, translator_id, language, user_id), -- Композитный первичный ключ
+    CONSTRAINT fk_translation_vote_translation FOREIGN KEY (book_chapter_id, translator_id, language)
+        REFERENCES book_chapter_translation (book_chapter_id, translator_id, language) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_translation_vote_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE book_tag
+(
+    book_id BINARY(16) NOT NULL,               -- UUID книги
+    tag_id  BINARY(16) NOT NULL,               -- UUID тега
+    user_id BINARY(16) NOT NULL,               -- UUID пользователя, отметившего книгу тегом
+    PRIMARY KEY (book_id, tag_id, user_id),    -- Композитный первичный ключ
+    INDEX idx_book_tag_tag (tag_id),
+    CONSTRAINT fk_book_tag_book FOREIGN KEY (book_id) REFERENCES book (book_id) ON DELETE CASCADE,
+    CONSTRAINT fk_book_tag_tag FOREIGN KEY (tag_id) REFERENCES tag (tag_id) ON DELETE CASCADE,
+    CONSTRAINT fk_book_tag_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
@@ -1,8 +1,12 @@
 CREATE TABLE genre
 (
-    genre_id BINARY(16) NOT NULL,   -- UUID жанра
-    name     VARCHAR(255) NOT NULL, -- Название жанра
-    PRIMARY KEY (genre_id)           -- Первичный ключ
+    genre_id   BINARY(16) NOT NULL,   -- UUID жанра
+    name       VARCHAR(255) NOT NULL, -- Название жанра
+    parent_id  BINARY(16) DEFAULT NULL, -- UUID родительского жанра (NULL - жанр верхнего уровня)
+    deleted_at DATETIME DEFAULT NULL, -- Дата мягкого удаления (NULL - не удалён)
+    PRIMARY KEY (genre_id),           -- Первичный ключ
+    INDEX idx_genre_deleted_at (deleted_at),
+    CONSTRAINT fk_genre_parent FOREIGN KEY (parent_id) REFERENCES genre (genre_id) ON DELETE SET NULL
 ) ENGINE=InnoDB
     CHARACTER SET = utf8mb4
     COLLATE utf8mb4_unicode_ci
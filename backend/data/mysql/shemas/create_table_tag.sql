@@ -0,0 +1,15 @@
+CREATE TABLE tag
+(
+    tag_id       BINARY(16)  NOT NULL,                -- UUID тега
+    name         VARCHAR(64) NOT NULL,                -- Название тега в нормализованном виде
+    status       SMALLINT    NOT NULL,                -- Статус модерации (на рассмотрении, одобрен, отклонён)
+    suggested_by BINARY(16) DEFAULT NULL,             -- UUID предложившего тег пользователя
+    created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,  -- Дата предложения
+    PRIMARY KEY (tag_id),                             -- Первичный ключ
+    UNIQUE INDEX idx_tag_name (name),
+    INDEX idx_tag_status (status),
+    CONSTRAINT fk_tag_suggested_by FOREIGN KEY (suggested_by) REFERENCES user (user_id) ON DELETE SET NULL
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE user_data_export
+(
+    user_data_export_id BINARY(16) NOT NULL,                -- UUID выгрузки данных пользователя
+    user_id             BINARY(16) NOT NULL,                -- UUID пользователя
+    status              SMALLINT   NOT NULL,                -- Статус выгрузки (в очереди, готова, ошибка)
+    pending_user_id     BINARY(16) AS (IF(status = 0, user_id, NULL)) STORED, -- UUID пользователя, пока выгрузка в очереди
+    archive             LONGBLOB DEFAULT NULL,              -- ZIP-архив с данными (NULL - ещё не готов)
+    created_at          DATETIME DEFAULT CURRENT_TIMESTAMP, -- Дата запроса
+    completed_at        DATETIME DEFAULT NULL,              -- Дата завершения
+    PRIMARY KEY (user_data_export_id),                      -- Первичный ключ
+    INDEX idx_user_data_export_user (user_id),
+    UNIQUE INDEX uniq_user_data_export_pending_user (pending_user_id),
+    INDEX idx_user_data_export_completed_at (completed_at),
+    CONSTRAINT fk_user_data_export_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE user_restriction
+(
+    user_restriction_id BINARY(16) NOT NULL,                -- UUID ограничения
+    user_id             BINARY(16) NOT NULL,                -- UUID пользователя
+    issued_by           BINARY(16) NOT NULL,                -- UUID выдавшего ограничение
+    type                SMALLINT   NOT NULL,                -- Тип ограничения (блокировка, заглушение)
+    reason              TEXT       NOT NULL,                -- Причина
+    created_at          DATETIME DEFAULT CURRENT_TIMESTAMP, -- Дата выдачи
+    expires_at          DATETIME DEFAULT NULL,              -- Дата окончания (NULL - бессрочно)
+    PRIMARY KEY (user_restriction_id),                      -- Первичный ключ
+    INDEX idx_user_restriction_user (user_id),
+    CONSTRAINT fk_user_restriction_user FOREIGN KEY (user_id) REFERENCES user (user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_restriction_issuer FOREIGN KEY (issued_by) REFERENCES user (user_id)
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
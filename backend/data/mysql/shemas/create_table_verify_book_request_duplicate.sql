@@ -0,0 +1,14 @@
+CREATE TABLE verify_book_request_duplicate
+(
+    verify_book_request_id BINARY(16) NOT NULL, -- UUID запроса на проверку книги
+    book_id                BINARY(16) NOT NULL, -- UUID похожей существующей книги
+    title_similarity       DOUBLE     NOT NULL, -- Похожесть названий от 0 до 1
+    author_overlap         DOUBLE     NOT NULL, -- Доля общих авторов от 0 до 1
+    score                  DOUBLE     NOT NULL, -- Итоговая оценка похожести
+    PRIMARY KEY (verify_book_request_id, book_id), -- Композитный первичный ключ
+    CONSTRAINT fk_verify_duplicate_request FOREIGN KEY (verify_book_request_id) REFERENCES verify_book_request (verify_book_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_verify_duplicate_book FOREIGN KEY (book_id) REFERENCES book (book_id) ON DELETE CASCADE
+) ENGINE=InnoDB
+    CHARACTER SET = utf8mb4
+    COLLATE utf8mb4_unicode_ci
+;
\ No newline at end of file
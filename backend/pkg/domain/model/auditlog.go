@@ -0,0 +1,123 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"time"
+)
+
+type AuditLogID = uuid.UUID
+
+type AuditAction int16
+
+const (
+	AuditActionPublishBook AuditAction = iota
+	AuditActionDeleteBook
+	AuditActionDeleteAuthor
+	AuditActionDeleteGenre
+	AuditActionEditUser
+	AuditActionDeleteUser
+	AuditActionAcceptVerifyBookRequest
+	AuditActionRestrictUser
+	AuditActionLiftUserRestriction
+	AuditActionRestoreBook
+	AuditActionRestoreAuthor
+	AuditActionRestoreGenre
+	AuditActionApproveTag
+	AuditActionRejectTag
+	AuditActionMergeAuthor
+	AuditActionRestoreBookChapter
+)
+
+type AuditEntityType int16
+
+const (
+	AuditEntityUser AuditEntityType = iota
+	AuditEntityBook
+	AuditEntityAuthor
+	AuditEntityGenre
+	AuditEntityVerifyBookRequest
+	AuditEntityUserRestriction
+	AuditEntityTag
+	AuditEntityBookChapter
+)
+
+type AuditLog struct {
+	id         AuditLogID
+	actorID    maybe.Maybe[UserID]
+	action     AuditAction
+	entityType AuditEntityType
+	entityID   uuid.UUID
+	before     maybe.Maybe[string]
+	after      maybe.Maybe[string]
+	ip         string
+	userAgent  string
+	createdAt  time.Time
+}
+
+func NewAuditLog(
+	id AuditLogID,
+	actorID maybe.Maybe[UserID],
+	action AuditAction,
+	entityType AuditEntityType,
+	entityID uuid.UUID,
+	before maybe.Maybe[string],
+	after maybe.Maybe[string],
+	ip string,
+	userAgent string,
+	createdAt time.Time,
+) AuditLog {
+	return AuditLog{
+		id:         id,
+		actorID:    actorID,
+		action:     action,
+		entityType: entityType,
+		entityID:   entityID,
+		before:     before,
+		after:      after,
+		ip:         ip,
+		userAgent:  userAgent,
+		createdAt:  createdAt,
+	}
+}
+
+func (auditLog *AuditLog) ID() AuditLogID {
+	return auditLog.id
+}
+
+func (auditLog *AuditLog) ActorID() maybe.Maybe[UserID] {
+	return auditLog.actorID
+}
+
+func (auditLog *AuditLog) Action() AuditAction {
+	return auditLog.action
+}
+
+func (auditLog *AuditLog) EntityType() AuditEntityType {
+	return auditLog.entityType
+}
+
+func (auditLog *AuditLog) EntityID() uuid.UUID {
+	return auditLog.entityID
+}
+
+// Before и After содержат JSON-снимок сущности до и после действия
+func (auditLog *AuditLog) Before() maybe.Maybe[string] {
+	return auditLog.before
+}
+
+func (auditLog *AuditLog) After() maybe.Maybe[string] {
+	return auditLog.after
+}
+
+func (auditLog *AuditLog) IP() string {
+	return auditLog.ip
+}
+
+func (auditLog *AuditLog) UserAgent() string {
+	return auditLog.userAgent
+}
+
+func (auditLog *AuditLog) CreatedAt() time.Time {
+	return auditLog.createdAt
+}
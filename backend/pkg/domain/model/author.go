@@ -3,6 +3,7 @@ package model
 import (
 	"github.com/gofrs/uuid"
 	"github.com/mono83/maybe"
+	"strings"
 )
 
 type AuthorID = uuid.UUID
@@ -14,6 +15,11 @@ type Author struct {
 	secondName string
 	middleName maybe.Maybe[string]
 	nickname   maybe.Maybe[string]
+	biography  maybe.Maybe[string]
+	birthYear  maybe.Maybe[int]
+	deathYear  maybe.Maybe[int]
+	country    maybe.Maybe[string]
+	aliases    []string
 }
 
 func NewAuthor(
@@ -23,6 +29,11 @@ func NewAuthor(
 	secondName string,
 	middleName maybe.Maybe[string],
 	nickname maybe.Maybe[string],
+	biography maybe.Maybe[string],
+	birthYear maybe.Maybe[int],
+	deathYear maybe.Maybe[int],
+	country maybe.Maybe[string],
+	aliases []string,
 ) Author {
 	return Author{
 		id:         id,
@@ -31,6 +42,11 @@ func NewAuthor(
 		secondName: secondName,
 		middleName: middleName,
 		nickname:   nickname,
+		biography:  biography,
+		birthYear:  birthYear,
+		deathYear:  deathYear,
+		country:    country,
+		aliases:    aliases,
 	}
 }
 
@@ -58,6 +74,26 @@ func (author *Author) Nickname() maybe.Maybe[string] {
 	return author.nickname
 }
 
+func (author *Author) Biography() maybe.Maybe[string] {
+	return author.biography
+}
+
+func (author *Author) BirthYear() maybe.Maybe[int] {
+	return author.birthYear
+}
+
+func (author *Author) DeathYear() maybe.Maybe[int] {
+	return author.deathYear
+}
+
+func (author *Author) Country() maybe.Maybe[string] {
+	return author.country
+}
+
+func (author *Author) Aliases() []string {
+	return author.aliases
+}
+
 func (author *Author) SetAvatarID(avatarID maybe.Maybe[ImageID]) {
 	author.avatarID = avatarID
 }
@@ -77,3 +113,50 @@ func (author *Author) SetMiddleName(middleName maybe.Maybe[string]) {
 func (author *Author) SetNickname(nickname maybe.Maybe[string]) {
 	author.nickname = nickname
 }
+
+func (author *Author) SetBiography(biography maybe.Maybe[string]) {
+	author.biography = biography
+}
+
+// SetLifeYears задаёт годы жизни, год смерти не может быть раньше года рождения
+func (author *Author) SetLifeYears(birthYear, deathYear maybe.Maybe[int]) error {
+	birth, hasBirth := birthYear.Get()
+	death, hasDeath := deathYear.Get()
+	if hasBirth && hasDeath && death < birth {
+		return ErrInvalidAuthorLifeYears
+	}
+
+	author.birthYear = birthYear
+	author.deathYear = deathYear
+
+	return nil
+}
+
+func (author *Author) SetCountry(country maybe.Maybe[string]) {
+	author.country = country
+}
+
+func (author *Author) SetAliases(aliases []string) {
+	author.aliases = NormalizeAuthorAliases(aliases)
+}
+
+// NormalizeAuthorAliases убирает лишние пробелы, пустые значения и повторы без учёта регистра
+func NormalizeAuthorAliases(aliases []string) []string {
+	normalized := make([]string, 0, len(aliases))
+	seen := make(map[string]struct{}, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.Join(strings.Fields(alias), " ")
+		if alias == "" {
+			continue
+		}
+
+		key := strings.ToLower(alias)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		normalized = append(normalized, alias)
+	}
+
+	return normalized
+}
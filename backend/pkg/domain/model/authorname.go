@@ -0,0 +1,215 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultAuthorNameSimilarity - порог похожести имён, начиная с которого авторы предлагаются как дубликаты
+const DefaultAuthorNameSimilarity = 0.8
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// Разные системы транслитерации по-разному записывают одни и те же звуки, приводим их к одному написанию
+var latinSpellingReplacer = strings.NewReplacer(
+	"shch", "sch",
+	"kh", "h",
+	"ph", "f",
+	"ck", "k",
+	"iy", "y",
+	"yj", "y",
+	"ij", "y",
+	"j", "y",
+	"w", "v",
+	"x", "ks",
+	"c", "k",
+	"q", "k",
+)
+
+// NormalizeAuthorName переводит имя в латиницу в нижнем регистре без знаков препинания и диакритики,
+// чтобы "Лев Толстой" и "Lev Tolstoy" давали одинаковый результат
+func NormalizeAuthorName(name string) string {
+	return transliterateAndNormalize(name)
+}
+
+func transliterateAndNormalize(text string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if latin, ok := cyrillicToLatin[r]; ok {
+			builder.WriteString(latin)
+			continue
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			builder.WriteRune(r)
+		case unicode.IsLetter(r):
+			builder.WriteString(foldLatinDiacritic(r))
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(builder.String())
+	for i, word := range words {
+		words[i] = squeezeRepeatedLetters(latinSpellingReplacer.Replace(word))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// AuthorNameVariants возвращает нормализованные варианты написания имени автора
+// с отсортированными словами, чтобы порядок имени и фамилии не влиял на сравнение
+func AuthorNameVariants(author Author) []string {
+	fullName := author.FirstName() + " " + author.SecondName()
+	if middleName, ok := author.MiddleName().Get(); ok {
+		fullName += " " + middleName
+	}
+
+	names := []string{fullName, author.FirstName() + " " + author.SecondName()}
+	if nickname, ok := author.Nickname().Get(); ok {
+		names = append(names, nickname)
+	}
+	names = append(names, author.Aliases()...)
+
+	variants := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		words := strings.Fields(NormalizeAuthorName(name))
+		if len(words) == 0 {
+			continue
+		}
+		sort.Strings(words)
+
+		variant := strings.Join(words, " ")
+		if _, ok := seen[variant]; ok {
+			continue
+		}
+		seen[variant] = struct{}{}
+		variants = append(variants, variant)
+	}
+
+	return variants
+}
+
+// AuthorNameBlockingKeys возвращает инициалы слов каждого варианта имени. Имена с похожестью выше порога
+// почти всегда совпадают по инициалам, поэтому дубликаты достаточно искать среди авторов с общим ключом.
+// Опечатка в первой букве слова при этом не находится
+func AuthorNameBlockingKeys(variants []string) []string {
+	keys := make([]string, 0, len(variants))
+	seen := make(map[string]struct{}, len(variants))
+	for _, variant := range variants {
+		var builder strings.Builder
+		for _, word := range strings.Fields(variant) {
+			initial, _ := utf8.DecodeRuneInString(word)
+			builder.WriteRune(initial)
+		}
+
+		key := builder.String()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// AuthorNameSimilarity возвращает наибольшую похожесть вариантов имён двух авторов от 0 до 1
+func AuthorNameSimilarity(first, second Author) float64 {
+	return AuthorNameVariantsSimilarity(AuthorNameVariants(first), AuthorNameVariants(second))
+}
+
+// AuthorNameVariantsSimilarity - AuthorNameSimilarity для заранее посчитанных AuthorNameVariants
+func AuthorNameVariantsSimilarity(firstVariants, secondVariants []string) float64 {
+	var best float64
+	for _, firstVariant := range firstVariants {
+		for _, secondVariant := range secondVariants {
+			similarity := StringSimilarity(firstVariant, secondVariant)
+			if similarity > best {
+				best = similarity
+			}
+		}
+	}
+
+	return best
+}
+
+// StringSimilarity - единица минус расстояние Левенштейна, делённое на длину более длинной строки
+func StringSimilarity(first, second string) float64 {
+	firstRunes := []rune(first)
+	secondRunes := []rune(second)
+	longest := len(firstRunes)
+	if len(secondRunes) > longest {
+		longest = len(secondRunes)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshteinDistance(firstRunes, secondRunes))/float64(longest)
+}
+
+func levenshteinDistance(first, second []rune) int {
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
+func squeezeRepeatedLetters(word string) string {
+	var builder strings.Builder
+	var last rune
+	for _, r := range word {
+		if r == last {
+			continue
+		}
+		builder.WriteRune(r)
+		last = r
+	}
+
+	return builder.String()
+}
+
+var latinDiacritics = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'č': "ch", 'ć': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ě': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ř': "r", 'š': "sh", 'ś': "s", 'ß': "ss",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ů': "u",
+	'ý': "y", 'ÿ': "y", 'ž': "zh", 'ź': "z", 'ż': "z", 'ł': "l",
+}
+
+func foldLatinDiacritic(r rune) string {
+	if latin, ok := latinDiacritics[r]; ok {
+		return latin
+	}
+
+	return string(r)
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"slices"
+	"testing"
+)
+
+func newTestAuthor(firstName, secondName string, aliases ...string) Author {
+	return NewAuthor(
+		AuthorID(uuid.Must(uuid.NewV4())),
+		maybe.Nothing[ImageID](),
+		firstName,
+		secondName,
+		maybe.Nothing[string](),
+		maybe.Nothing[string](),
+		maybe.Nothing[string](),
+		maybe.Nothing[int](),
+		maybe.Nothing[int](),
+		maybe.Nothing[string](),
+		aliases,
+	)
+}
+
+func TestNormalizeAuthorName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Лев Толстой", want: "lev tolstoy"},
+		{name: "Lev Tolstoy", want: "lev tolstoy"},
+		{name: "  LEV   TOLSTOY ", want: "lev tolstoy"},
+		{name: "Фёдор Достоевский", want: "fyodor dostoevsky"},
+		{name: "Fjodor Dostojewski", want: "fyodor dostoyevski"},
+		{name: "Antoine de Saint-Exupéry", want: "antoine de saint eksupery"},
+		{name: "Чехов", want: "khehov"},
+		{name: "Chekhov", want: "khehov"},
+		{name: "", want: ""},
+	}
+
+	for _, test := range tests {
+		if got := NormalizeAuthorName(test.name); got != test.want {
+			t.Errorf("NormalizeAuthorName(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		first  string
+		second string
+		want   int
+	}{
+		{first: "", second: "", want: 0},
+		{first: "abc", second: "", want: 3},
+		{first: "", second: "abc", want: 3},
+		{first: "kitten", second: "sitting", want: 3},
+		{first: "толстой", second: "толстый", want: 1},
+		{first: "flaw", second: "lawn", want: 2},
+	}
+
+	for _, test := range tests {
+		if got := levenshteinDistance([]rune(test.first), []rune(test.second)); got != test.want {
+			t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", test.first, test.second, got, test.want)
+		}
+	}
+}
+
+func TestStringSimilarity(t *testing.T) {
+	tests := []struct {
+		first  string
+		second string
+		want   float64
+	}{
+		{first: "", second: "", want: 1},
+		{first: "abcd", second: "abcd", want: 1},
+		{first: "abcd", second: "abce", want: 0.75},
+		{first: "abcd", second: "", want: 0},
+	}
+
+	for _, test := range tests {
+		if got := StringSimilarity(test.first, test.second); got != test.want {
+			t.Errorf("StringSimilarity(%q, %q) = %v, want %v", test.first, test.second, got, test.want)
+		}
+	}
+}
+
+func TestAuthorNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     Author
+		second    Author
+		duplicate bool
+	}{
+		{
+			name:      "transliteration",
+			first:     newTestAuthor("Лев", "Толстой"),
+			second:    newTestAuthor("Lev", "Tolstoy"),
+			duplicate: true,
+		},
+		{
+			name:      "swapped name order",
+			first:     newTestAuthor("Tolstoy", "Lev"),
+			second:    newTestAuthor("Лев", "Толстой"),
+			duplicate: true,
+		},
+		{
+			name:      "different transliteration systems",
+			first:     newTestAuthor("Фёдор", "Достоевский"),
+			second:    newTestAuthor("Fjodor", "Dostojewski"),
+			duplicate: true,
+		},
+		{
+			name:      "alias",
+			first:     newTestAuthor("Сэмюэл", "Клеменс", "Марк Твен"),
+			second:    newTestAuthor("Mark", "Twain"),
+			duplicate: true,
+		},
+		{
+			name:      "different authors",
+			first:     newTestAuthor("Лев", "Толстой"),
+			second:    newTestAuthor("Алексей", "Толстой"),
+			duplicate: false,
+		},
+	}
+
+	for _, test := range tests {
+		similarity := AuthorNameSimilarity(test.first, test.second)
+		if duplicate := similarity >= DefaultAuthorNameSimilarity; duplicate != test.duplicate {
+			t.Errorf("%s: similarity = %v, want duplicate = %v", test.name, similarity, test.duplicate)
+		}
+	}
+}
+
+func TestAuthorNameBlockingKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		variants []string
+		want     []string
+	}{
+		{name: "single variant", variants: []string{"lev tolstoy"}, want: []string{"lt"}},
+		{name: "same initials", variants: []string{"lev nikolaevich tolstoy", "lev nikolay tolstoy"}, want: []string{"lnt"}},
+		{name: "several keys", variants: []string{"lev tolstoy", "graf"}, want: []string{"lt", "g"}},
+		{name: "no variants", variants: nil, want: []string{}},
+	}
+
+	for _, test := range tests {
+		if got := AuthorNameBlockingKeys(test.variants); !slices.Equal(got, test.want) {
+			t.Errorf("%s: AuthorNameBlockingKeys(%q) = %q, want %q", test.name, test.variants, got, test.want)
+		}
+	}
+
+	// Дубликаты из TestAuthorNameSimilarity должны попадать в одну группу
+	first := AuthorNameBlockingKeys(AuthorNameVariants(newTestAuthor("Фёдор", "Достоевский")))
+	second := AuthorNameBlockingKeys(AuthorNameVariants(newTestAuthor("Fjodor", "Dostojewski")))
+	if !slices.ContainsFunc(first, func(key string) bool { return slices.Contains(second, key) }) {
+		t.Errorf("no common blocking key for transliterated names: %q and %q", first, second)
+	}
+}
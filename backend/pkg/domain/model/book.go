@@ -3,16 +3,19 @@ package model
 import (
 	"github.com/gofrs/uuid"
 	"github.com/mono83/maybe"
+	"time"
 )
 
 type BookID = uuid.UUID
 
 type Book struct {
-	id          BookID
-	coverID     maybe.Maybe[ImageID]
-	title       string
-	description string
-	isPublished bool
+	id               BookID
+	coverID          maybe.Maybe[ImageID]
+	title            string
+	description      string
+	isPublished      bool
+	publishedAt      maybe.Maybe[time.Time]
+	originalLanguage maybe.Maybe[string]
 }
 
 func NewBook(
@@ -21,13 +24,17 @@ func NewBook(
 	title string,
 	description string,
 	isPublished bool,
+	publishedAt maybe.Maybe[time.Time],
+	originalLanguage maybe.Maybe[string],
 ) Book {
 	return Book{
-		id:          id,
-		coverID:     coverID,
-		title:       title,
-		description: description,
-		isPublished: isPublished,
+		id:               id,
+		coverID:          coverID,
+		title:            title,
+		description:      description,
+		isPublished:      isPublished,
+		publishedAt:      publishedAt,
+		originalLanguage: originalLanguage,
 	}
 }
 
@@ -51,6 +58,14 @@ func (book *Book) IsPublished() bool {
 	return book.isPublished
 }
 
+func (book *Book) PublishedAt() maybe.Maybe[time.Time] {
+	return book.publishedAt
+}
+
+func (book *Book) OriginalLanguage() maybe.Maybe[string] {
+	return book.originalLanguage
+}
+
 func (book *Book) SetCoverID(coverID maybe.Maybe[ImageID]) {
 	book.coverID = coverID
 }
@@ -66,3 +81,11 @@ func (book *Book) SetDescription(description string) {
 func (book *Book) SetIsPublished(isPublished bool) {
 	book.isPublished = isPublished
 }
+
+func (book *Book) SetPublishedAt(publishedAt maybe.Maybe[time.Time]) {
+	book.publishedAt = publishedAt
+}
+
+func (book *Book) SetOriginalLanguage(originalLanguage maybe.Maybe[string]) {
+	book.originalLanguage = originalLanguage
+}
@@ -1,17 +1,33 @@
 package model
 
+type BookAuthorRole int16
+
+const (
+	BookAuthorRoleAuthor BookAuthorRole = iota
+	BookAuthorRoleCoAuthor
+	BookAuthorRoleIllustrator
+	BookAuthorRoleEditor
+	BookAuthorRoleOriginalTranslator
+)
+
 type BookAuthor struct {
-	bookID BookID
-	author AuthorID
+	bookID   BookID
+	author   AuthorID
+	role     BookAuthorRole
+	position int
 }
 
 func NewBookAuthor(
 	bookID BookID,
 	authorID AuthorID,
+	role BookAuthorRole,
+	position int,
 ) BookAuthor {
 	return BookAuthor{
-		bookID: bookID,
-		author: authorID,
+		bookID:   bookID,
+		author:   authorID,
+		role:     role,
+		position: position,
 	}
 }
 
@@ -22,3 +38,20 @@ func (bookAuthor *BookAuthor) BookID() BookID {
 func (bookAuthor *BookAuthor) Author() AuthorID {
 	return bookAuthor.author
 }
+
+func (bookAuthor *BookAuthor) Role() BookAuthorRole {
+	return bookAuthor.role
+}
+
+// Position - порядковый номер автора среди авторов книги, начиная с нуля
+func (bookAuthor *BookAuthor) Position() int {
+	return bookAuthor.position
+}
+
+func (bookAuthor *BookAuthor) SetRole(role BookAuthorRole) {
+	bookAuthor.role = role
+}
+
+func (bookAuthor *BookAuthor) SetPosition(position int) {
+	bookAuthor.position = position
+}
@@ -1,19 +1,27 @@
 package model
 
+import (
+	"strings"
+	"unicode"
+)
+
 type BookChapterTranslation struct {
 	bookChapterID BookChapterID
 	translatorID  UserID
+	language      string
 	text          string
 }
 
 func NewBookChapterTranslation(
 	bookChapterID BookChapterID,
 	translatorID UserID,
+	language string,
 	text string,
 ) BookChapterTranslation {
 	return BookChapterTranslation{
 		bookChapterID: bookChapterID,
 		translatorID:  translatorID,
+		language:      language,
 		text:          text,
 	}
 }
@@ -26,10 +34,31 @@ func (bookChapterTranslation *BookChapterTranslation) TranslatorID() UserID {
 	return bookChapterTranslation.translatorID
 }
 
+// Language - код языка перевода по ISO 639-1, один переводчик может перевести главу на несколько языков
+func (bookChapterTranslation *BookChapterTranslation) Language() string {
+	return bookChapterTranslation.language
+}
+
 func (bookChapterTranslation *BookChapterTranslation) Text() string {
 	return bookChapterTranslation.text
 }
 
+// HTML - отрисованный текст перевода, безопасный для вставки в страницу
+func (bookChapterTranslation *BookChapterTranslation) HTML() string {
+	return RenderTranslationMarkup(bookChapterTranslation.text)
+}
+
+func (bookChapterTranslation *BookChapterTranslation) WordCount() int {
+	return CountWords(bookChapterTranslation.text)
+}
+
 func (bookChapterTranslation *BookChapterTranslation) SetText(text string) {
 	bookChapterTranslation.text = text
 }
+
+// CountWords считает слова как последовательности букв и цифр, дефис и апостроф не разрывают слово
+func CountWords(text string) int {
+	return len(strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '\''
+	}))
+}
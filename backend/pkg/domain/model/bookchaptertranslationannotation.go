@@ -0,0 +1,203 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// annotationAnchorPrefixLength - сколько символов перед фрагментом запоминается, чтобы найти его после правки перевода
+const annotationAnchorPrefixLength = 32
+
+const maxAnnotationAnchorLength = 1024
+
+type BookChapterTranslationAnnotationID = uuid.UUID
+
+// TranslationAnnotationAnchor - место примечания в переводе: номер абзаца, смещение в символах
+// от начала абзаца и выделенный фрагмент, может быть пустым для примечания к точке текста
+type TranslationAnnotationAnchor struct {
+	ParagraphIndex int
+	Offset         int
+	Text           string
+	Prefix         string
+}
+
+// NewTranslationAnnotationAnchor проверяет, что фрагмент лежит внутри абзаца перевода, и запоминает его текст
+func NewTranslationAnnotationAnchor(translationText string, paragraphIndex, offset, length int) (TranslationAnnotationAnchor, error) {
+	paragraphs := TranslationParagraphs(translationText)
+	if paragraphIndex < 0 || paragraphIndex >= len(paragraphs) || offset < 0 || length < 0 || length > maxAnnotationAnchorLength {
+		return TranslationAnnotationAnchor{}, ErrInvalidAnnotationAnchor
+	}
+	paragraph := []rune(paragraphs[paragraphIndex])
+	if offset+length > len(paragraph) {
+		return TranslationAnnotationAnchor{}, ErrInvalidAnnotationAnchor
+	}
+
+	return TranslationAnnotationAnchor{
+		ParagraphIndex: paragraphIndex,
+		Offset:         offset,
+		Text:           string(paragraph[offset : offset+length]),
+		Prefix:         string(paragraph[max(0, offset-annotationAnchorPrefixLength):offset]),
+	}, nil
+}
+
+func (anchor TranslationAnnotationAnchor) Length() int {
+	return utf8.RuneCountInString(anchor.Text)
+}
+
+// Relocate ищет якорь в новой редакции перевода: сначала фрагмент вместе с предшествующим текстом,
+// затем только фрагмент. Из нескольких совпадений выбирается ближайшее к прежнему месту
+func (anchor TranslationAnnotationAnchor) Relocate(paragraphs []string) (TranslationAnnotationAnchor, bool) {
+	if anchor.Text == "" && anchor.Prefix == "" {
+		if anchor.ParagraphIndex < len(paragraphs) {
+			return anchor, true
+		}
+		return TranslationAnnotationAnchor{}, false
+	}
+
+	type candidate struct {
+		needle       string
+		prefixLength int
+	}
+	candidates := []candidate{{needle: anchor.Prefix + anchor.Text, prefixLength: utf8.RuneCountInString(anchor.Prefix)}}
+	if anchor.Text != "" && anchor.Prefix != "" {
+		candidates = append(candidates, candidate{needle: anchor.Text})
+	}
+
+	for _, c := range candidates {
+		bestParagraph, bestOffset := -1, 0
+		for index, paragraph := range paragraphs {
+			for _, offset := range runeIndexes(paragraph, c.needle) {
+				offset += c.prefixLength
+				if bestParagraph < 0 || anchor.closer(index, offset, bestParagraph, bestOffset) {
+					bestParagraph, bestOffset = index, offset
+				}
+			}
+		}
+		if bestParagraph >= 0 {
+			paragraph := []rune(paragraphs[bestParagraph])
+			return TranslationAnnotationAnchor{
+				ParagraphIndex: bestParagraph,
+				Offset:         bestOffset,
+				Text:           anchor.Text,
+				Prefix:         string(paragraph[max(0, bestOffset-annotationAnchorPrefixLength):bestOffset]),
+			}, true
+		}
+	}
+
+	return TranslationAnnotationAnchor{}, false
+}
+
+// closer сравнивает кандидатов сначала по удалённости абзаца, затем по смещению внутри него
+func (anchor TranslationAnnotationAnchor) closer(paragraphIndex, offset, otherParagraphIndex, otherOffset int) bool {
+	paragraphDistance := abs(anchor.ParagraphIndex - paragraphIndex)
+	otherParagraphDistance := abs(anchor.ParagraphIndex - otherParagraphIndex)
+	if paragraphDistance != otherParagraphDistance {
+		return paragraphDistance < otherParagraphDistance
+	}
+
+	return abs(anchor.Offset-offset) < abs(anchor.Offset-otherOffset)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func runeIndexes(text, needle string) []int {
+	var indexes []int
+	for start := 0; start <= len(text); {
+		index := strings.Index(text[start:], needle)
+		if index < 0 {
+			break
+		}
+		indexes = append(indexes, utf8.RuneCountInString(text[:start+index]))
+		_, size := utf8.DecodeRuneInString(text[start+index:])
+		start += index + max(size, 1)
+	}
+
+	return indexes
+}
+
+// BookChapterTranslationAnnotation - примечание переводчика к месту в его переводе главы.
+// Если после правки перевода якорь не удалось найти, примечание отвязывается и ждёт, пока переводчик укажет новое место
+type BookChapterTranslationAnnotation struct {
+	id            BookChapterTranslationAnnotationID
+	bookChapterID BookChapterID
+	translatorID  UserID
+	language      string
+	anchor        TranslationAnnotationAnchor
+	text          string
+	detached      bool
+	createdAt     time.Time
+}
+
+func NewBookChapterTranslationAnnotation(
+	id BookChapterTranslationAnnotationID,
+	bookChapterID BookChapterID,
+	translatorID UserID,
+	language string,
+	anchor TranslationAnnotationAnchor,
+	text string,
+	detached bool,
+	createdAt time.Time,
+) BookChapterTranslationAnnotation {
+	return BookChapterTranslationAnnotation{
+		id:            id,
+		bookChapterID: bookChapterID,
+		translatorID:  translatorID,
+		language:      language,
+		anchor:        anchor,
+		text:          text,
+		detached:      detached,
+		createdAt:     createdAt,
+	}
+}
+
+func (annotation *BookChapterTranslationAnnotation) ID() BookChapterTranslationAnnotationID {
+	return annotation.id
+}
+
+func (annotation *BookChapterTranslationAnnotation) BookChapterID() BookChapterID {
+	return annotation.bookChapterID
+}
+
+func (annotation *BookChapterTranslationAnnotation) TranslatorID() UserID {
+	return annotation.translatorID
+}
+
+func (annotation *BookChapterTranslationAnnotation) Language() string {
+	return annotation.language
+}
+
+func (annotation *BookChapterTranslationAnnotation) Anchor() TranslationAnnotationAnchor {
+	return annotation.anchor
+}
+
+func (annotation *BookChapterTranslationAnnotation) Text() string {
+	return annotation.text
+}
+
+func (annotation *BookChapterTranslationAnnotation) Detached() bool {
+	return annotation.detached
+}
+
+func (annotation *BookChapterTranslationAnnotation) CreatedAt() time.Time {
+	return annotation.createdAt
+}
+
+func (annotation *BookChapterTranslationAnnotation) SetText(text string) {
+	annotation.text = text
+}
+
+func (annotation *BookChapterTranslationAnnotation) SetAnchor(anchor TranslationAnnotationAnchor) {
+	annotation.anchor = anchor
+	annotation.detached = false
+}
+
+func (annotation *BookChapterTranslationAnnotation) Detach() {
+	annotation.detached = true
+}
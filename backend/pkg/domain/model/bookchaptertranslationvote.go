@@ -0,0 +1,52 @@
+package model
+
+const (
+	TranslationVoteUp   = 1
+	TranslationVoteDown = -1
+)
+
+// BookChapterTranslationVote - голос пользователя за перевод главы или против него,
+// сумма голосов определяет перевод, который читатель видит по умолчанию
+type BookChapterTranslationVote struct {
+	bookChapterID BookChapterID
+	translatorID  UserID
+	language      string
+	userID        UserID
+	value         int
+}
+
+func NewBookChapterTranslationVote(
+	bookChapterID BookChapterID,
+	translatorID UserID,
+	language string,
+	userID UserID,
+	value int,
+) BookChapterTranslationVote {
+	return BookChapterTranslationVote{
+		bookChapterID: bookChapterID,
+		translatorID:  translatorID,
+		language:      language,
+		userID:        userID,
+		value:         value,
+	}
+}
+
+func (vote *BookChapterTranslationVote) BookChapterID() BookChapterID {
+	return vote.bookChapterID
+}
+
+func (vote *BookChapterTranslationVote) TranslatorID() UserID {
+	return vote.translatorID
+}
+
+func (vote *BookChapterTranslationVote) Language() string {
+	return vote.language
+}
+
+func (vote *BookChapterTranslationVote) UserID() UserID {
+	return vote.userID
+}
+
+func (vote *BookChapterTranslationVote) Value() int {
+	return vote.value
+}
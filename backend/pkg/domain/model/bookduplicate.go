@@ -0,0 +1,149 @@
+package model
+
+import "sort"
+
+const (
+	// MaxBookDuplicates - сколько самых похожих книг возвращается как возможные дубликаты
+	MaxBookDuplicates = 10
+
+	bookTitleDuplicateSimilarity           = 0.85
+	bookTitleWithAuthorDuplicateSimilarity = 0.6
+)
+
+// BookFingerprint - название и авторы книги, по которым она сравнивается с другими книгами
+type BookFingerprint struct {
+	bookID    BookID
+	title     string
+	authorIDs []AuthorID
+}
+
+func NewBookFingerprint(bookID BookID, title string, authorIDs []AuthorID) BookFingerprint {
+	return BookFingerprint{
+		bookID:    bookID,
+		title:     title,
+		authorIDs: authorIDs,
+	}
+}
+
+func (fingerprint *BookFingerprint) BookID() BookID {
+	return fingerprint.bookID
+}
+
+func (fingerprint *BookFingerprint) Title() string {
+	return fingerprint.title
+}
+
+func (fingerprint *BookFingerprint) AuthorIDs() []AuthorID {
+	return fingerprint.authorIDs
+}
+
+// BookDuplicate - существующая книга, похожая на создаваемую или отправленную на проверку
+type BookDuplicate struct {
+	bookID          BookID
+	title           string
+	titleSimilarity float64
+	authorOverlap   float64
+	score           float64
+}
+
+func NewBookDuplicate(bookID BookID, title string, titleSimilarity, authorOverlap, score float64) BookDuplicate {
+	return BookDuplicate{
+		bookID:          bookID,
+		title:           title,
+		titleSimilarity: titleSimilarity,
+		authorOverlap:   authorOverlap,
+		score:           score,
+	}
+}
+
+func (duplicate *BookDuplicate) BookID() BookID {
+	return duplicate.bookID
+}
+
+func (duplicate *BookDuplicate) Title() string {
+	return duplicate.title
+}
+
+func (duplicate *BookDuplicate) TitleSimilarity() float64 {
+	return duplicate.titleSimilarity
+}
+
+// AuthorOverlap - доля общих авторов среди всех авторов обеих книг
+func (duplicate *BookDuplicate) AuthorOverlap() float64 {
+	return duplicate.authorOverlap
+}
+
+func (duplicate *BookDuplicate) Score() float64 {
+	return duplicate.score
+}
+
+// NormalizeBookTitle приводит название к латинице без регистра и знаков препинания
+func NormalizeBookTitle(title string) string {
+	return transliterateAndNormalize(title)
+}
+
+// FindBookDuplicates сравнивает книгу с существующими: похожее название считается дубликатом само по себе,
+// менее похожее - только при общем авторе. Результат отсортирован по убыванию похожести
+func FindBookDuplicates(book BookFingerprint, existing []BookFingerprint) []BookDuplicate {
+	title := NormalizeBookTitle(book.Title())
+
+	var duplicates []BookDuplicate
+	for _, other := range existing {
+		if other.BookID() == book.BookID() {
+			continue
+		}
+
+		titleSimilarity := StringSimilarity(title, NormalizeBookTitle(other.Title()))
+		overlap := authorOverlap(book.AuthorIDs(), other.AuthorIDs())
+		if titleSimilarity < bookTitleDuplicateSimilarity &&
+			(overlap == 0 || titleSimilarity < bookTitleWithAuthorDuplicateSimilarity) {
+			continue
+		}
+
+		// Если у одной из книг авторы ещё не указаны, судить можно только по названию
+		score := titleSimilarity
+		if len(book.AuthorIDs()) > 0 && len(other.AuthorIDs()) > 0 {
+			score = 0.75*titleSimilarity + 0.25*overlap
+		}
+
+		duplicates = append(duplicates, NewBookDuplicate(other.BookID(), other.Title(), titleSimilarity, overlap, score))
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score() > duplicates[j].Score()
+	})
+	if len(duplicates) > MaxBookDuplicates {
+		duplicates = duplicates[:MaxBookDuplicates]
+	}
+
+	return duplicates
+}
+
+func authorOverlap(first, second []AuthorID) float64 {
+	if len(first) == 0 || len(second) == 0 {
+		return 0
+	}
+
+	firstSet := make(map[AuthorID]struct{}, len(first))
+	for _, authorID := range first {
+		firstSet[authorID] = struct{}{}
+	}
+
+	union := len(firstSet)
+	common := 0
+	seen := make(map[AuthorID]struct{}, len(second))
+	for _, authorID := range second {
+		if _, ok := seen[authorID]; ok {
+			continue
+		}
+		seen[authorID] = struct{}{}
+
+		if _, ok := firstSet[authorID]; ok {
+			common++
+		} else {
+			union++
+		}
+	}
+
+	return float64(common) / float64(union)
+}
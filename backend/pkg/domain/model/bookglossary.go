@@ -0,0 +1,305 @@
+package model
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// glossaryVariantSimilarity - порог похожести, начиная с которого слово считается другим написанием термина
+const glossaryVariantSimilarity = 0.8
+
+// minGlossaryVariantLength - у более коротких терминов похожими оказываются обычные слова, их варианты не ищутся
+const minGlossaryVariantLength = 4
+
+// maxGlossaryEndingLength - сколько букв окончания может отличаться у склонённой формы термина
+const maxGlossaryEndingLength = 3
+
+const MaxGlossaryTermLength = 255
+
+type BookGlossaryTermID = uuid.UUID
+
+// BookGlossaryTerm - принятый перевод имени или термина книги. Общие термины видят все переводчики книги,
+// личный термин виден только его автору и заменяет общий термин с тем же исходным написанием
+type BookGlossaryTerm struct {
+	id         BookGlossaryTermID
+	bookID     BookID
+	language   string
+	sourceTerm string
+	targetTerm string
+	notes      maybe.Maybe[string]
+	authorID   UserID
+	isShared   bool
+	createdAt  time.Time
+}
+
+func NewBookGlossaryTerm(
+	id BookGlossaryTermID,
+	bookID BookID,
+	language string,
+	sourceTerm string,
+	targetTerm string,
+	notes maybe.Maybe[string],
+	authorID UserID,
+	isShared bool,
+	createdAt time.Time,
+) BookGlossaryTerm {
+	return BookGlossaryTerm{
+		id:         id,
+		bookID:     bookID,
+		language:   language,
+		sourceTerm: sourceTerm,
+		targetTerm: targetTerm,
+		notes:      notes,
+		authorID:   authorID,
+		isShared:   isShared,
+		createdAt:  createdAt,
+	}
+}
+
+func (term *BookGlossaryTerm) ID() BookGlossaryTermID {
+	return term.id
+}
+
+func (term *BookGlossaryTerm) BookID() BookID {
+	return term.bookID
+}
+
+func (term *BookGlossaryTerm) Language() string {
+	return term.language
+}
+
+func (term *BookGlossaryTerm) SourceTerm() string {
+	return term.sourceTerm
+}
+
+func (term *BookGlossaryTerm) TargetTerm() string {
+	return term.targetTerm
+}
+
+func (term *BookGlossaryTerm) Notes() maybe.Maybe[string] {
+	return term.notes
+}
+
+func (term *BookGlossaryTerm) AuthorID() UserID {
+	return term.authorID
+}
+
+func (term *BookGlossaryTerm) IsShared() bool {
+	return term.isShared
+}
+
+func (term *BookGlossaryTerm) CreatedAt() time.Time {
+	return term.createdAt
+}
+
+func (term *BookGlossaryTerm) SetSourceTerm(sourceTerm string) {
+	term.sourceTerm = sourceTerm
+}
+
+func (term *BookGlossaryTerm) SetTargetTerm(targetTerm string) {
+	term.targetTerm = targetTerm
+}
+
+func (term *BookGlossaryTerm) SetNotes(notes maybe.Maybe[string]) {
+	term.notes = notes
+}
+
+func (term *BookGlossaryTerm) SetIsShared(isShared bool) {
+	term.isShared = isShared
+}
+
+// NormalizeGlossaryTerm убирает лишние пробелы, пустой или слишком длинный термин - ошибка
+func NormalizeGlossaryTerm(term string) (string, error) {
+	normalized := strings.Join(strings.Fields(term), " ")
+	if normalized == "" || utf8.RuneCountInString(normalized) > MaxGlossaryTermLength {
+		return "", ErrInvalidGlossaryTerm
+	}
+
+	return normalized, nil
+}
+
+// SameGlossaryScope - термины конфликтуют, если совпадает исходное написание и оба общие или оба личные одного автора
+func SameGlossaryScope(first, second BookGlossaryTerm) bool {
+	if first.language != second.language || !strings.EqualFold(first.sourceTerm, second.sourceTerm) {
+		return false
+	}
+	if first.isShared || second.isShared {
+		return first.isShared == second.isShared
+	}
+
+	return first.authorID == second.authorID
+}
+
+// ApplicableGlossaryTerms возвращает термины, которыми пользуется переводчик: общие и его личные,
+// личный термин заменяет общий с тем же исходным написанием
+func ApplicableGlossaryTerms(terms []BookGlossaryTerm, translatorID maybe.Maybe[UserID]) []BookGlossaryTerm {
+	personal := make(map[string]struct{})
+	for _, term := range terms {
+		if id, ok := translatorID.Get(); ok && !term.isShared && term.authorID == id {
+			personal[term.language+":"+strings.ToLower(term.sourceTerm)] = struct{}{}
+		}
+	}
+
+	var applicable []BookGlossaryTerm
+	for _, term := range terms {
+		if term.isShared {
+			if _, ok := personal[term.language+":"+strings.ToLower(term.sourceTerm)]; ok {
+				continue
+			}
+			applicable = append(applicable, term)
+			continue
+		}
+		if id, ok := translatorID.Get(); ok && term.authorID == id {
+			applicable = append(applicable, term)
+		}
+	}
+
+	return applicable
+}
+
+type GlossaryIssueType int
+
+const (
+	// GlossaryVariant - в тексте встречается написание, похожее на термин глоссария, но отличающееся от него
+	GlossaryVariant GlossaryIssueType = iota
+	// GlossaryUntranslated - в тексте остался термин в написании оригинала
+	GlossaryUntranslated
+)
+
+type GlossaryIssue struct {
+	Term           BookGlossaryTerm
+	Type           GlossaryIssueType
+	Found          string
+	Count          int
+	ParagraphIndex int
+}
+
+type glossaryWord struct {
+	text       string
+	normalized string
+}
+
+// CheckGlossaryConsistency ищет в переводе написания терминов, расходящиеся с глоссарием.
+// Склонённые формы термина (отличающиеся окончанием) расхождением не считаются
+func CheckGlossaryConsistency(terms []BookGlossaryTerm, text string) []GlossaryIssue {
+	var issues []GlossaryIssue
+	issueIndexes := make(map[string]int)
+	addIssue := func(term BookGlossaryTerm, issueType GlossaryIssueType, found []glossaryWord, paragraphIndex int) {
+		texts := make([]string, len(found))
+		for i, word := range found {
+			texts[i] = word.text
+		}
+		foundText := strings.Join(texts, " ")
+
+		key := fmt.Sprintf("%s:%d:%s", term.id, issueType, strings.ToLower(foundText))
+		if index, ok := issueIndexes[key]; ok {
+			issues[index].Count++
+			return
+		}
+		issueIndexes[key] = len(issues)
+		issues = append(issues, GlossaryIssue{
+			Term:           term,
+			Type:           issueType,
+			Found:          foundText,
+			Count:          1,
+			ParagraphIndex: paragraphIndex,
+		})
+	}
+
+	for paragraphIndex, paragraph := range TranslationParagraphs(text) {
+		words := splitGlossaryWords(paragraph)
+		for _, term := range terms {
+			target := splitGlossaryWords(term.targetTerm)
+			source := splitGlossaryWords(term.sourceTerm)
+			checkSource := len(source) > 0 && !strings.EqualFold(term.sourceTerm, term.targetTerm)
+			checkVariants := utf8.RuneCountInString(term.targetTerm) >= minGlossaryVariantLength
+
+			for i := range words {
+				if len(target) > 0 && i+len(target) <= len(words) {
+					candidate := words[i : i+len(target)]
+					if !matchGlossaryWords(candidate, target) && checkVariants && similarGlossaryWords(candidate, target) {
+						addIssue(term, GlossaryVariant, candidate, paragraphIndex)
+					}
+				}
+				if checkSource && i+len(source) <= len(words) {
+					candidate := words[i : i+len(source)]
+					if matchGlossaryWords(candidate, source) && !matchGlossaryWords(candidate, target) {
+						addIssue(term, GlossaryUntranslated, candidate, paragraphIndex)
+					}
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func splitGlossaryWords(text string) []glossaryWord {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '\''
+	})
+
+	words := make([]glossaryWord, len(fields))
+	for i, field := range fields {
+		words[i] = glossaryWord{text: field, normalized: strings.ToLower(field)}
+	}
+
+	return words
+}
+
+func matchGlossaryWords(candidate, term []glossaryWord) bool {
+	if len(candidate) != len(term) {
+		return false
+	}
+	for i := range term {
+		if !isGlossaryWordForm(candidate[i].normalized, term[i].normalized) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// similarGlossaryWords сравнивает основы слов, чтобы падежное окончание не снижало похожесть
+func similarGlossaryWords(candidate, term []glossaryWord) bool {
+	if len(candidate) != len(term) {
+		return false
+	}
+	for i := range term {
+		candidateStem := glossaryStem(candidate[i].normalized)
+		termStem := glossaryStem(term[i].normalized)
+		if candidateStem == termStem {
+			continue
+		}
+		if []rune(candidateStem)[0] != []rune(termStem)[0] || StringSimilarity(candidateStem, termStem) < glossaryVariantSimilarity {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isGlossaryWordForm - слово совпадает с термином или отличается от него только окончанием
+func isGlossaryWordForm(word, term string) bool {
+	if word == term {
+		return true
+	}
+
+	stem := glossaryStem(term)
+	return strings.HasPrefix(word, stem) && utf8.RuneCountInString(word)-utf8.RuneCountInString(stem) <= maxGlossaryEndingLength
+}
+
+// glossaryStem отбрасывает до двух конечных гласных, чтобы "Анна" и "Анну" имели общую основу
+func glossaryStem(word string) string {
+	runes := []rune(word)
+	for i := 0; i < 2 && len(runes) > 3 && strings.ContainsRune("аеёиоуыэюяьйaeiouy", runes[len(runes)-1]); i++ {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"testing"
+	"time"
+)
+
+func newTestGlossaryTerm(sourceTerm, targetTerm string) BookGlossaryTerm {
+	return NewBookGlossaryTerm(
+		uuid.Must(uuid.NewV4()),
+		uuid.Must(uuid.NewV4()),
+		"ru",
+		sourceTerm,
+		targetTerm,
+		maybe.Nothing[string](),
+		UserID(uuid.Must(uuid.NewV4())),
+		true,
+		time.Now(),
+	)
+}
+
+func TestGlossaryStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "анна", want: "анн"},
+		{word: "анну", want: "анн"},
+		{word: "гермиона", want: "гермион"},
+		{word: "тёмный", want: "тёмн"},
+		{word: "лорд", want: "лорд"},
+		{word: "рон", want: "рон"},
+		{word: "ива", want: "ива"},
+	}
+
+	for _, test := range tests {
+		if got := glossaryStem(test.word); got != test.want {
+			t.Errorf("glossaryStem(%q) = %q, want %q", test.word, got, test.want)
+		}
+	}
+}
+
+func TestIsGlossaryWordForm(t *testing.T) {
+	tests := []struct {
+		word string
+		term string
+		want bool
+	}{
+		{word: "гермиона", term: "гермиона", want: true},
+		{word: "гермиону", term: "гермиона", want: true},
+		{word: "гермионой", term: "гермиона", want: true},
+		{word: "тёмного", term: "тёмный", want: true},
+		{word: "лордами", term: "лорд", want: true},
+		{word: "лордовского", term: "лорд", want: false},
+		{word: "гормиона", term: "гермиона", want: false},
+	}
+
+	for _, test := range tests {
+		if got := isGlossaryWordForm(test.word, test.term); got != test.want {
+			t.Errorf("isGlossaryWordForm(%q, %q) = %v, want %v", test.word, test.term, got, test.want)
+		}
+	}
+}
+
+func TestCheckGlossaryConsistency(t *testing.T) {
+	hermione := newTestGlossaryTerm("Hermione", "Гермиона")
+	ron := newTestGlossaryTerm("Ron", "Рон")
+	darkLord := newTestGlossaryTerm("Dark Lord", "Тёмный Лорд")
+	hogwarts := newTestGlossaryTerm("Hogwarts", "Hogwarts")
+
+	type issue struct {
+		term           BookGlossaryTerm
+		issueType      GlossaryIssueType
+		found          string
+		count          int
+		paragraphIndex int
+	}
+
+	tests := []struct {
+		name  string
+		terms []BookGlossaryTerm
+		text  string
+		want  []issue
+	}{
+		{
+			name:  "exact and declined forms",
+			terms: []BookGlossaryTerm{hermione, darkLord},
+			text:  "Гермиона боялась, что Тёмного Лорда не остановить.\n\nОн увидел Гермиону.",
+		},
+		{
+			name:  "variant spelling",
+			terms: []BookGlossaryTerm{hermione},
+			text:  "Гормиона пришла.\n\nГермиона ушла, а Гормиона осталась.",
+			want:  []issue{{term: hermione, issueType: GlossaryVariant, found: "Гормиона", count: 2, paragraphIndex: 0}},
+		},
+		{
+			name:  "variant with another first letter is a different word",
+			terms: []BookGlossaryTerm{hermione},
+			text:  "Хермиона пришла.",
+		},
+		{
+			name:  "short terms have no variants",
+			terms: []BookGlossaryTerm{ron},
+			text:  "Рен пришёл.",
+		},
+		{
+			name:  "untranslated term",
+			terms: []BookGlossaryTerm{hermione},
+			text:  "Он увидел Hermione.",
+			want:  []issue{{term: hermione, issueType: GlossaryUntranslated, found: "Hermione", count: 1, paragraphIndex: 0}},
+		},
+		{
+			name:  "untranslated multi-word term",
+			terms: []BookGlossaryTerm{darkLord},
+			text:  "Первый абзац.\n\nThe Dark Lord вернулся.",
+			want:  []issue{{term: darkLord, issueType: GlossaryUntranslated, found: "Dark Lord", count: 1, paragraphIndex: 1}},
+		},
+		{
+			name:  "term kept as in original",
+			terms: []BookGlossaryTerm{hogwarts},
+			text:  "Он вернулся в Hogwarts.",
+		},
+	}
+
+	for _, test := range tests {
+		got := CheckGlossaryConsistency(test.terms, test.text)
+		if len(got) != len(test.want) {
+			t.Errorf("%s: CheckGlossaryConsistency() returned %d issues, want %d: %+v", test.name, len(got), len(test.want), got)
+			continue
+		}
+		for i, want := range test.want {
+			if got[i].Term.ID() != want.term.ID() ||
+				got[i].Type != want.issueType ||
+				got[i].Found != want.found ||
+				got[i].Count != want.count ||
+				got[i].ParagraphIndex != want.paragraphIndex {
+				t.Errorf("%s: issue %d = %+v, want %+v", test.name, i, got[i], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+// BookLocalization - название и описание книги на одном из языков, оригинальные хранятся в самой книге
+type BookLocalization struct {
+	bookID      BookID
+	language    string
+	title       string
+	description string
+}
+
+func NewBookLocalization(
+	bookID BookID,
+	language string,
+	title string,
+	description string,
+) BookLocalization {
+	return BookLocalization{
+		bookID:      bookID,
+		language:    language,
+		title:       title,
+		description: description,
+	}
+}
+
+func (localization *BookLocalization) BookID() BookID {
+	return localization.bookID
+}
+
+func (localization *BookLocalization) Language() string {
+	return localization.language
+}
+
+func (localization *BookLocalization) Title() string {
+	return localization.title
+}
+
+func (localization *BookLocalization) Description() string {
+	return localization.description
+}
@@ -3,30 +3,75 @@ package model
 import "errors"
 
 var (
-	ErrUserNotFound   = errors.New("user not found")
-	ErrNotDeleteAdmin = errors.New("not delete admin")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrNotDeleteAdmin  = errors.New("not delete admin")
+	ErrInvalidPassword = errors.New("invalid password")
 
-	ErrBookNotFound = errors.New("book not found")
+	ErrBookNotFound             = errors.New("book not found")
+	ErrBookLocalizationNotFound = errors.New("book localization not found")
 
-	ErrBookChapterNotFound = errors.New("book chapter not found")
+	ErrBookChapterNotFound             = errors.New("book chapter not found")
+	ErrBookChapterOriginalTextNotFound = errors.New("book chapter original text not found")
+	ErrNotBookOwner                    = errors.New("not book owner")
 
-	ErrBookChapterTranslationNotFound = errors.New("book chapter translation not found")
+	ErrBookChapterTranslationNotFound     = errors.New("book chapter translation not found")
+	ErrBookChapterTranslationVoteNotFound = errors.New("book chapter translation vote not found")
+	ErrInvalidTranslationVote             = errors.New("invalid translation vote")
+	ErrVoteOwnTranslation                 = errors.New("vote own translation")
+	ErrInvalidTranslationMarkup           = errors.New("invalid translation markup")
+	ErrUnreviewedMachineDraft             = errors.New("unreviewed machine draft")
+	ErrMachineTranslationFailed           = errors.New("machine translation failed")
+	ErrMachineTranslationSameLanguage     = errors.New("machine translation same language")
+
+	ErrBookChapterTranslationAnnotationNotFound = errors.New("book chapter translation annotation not found")
+	ErrInvalidAnnotationAnchor                  = errors.New("invalid annotation anchor")
+	ErrEmptyAnnotationText                      = errors.New("empty annotation text")
+	ErrNotAnnotationTranslator                  = errors.New("not annotation translator")
+
+	ErrBookGlossaryTermNotFound = errors.New("book glossary term not found")
+	ErrInvalidGlossaryTerm      = errors.New("invalid glossary term")
+	ErrGlossaryTermExists       = errors.New("glossary term exists")
+	ErrNotGlossaryTermAuthor    = errors.New("not glossary term author")
 
 	ErrVerifyBookRequestNotFound = errors.New("verify book request not found")
 
 	ErrBookRatingNotFound = errors.New("book rating not found")
 
-	ErrSessionReadingNotFound = errors.New("session reading not found")
+	ErrSessionReadingNotFound       = errors.New("session reading not found")
+	ErrPreferredTranslatorNotFound  = errors.New("preferred translator not found")
+	ErrTranslatorNotTranslatingBook = errors.New("translator not translating book")
 
 	ErrImageNotFound = errors.New("image not found")
 
 	ErrUserBookFavouritesNotFound = errors.New("user book favourites not found")
 
-	ErrAuthorNotFound = errors.New("author not found")
+	ErrAuthorNotFound         = errors.New("author not found")
+	ErrInvalidAuthorLifeYears = errors.New("invalid author life years")
+	ErrMergeAuthorIntoItself  = errors.New("merge author into itself")
 
-	ErrGenreNotFound = errors.New("genre not found")
+	ErrGenreNotFound    = errors.New("genre not found")
+	ErrGenreParentCycle = errors.New("genre parent cycle")
 
 	ErrBookGenreNotFound = errors.New("book genre not found")
 
-	ErrBookAuthorNotFound = errors.New("book author not found")
+	ErrBookAuthorNotFound     = errors.New("book author not found")
+	ErrInvalidBookAuthorOrder = errors.New("invalid book author order")
+
+	ErrUserRestrictionNotFound = errors.New("user restriction not found")
+	ErrNotRestrictAdmin        = errors.New("not restrict admin")
+	ErrNotRestrictModerator    = errors.New("not restrict moderator")
+	ErrNotRestrictSelf         = errors.New("not restrict self")
+	ErrUserSuspended           = errors.New("user suspended")
+	ErrUserMuted               = errors.New("user muted")
+
+	ErrUserDataExportNotFound = errors.New("user data export not found")
+	ErrUserDataExportNotReady = errors.New("user data export not ready")
+	ErrUserDataExportPending  = errors.New("user data export pending")
+
+	ErrInvalidLanguageCode = errors.New("invalid language code")
+
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagRejected     = errors.New("tag rejected")
+	ErrInvalidTagName  = errors.New("invalid tag name")
+	ErrBookTagNotFound = errors.New("book tag not found")
 )
@@ -1,21 +1,27 @@
 package model
 
-import "github.com/gofrs/uuid"
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+)
 
 type GenreID = uuid.UUID
 
 type Genre struct {
-	id   GenreID
-	name string
+	id       GenreID
+	parentID maybe.Maybe[GenreID]
+	name     string
 }
 
 func NewGenre(
 	id GenreID,
+	parentID maybe.Maybe[GenreID],
 	name string,
 ) Genre {
 	return Genre{
-		id:   id,
-		name: name,
+		id:       id,
+		parentID: parentID,
+		name:     name,
 	}
 }
 
@@ -23,10 +29,18 @@ func (genre *Genre) ID() GenreID {
 	return genre.id
 }
 
+func (genre *Genre) ParentID() maybe.Maybe[GenreID] {
+	return genre.parentID
+}
+
 func (genre *Genre) Name() string {
 	return genre.name
 }
 
+func (genre *Genre) SetParentID(parentID maybe.Maybe[GenreID]) {
+	genre.parentID = parentID
+}
+
 func (genre *Genre) SetName(name string) {
 	genre.name = name
 }
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// machineDraftMarker начинает абзац-пометку машинного черновика, перевод с такой пометкой сохранить нельзя
+const machineDraftMarker = "*Машинный черновик"
+
+const machineDraftNotice = machineDraftMarker + " (%s). Отредактируйте перевод и удалите этот абзац перед сохранением*"
+
+// MachineDraftSegments возвращает тексты абзацев и сносок оригинала, которые нужно перевести, смены сцен не переводятся
+func MachineDraftSegments(blocks []TranslationBlock) []string {
+	var segments []string
+	for _, block := range blocks {
+		if block.Type != TranslationSceneBreak {
+			segments = append(segments, block.Text)
+		}
+	}
+
+	return segments
+}
+
+// BuildMachineDraft собирает черновик из переведённых сегментов в порядке MachineDraftSegments,
+// сохраняя смены сцен и сноски оригинала, и первым абзацем добавляет пометку с названием переводчика
+func BuildMachineDraft(blocks []TranslationBlock, translatedSegments []string, providerName string) string {
+	parts := []string{fmt.Sprintf(machineDraftNotice, providerName)}
+	segmentIndex := 0
+	for _, block := range blocks {
+		switch block.Type {
+		case TranslationSceneBreak:
+			parts = append(parts, block.Text)
+		case TranslationFootnoteDefinition:
+			parts = append(parts, fmt.Sprintf("[^%s]: %s", block.FootnoteID, translatedSegments[segmentIndex]))
+			segmentIndex++
+		default:
+			parts = append(parts, translatedSegments[segmentIndex])
+			segmentIndex++
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// IsMachineDraft - в тексте осталась пометка машинного черновика, то есть переводчик его ещё не вычитал
+func IsMachineDraft(text string) bool {
+	for _, paragraph := range TranslationParagraphs(text) {
+		if strings.HasPrefix(strings.TrimSpace(paragraph), machineDraftMarker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Штрафы за объединение и пропуск абзацев при выравнивании: без них выгоднее было бы
+// склеить всё в несколько больших строк с удачным соотношением длин
+const (
+	paragraphMergePenalty = 0.25
+	paragraphSkipPenalty  = 0.5
+)
+
+// AlignedParagraphs - строка параллельного текста: номера абзацев оригинала и соответствующих им абзацев перевода.
+// Одна из сторон пуста, если абзац не переведён или добавлен переводчиком
+type AlignedParagraphs struct {
+	Original    []int
+	Translation []int
+}
+
+type paragraphAlignmentStep struct {
+	original    int
+	translation int
+	penalty     float64
+}
+
+// Допустимые шаги выравнивания: абзац в абзац, объединение двух абзацев одной стороны и пропуск абзаца
+var paragraphAlignmentSteps = []paragraphAlignmentStep{
+	{original: 1, translation: 1},
+	{original: 2, translation: 1, penalty: paragraphMergePenalty},
+	{original: 1, translation: 2, penalty: paragraphMergePenalty},
+	{original: 1, translation: 0, penalty: paragraphSkipPenalty},
+	{original: 0, translation: 1, penalty: paragraphSkipPenalty},
+}
+
+// paragraphAlignmentBand - на сколько абзацев выравнивание может отойти от диагонали. Вычисляется только полоса
+// вокруг диагонали, поэтому память и время растут линейно от числа абзацев, а не от их произведения
+const paragraphAlignmentBand = 20
+
+// AlignParagraphs сопоставляет абзацы оригинала и перевода по длине, как в алгоритме Гейла-Чёрча:
+// длины абзацев перевода сравниваются с длинами абзацев оригинала с поправкой на общее соотношение длин текстов.
+// При одинаковом числе абзацев близкой длины каждый абзац оригинала получает абзац перевода с тем же номером
+func AlignParagraphs(original, translation []string) []AlignedParagraphs {
+	originalLengths := paragraphLengths(original)
+	translationLengths := paragraphLengths(translation)
+
+	ratio := 1.0
+	if total := originalLengths[len(original)]; total > 0 && translationLengths[len(translation)] > 0 {
+		ratio = float64(translationLengths[len(translation)]) / float64(total)
+	}
+
+	band := newParagraphAlignmentBand(len(original), len(translation))
+	costs := make([][]float64, len(original)+1)
+	steps := make([][]int, len(original)+1)
+	for i := range costs {
+		costs[i] = make([]float64, band.to[i]-band.from[i]+1)
+		steps[i] = make([]int, len(costs[i]))
+		for j := range costs[i] {
+			costs[i][j] = math.Inf(1)
+		}
+	}
+	costs[0][0] = 0
+
+	for i := 0; i <= len(original); i++ {
+		for j := band.from[i]; j <= band.to[i]; j++ {
+			for stepIndex, step := range paragraphAlignmentSteps {
+				fromI, fromJ := i-step.original, j-step.translation
+				if fromI < 0 || !band.contains(fromI, fromJ) || math.IsInf(costs[fromI][fromJ-band.from[fromI]], 1) {
+					continue
+				}
+
+				cost := costs[fromI][fromJ-band.from[fromI]] + step.penalty + paragraphLengthCost(
+					float64(originalLengths[i]-originalLengths[fromI])*ratio,
+					float64(translationLengths[j]-translationLengths[fromJ]),
+				)
+				if cost < costs[i][j-band.from[i]] {
+					costs[i][j-band.from[i]] = cost
+					steps[i][j-band.from[i]] = stepIndex
+				}
+			}
+		}
+	}
+
+	var rows []AlignedParagraphs
+	for i, j := len(original), len(translation); i > 0 || j > 0; {
+		step := paragraphAlignmentSteps[steps[i][j-band.from[i]]]
+		rows = append(rows, AlignedParagraphs{
+			Original:    paragraphRange(i-step.original, i),
+			Translation: paragraphRange(j-step.translation, j),
+		})
+		i, j = i-step.original, j-step.translation
+	}
+
+	for left, right := 0, len(rows)-1; left < right; left, right = left+1, right-1 {
+		rows[left], rows[right] = rows[right], rows[left]
+	}
+
+	return rows
+}
+
+// alignmentBand - для каждого числа абзацев оригинала диапазон чисел абзацев перевода, которые вычисляются
+type alignmentBand struct {
+	from []int
+	to   []int
+}
+
+// newParagraphAlignmentBand строит полосу вокруг диагонали от (0, 0) до (originalCount, translationCount).
+// Полоса расширена на число абзацев перевода, приходящееся на один абзац оригинала, чтобы соседние строки
+// перекрывались и путь выравнивания не обрывался
+func newParagraphAlignmentBand(originalCount, translationCount int) alignmentBand {
+	band := alignmentBand{from: make([]int, originalCount+1), to: make([]int, originalCount+1)}
+	if originalCount == 0 {
+		band.to[0] = translationCount
+		return band
+	}
+
+	width := paragraphAlignmentBand + (translationCount+originalCount-1)/originalCount
+	for i := range band.from {
+		center := i * translationCount / originalCount
+		band.from[i] = max(0, center-width)
+		band.to[i] = min(translationCount, center+width)
+	}
+
+	return band
+}
+
+func (band alignmentBand) contains(i, j int) bool {
+	return j >= band.from[i] && j <= band.to[i]
+}
+
+// paragraphLengths возвращает длины в символах нарастающим итогом, первый элемент - ноль
+func paragraphLengths(paragraphs []string) []int {
+	lengths := make([]int, len(paragraphs)+1)
+	for i, paragraph := range paragraphs {
+		lengths[i+1] = lengths[i] + utf8.RuneCountInString(paragraph)
+	}
+
+	return lengths
+}
+
+// paragraphLengthCost - относительная разница ожидаемой и фактической длины перевода от 0 до 1
+func paragraphLengthCost(expected, actual float64) float64 {
+	if expected+actual == 0 {
+		return 0
+	}
+
+	return math.Abs(expected-actual) / (expected + actual)
+}
+
+func paragraphRange(from, to int) []int {
+	indexes := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
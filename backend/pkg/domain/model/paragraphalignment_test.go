@@ -0,0 +1,121 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAlignParagraphs(t *testing.T) {
+	tests := []struct {
+		name        string
+		original    []string
+		translation []string
+		want        []AlignedParagraphs
+	}{
+		{
+			name:        "one to one",
+			original:    []string{strings.Repeat("a", 100), strings.Repeat("b", 40), strings.Repeat("c", 200)},
+			translation: []string{strings.Repeat("x", 110), strings.Repeat("y", 45), strings.Repeat("z", 210)},
+			want: []AlignedParagraphs{
+				{Original: []int{0}, Translation: []int{0}},
+				{Original: []int{1}, Translation: []int{1}},
+				{Original: []int{2}, Translation: []int{2}},
+			},
+		},
+		{
+			name:        "two original paragraphs merged in translation",
+			original:    []string{strings.Repeat("a", 100), strings.Repeat("b", 100), strings.Repeat("c", 100)},
+			translation: []string{strings.Repeat("x", 100), strings.Repeat("y", 200)},
+			want: []AlignedParagraphs{
+				{Original: []int{0}, Translation: []int{0}},
+				{Original: []int{1, 2}, Translation: []int{1}},
+			},
+		},
+		{
+			name:        "original paragraph split in translation",
+			original:    []string{strings.Repeat("a", 200), strings.Repeat("b", 100)},
+			translation: []string{strings.Repeat("x", 100), strings.Repeat("y", 100), strings.Repeat("z", 100)},
+			want: []AlignedParagraphs{
+				{Original: []int{0}, Translation: []int{0, 1}},
+				{Original: []int{1}, Translation: []int{2}},
+			},
+		},
+		{
+			name:        "paragraph added by translator",
+			original:    []string{strings.Repeat("a", 300)},
+			translation: []string{strings.Repeat("x", 150), strings.Repeat("y", 150), strings.Repeat("n", 20)},
+			want: []AlignedParagraphs{
+				{Original: []int{0}, Translation: []int{0, 1}},
+				{Original: []int{}, Translation: []int{2}},
+			},
+		},
+		{
+			name:        "untranslated paragraph",
+			original:    []string{strings.Repeat("a", 150), strings.Repeat("b", 150), strings.Repeat("n", 20)},
+			translation: []string{strings.Repeat("x", 300)},
+			want: []AlignedParagraphs{
+				{Original: []int{0, 1}, Translation: []int{0}},
+				{Original: []int{2}, Translation: []int{}},
+			},
+		},
+		{
+			name:        "empty translation",
+			original:    []string{"a", "b"},
+			translation: nil,
+			want: []AlignedParagraphs{
+				{Original: []int{0}, Translation: []int{}},
+				{Original: []int{1}, Translation: []int{}},
+			},
+		},
+		{
+			name:        "empty original",
+			original:    nil,
+			translation: []string{"x"},
+			want: []AlignedParagraphs{
+				{Original: []int{}, Translation: []int{0}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if got := AlignParagraphs(test.original, test.translation); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: AlignParagraphs() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAlignParagraphsLongText(t *testing.T) {
+	const count = 20000
+	original := make([]string, count)
+	translation := make([]string, count)
+	for i := range original {
+		original[i] = strings.Repeat("a", 50+i%7*30)
+		translation[i] = strings.Repeat("x", 55+i%7*33)
+	}
+
+	rows := AlignParagraphs(original, translation)
+	if len(rows) != count {
+		t.Fatalf("AlignParagraphs() returned %d rows, want %d", len(rows), count)
+	}
+	for i, row := range rows {
+		if !reflect.DeepEqual(row, AlignedParagraphs{Original: []int{i}, Translation: []int{i}}) {
+			t.Fatalf("row %d = %v, want one to one", i, row)
+		}
+	}
+}
+
+func TestParagraphAlignmentBand(t *testing.T) {
+	for _, counts := range [][2]int{{1, 1}, {3, 50}, {50, 3}, {1000, 10}, {10, 1000}, {5, 0}} {
+		band := newParagraphAlignmentBand(counts[0], counts[1])
+		if band.from[0] != 0 || band.to[counts[0]] != counts[1] {
+			t.Errorf("band %v does not contain both ends of the diagonal", counts)
+		}
+		// Шаги выравнивания сдвигают номер абзаца перевода не больше чем на 2, поэтому соседние строки полосы должны стыковаться
+		for i := 1; i <= counts[0]; i++ {
+			if band.from[i] > band.to[i-1]+2 {
+				t.Errorf("band %v breaks between rows %d and %d", counts, i-1, i)
+			}
+		}
+	}
+}
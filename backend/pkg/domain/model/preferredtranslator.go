@@ -0,0 +1,42 @@
+package model
+
+import "github.com/mono83/maybe"
+
+// PreferredTranslator - переводчик, переводы которого читатель выбрал для всей книги
+type PreferredTranslator struct {
+	userID       UserID
+	bookID       BookID
+	translatorID UserID
+	language     maybe.Maybe[string]
+}
+
+func NewPreferredTranslator(
+	userID UserID,
+	bookID BookID,
+	translatorID UserID,
+	language maybe.Maybe[string],
+) PreferredTranslator {
+	return PreferredTranslator{
+		userID:       userID,
+		bookID:       bookID,
+		translatorID: translatorID,
+		language:     language,
+	}
+}
+
+func (preferredTranslator *PreferredTranslator) UserID() UserID {
+	return preferredTranslator.userID
+}
+
+func (preferredTranslator *PreferredTranslator) BookID() BookID {
+	return preferredTranslator.bookID
+}
+
+func (preferredTranslator *PreferredTranslator) TranslatorID() UserID {
+	return preferredTranslator.translatorID
+}
+
+// Language - язык переводов, если не указан, подходит перевод переводчика на любой язык
+func (preferredTranslator *PreferredTranslator) Language() maybe.Maybe[string] {
+	return preferredTranslator.language
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type TagID = uuid.UUID
+
+type TagStatus int16
+
+const (
+	TagPending TagStatus = iota
+	TagApproved
+	TagRejected
+)
+
+const MaxTagNameLength = 64
+
+type Tag struct {
+	id          TagID
+	name        string
+	status      TagStatus
+	suggestedBy maybe.Maybe[UserID]
+	createdAt   time.Time
+}
+
+func NewTag(
+	id TagID,
+	name string,
+	status TagStatus,
+	suggestedBy maybe.Maybe[UserID],
+	createdAt time.Time,
+) Tag {
+	return Tag{
+		id:          id,
+		name:        name,
+		status:      status,
+		suggestedBy: suggestedBy,
+		createdAt:   createdAt,
+	}
+}
+
+func (tag *Tag) ID() TagID {
+	return tag.id
+}
+
+func (tag *Tag) Name() string {
+	return tag.name
+}
+
+func (tag *Tag) Status() TagStatus {
+	return tag.status
+}
+
+func (tag *Tag) SuggestedBy() maybe.Maybe[UserID] {
+	return tag.suggestedBy
+}
+
+func (tag *Tag) CreatedAt() time.Time {
+	return tag.createdAt
+}
+
+func (tag *Tag) SetStatus(status TagStatus) {
+	tag.status = status
+}
+
+// NormalizeTagName приводит тег к нижнему регистру и схлопывает пробелы, чтобы "Slow  Burn" и "slow burn" совпадали
+func NormalizeTagName(name string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if normalized == "" || utf8.RuneCountInString(normalized) > MaxTagNameLength {
+		return "", ErrInvalidTagName
+	}
+
+	return normalized, nil
+}
+
+type BookTag struct {
+	bookID BookID
+	tagID  TagID
+	userID UserID
+}
+
+func NewBookTag(
+	bookID BookID,
+	tagID TagID,
+	userID UserID,
+) BookTag {
+	return BookTag{
+		bookID: bookID,
+		tagID:  tagID,
+		userID: userID,
+	}
+}
+
+func (bookTag *BookTag) BookID() BookID {
+	return bookTag.bookID
+}
+
+func (bookTag *BookTag) TagID() TagID {
+	return bookTag.tagID
+}
+
+func (bookTag *BookTag) UserID() UserID {
+	return bookTag.userID
+}
@@ -0,0 +1,375 @@
+package model
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Разметка перевода - подмножество Markdown:
+//   - абзацы разделяются пустой строкой, перенос строки внутри абзаца сохраняется;
+//   - *курсив*, _курсив_, **полужирный**, __полужирный__, \* экранирует символ разметки;
+//   - строка из трёх и более *, - или _ - смена сцены;
+//   - ![подпись](https://example.com/image.png) - изображение, допускаются http(s) и пути от корня сайта;
+//   - [^id] - ссылка на сноску, абзац вида "[^id]: текст" - сама сноска.
+//
+// Любой HTML во входном тексте экранируется, поэтому результат отрисовки безопасно вставлять в страницу
+
+var (
+	sceneBreakPattern         = regexp.MustCompile(`^(?:(?:\* *){3,}|(?:- *){3,}|(?:_ *){3,})$`)
+	footnoteDefinitionPattern = regexp.MustCompile(`^\[\^([A-Za-z0-9_-]+)\]:\s*`)
+	footnoteIDPattern         = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+type TranslationBlockType int
+
+const (
+	TranslationParagraph TranslationBlockType = iota
+	TranslationSceneBreak
+	TranslationFootnoteDefinition
+)
+
+type TranslationBlock struct {
+	Type TranslationBlockType
+	// Text - исходный текст блока, для сноски без префикса "[^id]: "
+	Text       string
+	FootnoteID string
+}
+
+// SplitTranslationBlocks делит текст перевода на блоки по пустым строкам
+func SplitTranslationBlocks(text string) []TranslationBlock {
+	var blocks []TranslationBlock
+	var lines []string
+	flush := func() {
+		if len(lines) == 0 {
+			return
+		}
+		blocks = append(blocks, newTranslationBlock(strings.Join(lines, "\n")))
+		lines = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return blocks
+}
+
+// TranslationParagraphs возвращает исходный текст абзацев без смен сцен и сносок
+func TranslationParagraphs(text string) []string {
+	var paragraphs []string
+	for _, block := range SplitTranslationBlocks(text) {
+		if block.Type == TranslationParagraph {
+			paragraphs = append(paragraphs, block.Text)
+		}
+	}
+
+	return paragraphs
+}
+
+func newTranslationBlock(text string) TranslationBlock {
+	trimmed := strings.TrimSpace(text)
+	if sceneBreakPattern.MatchString(trimmed) {
+		return TranslationBlock{Type: TranslationSceneBreak, Text: trimmed}
+	}
+	if match := footnoteDefinitionPattern.FindStringSubmatch(text); match != nil {
+		return TranslationBlock{Type: TranslationFootnoteDefinition, Text: text[len(match[0]):], FootnoteID: match[1]}
+	}
+
+	return TranslationBlock{Type: TranslationParagraph, Text: text}
+}
+
+// RenderTranslationMarkup всегда возвращает HTML, ошибочная разметка выводится как обычный текст
+func RenderTranslationMarkup(text string) string {
+	return renderTranslationMarkup(text).html
+}
+
+// ValidateTranslationMarkup возвращает ErrInvalidTranslationMarkup с перечислением всех ошибок разметки
+func ValidateTranslationMarkup(text string) error {
+	issues := renderTranslationMarkup(text).issues
+	if len(issues) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidTranslationMarkup, strings.Join(issues, "; "))
+}
+
+type renderedTranslationMarkup struct {
+	html   string
+	issues []string
+}
+
+type markupRenderer struct {
+	footnoteNumbers map[string]int
+	footnoteOrder   []string
+	issues          []string
+}
+
+func renderTranslationMarkup(text string) renderedTranslationMarkup {
+	blocks := SplitTranslationBlocks(text)
+	renderer := markupRenderer{footnoteNumbers: make(map[string]int)}
+
+	definitions := make(map[string]TranslationBlock)
+	for _, block := range blocks {
+		if block.Type != TranslationFootnoteDefinition {
+			continue
+		}
+		if _, ok := definitions[block.FootnoteID]; ok {
+			renderer.addIssue("footnote [^%s] is defined more than once", block.FootnoteID)
+			continue
+		}
+		definitions[block.FootnoteID] = block
+	}
+
+	var builder strings.Builder
+	paragraph := 0
+	for _, block := range blocks {
+		switch block.Type {
+		case TranslationSceneBreak:
+			builder.WriteString("<hr class=\"scene-break\">\n")
+		case TranslationParagraph:
+			paragraph++
+			builder.WriteString("<p>")
+			builder.WriteString(renderer.renderInline(block.Text, fmt.Sprintf("paragraph %d", paragraph), definitions))
+			builder.WriteString("</p>\n")
+		}
+	}
+
+	var footnotes strings.Builder
+	// Сноска может ссылаться на другую сноску, поэтому список дополняется во время обхода
+	for i := 0; i < len(renderer.footnoteOrder); i++ {
+		id := renderer.footnoteOrder[i]
+		content := renderer.renderInline(definitions[id].Text, fmt.Sprintf("footnote [^%s]", id), definitions)
+		escapedID := html.EscapeString(id)
+		footnotes.WriteString(fmt.Sprintf(
+			"<li id=\"fn-%s\">%s <a href=\"#fnref-%s\" class=\"footnote-backref\">&#8617;</a></li>\n",
+			escapedID, content, escapedID,
+		))
+	}
+	if footnotes.Len() > 0 {
+		builder.WriteString("<section class=\"footnotes\">\n<ol>\n")
+		builder.WriteString(footnotes.String())
+		builder.WriteString("</ol>\n</section>\n")
+	}
+
+	for _, block := range blocks {
+		if block.Type != TranslationFootnoteDefinition {
+			continue
+		}
+		if _, ok := renderer.footnoteNumbers[block.FootnoteID]; !ok {
+			renderer.addIssue("footnote [^%s] is never referenced", block.FootnoteID)
+		}
+	}
+
+	return renderedTranslationMarkup{html: builder.String(), issues: renderer.issues}
+}
+
+func (renderer *markupRenderer) addIssue(format string, args ...interface{}) {
+	renderer.issues = append(renderer.issues, fmt.Sprintf(format, args...))
+}
+
+type emphasisDelimiter struct {
+	char   rune
+	length int
+	index  int
+	// intraword - разделитель внутри слова или выражения вроде 2*3, без пары он считается обычным текстом
+	intraword bool
+}
+
+// renderInline разбирает выделение стеком открытых разделителей: закрывающий разделитель
+// закрывает ближайший открытый того же символа, а всё открытое после него остаётся текстом
+func (renderer *markupRenderer) renderInline(text, location string, definitions map[string]TranslationBlock) string {
+	runes := []rune(text)
+	var parts []string
+	var stack []emphasisDelimiter
+	var plain strings.Builder
+	flushPlain := func() {
+		if plain.Len() > 0 {
+			parts = append(parts, html.EscapeString(plain.String()))
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && (unicode.IsPunct(runes[i+1]) || unicode.IsSymbol(runes[i+1])):
+			plain.WriteRune(runes[i+1])
+			i++
+		case r == '\n':
+			flushPlain()
+			parts = append(parts, "<br>\n")
+		case r == '!' && i+1 < len(runes) && runes[i+1] == '[':
+			alt, src, end, ok := parseMarkupImage(runes, i)
+			if !ok {
+				plain.WriteRune(r)
+				continue
+			}
+			flushPlain()
+			if !isSafeImageURL(src) {
+				renderer.addIssue("%s: image URL %q is not allowed", location, src)
+				parts = append(parts, html.EscapeString(alt))
+			} else {
+				parts = append(parts, fmt.Sprintf("<img src=\"%s\" alt=\"%s\">", html.EscapeString(src), html.EscapeString(alt)))
+			}
+			i = end
+		case r == '[' && i+1 < len(runes) && runes[i+1] == '^':
+			end := indexRune(runes, i+2, ']')
+			if end < 0 || !footnoteIDPattern.MatchString(string(runes[i+2:end])) {
+				plain.WriteRune(r)
+				continue
+			}
+			id := string(runes[i+2 : end])
+			if _, ok := definitions[id]; !ok {
+				renderer.addIssue("%s: footnote [^%s] is not defined", location, id)
+				plain.WriteString(string(runes[i : end+1]))
+				i = end
+				continue
+			}
+			flushPlain()
+			parts = append(parts, renderer.footnoteReference(id))
+			i = end
+		case r == '*' || r == '_':
+			length := 1
+			for i+length < len(runes) && runes[i+length] == r {
+				length++
+			}
+			var prev, next rune
+			if i > 0 {
+				prev = runes[i-1]
+			}
+			if i+length < len(runes) {
+				next = runes[i+length]
+			}
+			i += length - 1
+			// Подчёркивание внутри слова не считается разметкой, чтобы не ломать snake_case и подобное
+			if r == '_' && isWordRune(prev) && isWordRune(next) {
+				plain.WriteString(strings.Repeat("_", length))
+				continue
+			}
+
+			flushPlain()
+			canOpen := next != 0 && !unicode.IsSpace(next)
+			canClose := prev != 0 && !unicode.IsSpace(prev)
+			for length > 0 {
+				open := -1
+				for j := len(stack) - 1; j >= 0; j-- {
+					if stack[j].char == r {
+						open = j
+						break
+					}
+				}
+
+				if canClose && open >= 0 && stack[open].length <= length {
+					renderer.addUnclosedIssues(location, stack[open+1:])
+					tag := "em"
+					if stack[open].length == 2 {
+						tag = "strong"
+					}
+					parts[stack[open].index] = "<" + tag + ">"
+					parts = append(parts, "</"+tag+">")
+					length -= stack[open].length
+					stack = stack[:open]
+					continue
+				}
+				if !canOpen {
+					parts = append(parts, html.EscapeString(strings.Repeat(string(r), length)))
+					break
+				}
+
+				openLength := min(length, 2)
+				stack = append(stack, emphasisDelimiter{char: r, length: openLength, index: len(parts), intraword: canClose})
+				parts = append(parts, strings.Repeat(string(r), openLength))
+				length -= openLength
+			}
+		default:
+			plain.WriteRune(r)
+		}
+	}
+	flushPlain()
+	renderer.addUnclosedIssues(location, stack)
+
+	return strings.Join(parts, "")
+}
+
+func (renderer *markupRenderer) addUnclosedIssues(location string, unclosed []emphasisDelimiter) {
+	for _, delimiter := range unclosed {
+		if !delimiter.intraword {
+			renderer.addIssue("%s: unclosed %q", location, strings.Repeat(string(delimiter.char), delimiter.length))
+		}
+	}
+}
+
+func (renderer *markupRenderer) footnoteReference(id string) string {
+	number, ok := renderer.footnoteNumbers[id]
+	escapedID := html.EscapeString(id)
+	if ok {
+		return fmt.Sprintf("<sup class=\"footnote-ref\"><a href=\"#fn-%s\">%d</a></sup>", escapedID, number)
+	}
+
+	number = len(renderer.footnoteOrder) + 1
+	renderer.footnoteNumbers[id] = number
+	renderer.footnoteOrder = append(renderer.footnoteOrder, id)
+
+	return fmt.Sprintf("<sup class=\"footnote-ref\"><a href=\"#fn-%s\" id=\"fnref-%s\">%s</a></sup>", escapedID, escapedID, strconv.Itoa(number))
+}
+
+// parseMarkupImage разбирает ![alt](src), начиная с позиции "!", и возвращает позицию закрывающей скобки
+func parseMarkupImage(runes []rune, start int) (alt, src string, end int, ok bool) {
+	altEnd := indexRune(runes, start+2, ']')
+	if altEnd < 0 || altEnd+1 >= len(runes) || runes[altEnd+1] != '(' {
+		return "", "", 0, false
+	}
+	srcEnd := indexRune(runes, altEnd+2, ')')
+	if srcEnd < 0 {
+		return "", "", 0, false
+	}
+
+	return string(runes[start+2 : altEnd]), strings.TrimSpace(string(runes[altEnd+2 : srcEnd])), srcEnd, true
+}
+
+func isSafeImageURL(src string) bool {
+	// Браузеры считают обратный слеш прямым, поэтому /\evil.com ведёт на чужой хост
+	if src == "" || strings.ContainsAny(src, " \t\n\"'<>\\") {
+		return false
+	}
+	parsed, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+		return parsed.Host != ""
+	case "":
+		return parsed.Host == "" && strings.HasPrefix(src, "/") && !strings.HasPrefix(src, "//")
+	default:
+		return false
+	}
+}
+
+func indexRune(runes []rune, from int, target rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == '\n' {
+			return -1
+		}
+		if runes[i] == target {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
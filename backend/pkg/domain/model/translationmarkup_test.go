@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderTranslationMarkup(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Первый абзац\nвторая строка\n\nВторой абзац", want: "<p>Первый абзац<br>\nвторая строка</p>\n<p>Второй абзац</p>\n"},
+		{text: "*курсив* и **полужирный**", want: "<p><em>курсив</em> и <strong>полужирный</strong></p>\n"},
+		{text: "_курсив_ и __полужирный__", want: "<p><em>курсив</em> и <strong>полужирный</strong></p>\n"},
+		{text: "***", want: "<hr class=\"scene-break\">\n"},
+		{text: "<script>alert(1)</script>", want: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{text: "\\*не курсив\\*", want: "<p>*не курсив*</p>\n"},
+		{text: "snake_case_name", want: "<p>snake_case_name</p>\n"},
+		{text: "2*3 = 6", want: "<p>2*3 = 6</p>\n"},
+		{text: "a * b", want: "<p>a * b</p>\n"},
+		{text: "![кот](/images/cat.png)", want: "<p><img src=\"/images/cat.png\" alt=\"кот\"></p>\n"},
+		{text: "![\"><b>](https://example.com/a.png?x=1&y=2)", want: "<p><img src=\"https://example.com/a.png?x=1&amp;y=2\" alt=\"&#34;&gt;&lt;b&gt;\"></p>\n"},
+		{text: "![кот](javascript:alert(1))", want: "<p>кот)</p>\n"},
+		{
+			text: "Текст[^1] и ещё[^note]\n\n[^1]: Первая\n\n[^note]: Вторая",
+			want: "<p>Текст<sup class=\"footnote-ref\"><a href=\"#fn-1\" id=\"fnref-1\">1</a></sup> и ещё" +
+				"<sup class=\"footnote-ref\"><a href=\"#fn-note\" id=\"fnref-note\">2</a></sup></p>\n" +
+				"<section class=\"footnotes\">\n<ol>\n" +
+				"<li id=\"fn-1\">Первая <a href=\"#fnref-1\" class=\"footnote-backref\">&#8617;</a></li>\n" +
+				"<li id=\"fn-note\">Вторая <a href=\"#fnref-note\" class=\"footnote-backref\">&#8617;</a></li>\n" +
+				"</ol>\n</section>\n",
+		},
+		{text: "Текст[^a b]", want: "<p>Текст[^a b]</p>\n"},
+	}
+
+	for _, test := range tests {
+		if got := RenderTranslationMarkup(test.text); got != test.want {
+			t.Errorf("RenderTranslationMarkup(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestValidateTranslationMarkup(t *testing.T) {
+	tests := []struct {
+		text  string
+		valid bool
+	}{
+		{text: "*курсив* и **полужирный**", valid: true},
+		{text: "2*3 = 6, 2**10 = 1024", valid: true},
+		{text: "snake_case и __init__", valid: true},
+		{text: "a*b*c", valid: true},
+		{text: "*незакрытый курсив", valid: false},
+		{text: "**незакрытый полужирный", valid: false},
+		{text: "*курсив **внутри* курсива", valid: false},
+		{text: "Текст[^1]\n\n[^1]: Сноска", valid: true},
+		{text: "Текст[^1]", valid: false},
+		{text: "Текст\n\n[^1]: Сноска без ссылки", valid: false},
+		{text: "Текст[^1]\n\n[^1]: Первая\n\n[^1]: Вторая", valid: false},
+		{text: "Текст[^a]\n\n[^a]: Сноска на сноску[^b]\n\n[^b]: Вложенная", valid: true},
+	}
+
+	for _, test := range tests {
+		err := ValidateTranslationMarkup(test.text)
+		if test.valid && err != nil {
+			t.Errorf("ValidateTranslationMarkup(%q) = %v, want nil", test.text, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidTranslationMarkup) {
+			t.Errorf("ValidateTranslationMarkup(%q) = %v, want ErrInvalidTranslationMarkup", test.text, err)
+		}
+	}
+}
+
+func TestIsSafeImageURL(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{src: "https://example.com/image.png", want: true},
+		{src: "http://example.com/image.png", want: true},
+		{src: "/images/image.png", want: true},
+		{src: "", want: false},
+		{src: "https://", want: false},
+		{src: "//evil.com/image.png", want: false},
+		{src: "/\\evil.com/image.png", want: false},
+		{src: "\\\\evil.com/image.png", want: false},
+		{src: "https:\\\\evil.com", want: false},
+		{src: "images/image.png", want: false},
+		{src: "javascript:alert(1)", want: false},
+		{src: "data:image/png;base64,AAAA", want: false},
+		{src: "https://example.com/a\".png", want: false},
+		{src: "/a b.png", want: false},
+	}
+
+	for _, test := range tests {
+		if got := isSafeImageURL(test.src); got != test.want {
+			t.Errorf("isSafeImageURL(%q) = %v, want %v", test.src, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package model
+
+type TrashEntityType int16
+
+const (
+	TrashEntityBook TrashEntityType = iota
+	TrashEntityBookChapter
+	TrashEntityAuthor
+	TrashEntityGenre
+)
@@ -12,6 +12,10 @@ type UserRole int16
 const (
 	Admin UserRole = iota
 	Client
+	Moderator
+	// Deleted - анонимный пользователь, которому достались переводы и комментарии удалённого аккаунта.
+	// Под ним нельзя войти, и он не редактируется, не удаляется и не ограничивается как обычный пользователь
+	Deleted
 )
 
 type User struct {
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"time"
+)
+
+type UserDataExportID = uuid.UUID
+
+type UserDataExportStatus int16
+
+const (
+	UserDataExportPending UserDataExportStatus = iota
+	UserDataExportReady
+	UserDataExportFailed
+)
+
+type UserDataExport struct {
+	id          UserDataExportID
+	userID      UserID
+	status      UserDataExportStatus
+	createdAt   time.Time
+	completedAt maybe.Maybe[time.Time]
+}
+
+func NewUserDataExport(
+	id UserDataExportID,
+	userID UserID,
+	status UserDataExportStatus,
+	createdAt time.Time,
+	completedAt maybe.Maybe[time.Time],
+) UserDataExport {
+	return UserDataExport{
+		id:          id,
+		userID:      userID,
+		status:      status,
+		createdAt:   createdAt,
+		completedAt: completedAt,
+	}
+}
+
+func (userDataExport *UserDataExport) ID() UserDataExportID {
+	return userDataExport.id
+}
+
+func (userDataExport *UserDataExport) UserID() UserID {
+	return userDataExport.userID
+}
+
+func (userDataExport *UserDataExport) Status() UserDataExportStatus {
+	return userDataExport.status
+}
+
+func (userDataExport *UserDataExport) CreatedAt() time.Time {
+	return userDataExport.createdAt
+}
+
+func (userDataExport *UserDataExport) CompletedAt() maybe.Maybe[time.Time] {
+	return userDataExport.completedAt
+}
+
+func (userDataExport *UserDataExport) SetStatus(status UserDataExportStatus) {
+	userDataExport.status = status
+}
+
+func (userDataExport *UserDataExport) SetCompletedAt(completedAt maybe.Maybe[time.Time]) {
+	userDataExport.completedAt = completedAt
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"time"
+)
+
+type UserRestrictionID = uuid.UUID
+
+type UserRestrictionType int16
+
+const (
+	SUSPENSION UserRestrictionType = iota
+	MUTE
+)
+
+type UserRestriction struct {
+	id              UserRestrictionID
+	userID          UserID
+	issuedBy        UserID
+	restrictionType UserRestrictionType
+	reason          string
+	createdAt       time.Time
+	expiresAt       maybe.Maybe[time.Time]
+}
+
+func NewUserRestriction(
+	id UserRestrictionID,
+	userID UserID,
+	issuedBy UserID,
+	restrictionType UserRestrictionType,
+	reason string,
+	createdAt time.Time,
+	expiresAt maybe.Maybe[time.Time],
+) UserRestriction {
+	return UserRestriction{
+		id:              id,
+		userID:          userID,
+		issuedBy:        issuedBy,
+		restrictionType: restrictionType,
+		reason:          reason,
+		createdAt:       createdAt,
+		expiresAt:       expiresAt,
+	}
+}
+
+func (userRestriction *UserRestriction) ID() UserRestrictionID {
+	return userRestriction.id
+}
+
+func (userRestriction *UserRestriction) UserID() UserID {
+	return userRestriction.userID
+}
+
+func (userRestriction *UserRestriction) IssuedBy() UserID {
+	return userRestriction.issuedBy
+}
+
+func (userRestriction *UserRestriction) Type() UserRestrictionType {
+	return userRestriction.restrictionType
+}
+
+func (userRestriction *UserRestriction) Reason() string {
+	return userRestriction.reason
+}
+
+func (userRestriction *UserRestriction) CreatedAt() time.Time {
+	return userRestriction.createdAt
+}
+
+// ExpiresAt пуст для бессрочного ограничения
+func (userRestriction *UserRestriction) ExpiresAt() maybe.Maybe[time.Time] {
+	return userRestriction.expiresAt
+}
+
+func (userRestriction *UserRestriction) IsActive(now time.Time) bool {
+	expiresAt, ok := userRestriction.expiresAt.Get()
+	if !ok {
+		return true
+	}
+
+	return now.Before(expiresAt)
+}
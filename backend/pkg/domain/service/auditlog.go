@@ -0,0 +1,261 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"log"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type AuditLogRepository interface {
+	NextID() uuid.UUID
+	Store(auditLog model.AuditLog) error
+}
+
+// AuditMetadata описывает инициатора действия и запрос, из которого оно выполнено
+type AuditMetadata struct {
+	ActorID   maybe.Maybe[model.UserID]
+	IP        string
+	UserAgent string
+}
+
+type auditLogWriter struct {
+	auditLogRepo AuditLogRepository
+}
+
+func newAuditLogWriter(auditLogRepo AuditLogRepository) auditLogWriter {
+	return auditLogWriter{auditLogRepo: auditLogRepo}
+}
+
+// write принимает снимки сущности до и после действия, nil означает отсутствие снимка.
+// Запись вызывается после того, как действие сохранено, поэтому ошибка записи только логируется:
+// иначе клиент получил бы ошибку за выполненное действие и повторил бы его
+func (writer auditLogWriter) write(
+	metadata AuditMetadata,
+	action model.AuditAction,
+	entityType model.AuditEntityType,
+	entityID uuid.UUID,
+	before any,
+	after any,
+) {
+	err := writer.store(metadata, action, entityType, entityID, before, after)
+	if err != nil {
+		log.Printf("Failed to write audit log action %d of entity %s: %s", action, entityID, err)
+	}
+}
+
+func (writer auditLogWriter) store(
+	metadata AuditMetadata,
+	action model.AuditAction,
+	entityType model.AuditEntityType,
+	entityID uuid.UUID,
+	before any,
+	after any,
+) error {
+	beforeJSON, err := marshalAuditSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := marshalAuditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	auditLog := model.NewAuditLog(
+		model.AuditLogID(writer.auditLogRepo.NextID()),
+		metadata.ActorID,
+		action,
+		entityType,
+		entityID,
+		beforeJSON,
+		afterJSON,
+		metadata.IP,
+		metadata.UserAgent,
+		time.Now(),
+	)
+
+	return writer.auditLogRepo.Store(auditLog)
+}
+
+func marshalAuditSnapshot(snapshot any) (maybe.Maybe[string], error) {
+	if snapshot == nil {
+		return maybe.Nothing[string](), nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return maybe.Nothing[string](), err
+	}
+
+	return maybe.Just(string(data)), nil
+}
+
+type bookAuditSnapshot struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	IsPublished bool   `json:"isPublished"`
+}
+
+func newBookAuditSnapshot(book model.Book) bookAuditSnapshot {
+	return bookAuditSnapshot{
+		ID:          book.ID().String(),
+		Title:       book.Title(),
+		Description: book.Description(),
+		IsPublished: book.IsPublished(),
+	}
+}
+
+type authorAuditSnapshot struct {
+	ID         string   `json:"id"`
+	FirstName  string   `json:"firstName"`
+	SecondName string   `json:"secondName"`
+	MiddleName *string  `json:"middleName,omitempty"`
+	Nickname   *string  `json:"nickname,omitempty"`
+	Biography  *string  `json:"biography,omitempty"`
+	BirthYear  *int     `json:"birthYear,omitempty"`
+	DeathYear  *int     `json:"deathYear,omitempty"`
+	Country    *string  `json:"country,omitempty"`
+	Aliases    []string `json:"aliases,omitempty"`
+}
+
+func newAuthorAuditSnapshot(author model.Author) authorAuditSnapshot {
+	snapshot := authorAuditSnapshot{
+		ID:         author.ID().String(),
+		FirstName:  author.FirstName(),
+		SecondName: author.SecondName(),
+		Aliases:    author.Aliases(),
+	}
+	if middleName, ok := author.MiddleName().Get(); ok {
+		snapshot.MiddleName = &middleName
+	}
+	if nickname, ok := author.Nickname().Get(); ok {
+		snapshot.Nickname = &nickname
+	}
+	if biography, ok := author.Biography().Get(); ok {
+		snapshot.Biography = &biography
+	}
+	if birthYear, ok := author.BirthYear().Get(); ok {
+		snapshot.BirthYear = &birthYear
+	}
+	if deathYear, ok := author.DeathYear().Get(); ok {
+		snapshot.DeathYear = &deathYear
+	}
+	if country, ok := author.Country().Get(); ok {
+		snapshot.Country = &country
+	}
+
+	return snapshot
+}
+
+type genreAuditSnapshot struct {
+	ID       string  `json:"id"`
+	ParentID *string `json:"parentId,omitempty"`
+	Name     string  `json:"name"`
+}
+
+func newGenreAuditSnapshot(genre model.Genre) genreAuditSnapshot {
+	snapshot := genreAuditSnapshot{
+		ID:   genre.ID().String(),
+		Name: genre.Name(),
+	}
+	if parentID, ok := genre.ParentID().Get(); ok {
+		parentIDString := parentID.String()
+		snapshot.ParentID = &parentIDString
+	}
+
+	return snapshot
+}
+
+type bookChapterAuditSnapshot struct {
+	ID     string `json:"id"`
+	BookID string `json:"bookId"`
+	Index  int    `json:"index"`
+	Title  string `json:"title"`
+}
+
+func newBookChapterAuditSnapshot(bookChapter model.BookChapter) bookChapterAuditSnapshot {
+	return bookChapterAuditSnapshot{
+		ID:     bookChapter.ID().String(),
+		BookID: bookChapter.BookID().String(),
+		Index:  bookChapter.Index(),
+		Title:  bookChapter.Title(),
+	}
+}
+
+// userAuditSnapshot намеренно не содержит пароль
+type userAuditSnapshot struct {
+	ID      string `json:"id"`
+	Login   string `json:"login"`
+	Role    int    `json:"role"`
+	AboutMe string `json:"aboutMe"`
+}
+
+func newUserAuditSnapshot(user model.User) userAuditSnapshot {
+	return userAuditSnapshot{
+		ID:      uuid.UUID(user.ID()).String(),
+		Login:   user.Login(),
+		Role:    int(user.Role()),
+		AboutMe: user.AboutMe(),
+	}
+}
+
+type verifyBookRequestAuditSnapshot struct {
+	ID           string `json:"id"`
+	TranslatorID string `json:"translatorId"`
+	BookID       string `json:"bookId"`
+	IsVerified   *bool  `json:"isVerified,omitempty"`
+}
+
+func newVerifyBookRequestAuditSnapshot(verifyBookRequest model.VerifyBookRequest) verifyBookRequestAuditSnapshot {
+	snapshot := verifyBookRequestAuditSnapshot{
+		ID:           verifyBookRequest.VerifyBookRequestID().String(),
+		TranslatorID: uuid.UUID(verifyBookRequest.TranslatorID()).String(),
+		BookID:       verifyBookRequest.BookID().String(),
+	}
+	if isVerified, ok := verifyBookRequest.IsVerified().Get(); ok {
+		snapshot.IsVerified = &isVerified
+	}
+
+	return snapshot
+}
+
+type userRestrictionAuditSnapshot struct {
+	ID             string `json:"id"`
+	UserID         string `json:"userId"`
+	Type           int    `json:"type"`
+	Reason         string `json:"reason"`
+	ExpiresAtMilli *int64 `json:"expiresAtMilli,omitempty"`
+}
+
+func newUserRestrictionAuditSnapshot(userRestriction model.UserRestriction) userRestrictionAuditSnapshot {
+	snapshot := userRestrictionAuditSnapshot{
+		ID:     userRestriction.ID().String(),
+		UserID: uuid.UUID(userRestriction.UserID()).String(),
+		Type:   int(userRestriction.Type()),
+		Reason: userRestriction.Reason(),
+	}
+	if expiresAt, ok := userRestriction.ExpiresAt().Get(); ok {
+		expiresAtMilli := expiresAt.UnixMilli()
+		snapshot.ExpiresAtMilli = &expiresAtMilli
+	}
+
+	return snapshot
+}
+
+type tagAuditSnapshot struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status int    `json:"status"`
+}
+
+func newTagAuditSnapshot(tag model.Tag) tagAuditSnapshot {
+	return tagAuditSnapshot{
+		ID:     tag.ID().String(),
+		Name:   tag.Name(),
+		Status: int(tag.Status()),
+	}
+}
@@ -4,28 +4,46 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"sort"
+	"strings"
+	"time"
 )
 
 type AuthorService interface {
 	CreateAuthor(input CreateAuthorInput) error
 	EditAuthor(input EditAuthorInput) error
 	EditAuthorAvatar(input EditAuthorAvatarInput) error
-	DeleteAuthor(authorID model.AuthorID) error
+	DeleteAuthor(authorID model.AuthorID, metadata AuditMetadata) error
+	RestoreAuthor(authorID model.AuthorID, metadata AuditMetadata) error
+	FindDuplicateAuthors(minSimilarity float64) ([]AuthorDuplicate, error)
+	MergeAuthors(input MergeAuthorsInput) error
 }
 
 type authorService struct {
 	authorRepo AuthorRepository
+	auditLog   auditLogWriter
 }
 
-func NewAuthorService(authorRepo AuthorRepository) *authorService {
-	return &authorService{authorRepo: authorRepo}
+func NewAuthorService(authorRepo AuthorRepository, auditLogRepo AuditLogRepository) *authorService {
+	return &authorService{
+		authorRepo: authorRepo,
+		auditLog:   newAuditLogWriter(auditLogRepo),
+	}
 }
 
 type AuthorRepository interface {
 	NextID() uuid.UUID
 	Store(author model.Author) error
 	Delete(authorID model.AuthorID) error
+	Restore(authorID model.AuthorID) error
+	PurgeDeleted(deletedBefore time.Time) error
 	FindByID(authorID model.AuthorID) (model.Author, error)
+	FindAll() ([]model.Author, error)
+	Merge(
+		survivorID model.AuthorID,
+		duplicateID model.AuthorID,
+		merge func(survivor, duplicate model.Author) model.Author,
+	) (model.Author, model.Author, error)
 }
 
 type CreateAuthorInput struct {
@@ -33,6 +51,11 @@ type CreateAuthorInput struct {
 	SecondName string
 	MiddleName maybe.Maybe[string]
 	Nickname   maybe.Maybe[string]
+	Biography  maybe.Maybe[string]
+	BirthYear  maybe.Maybe[int]
+	DeathYear  maybe.Maybe[int]
+	Country    maybe.Maybe[string]
+	Aliases    []string
 }
 
 type EditAuthorInput struct {
@@ -41,6 +64,24 @@ type EditAuthorInput struct {
 	SecondName string
 	MiddleName maybe.Maybe[string]
 	Nickname   maybe.Maybe[string]
+	Biography  maybe.Maybe[string]
+	BirthYear  maybe.Maybe[int]
+	DeathYear  maybe.Maybe[int]
+	Country    maybe.Maybe[string]
+	Aliases    []string
+}
+
+// AuthorDuplicate - пара авторов с похожими именами, предлагаемая администратору для слияния
+type AuthorDuplicate struct {
+	First      model.Author
+	Second     model.Author
+	Similarity float64
+}
+
+type MergeAuthorsInput struct {
+	SurvivorID  model.AuthorID
+	DuplicateID model.AuthorID
+	Metadata    AuditMetadata
 }
 
 type EditAuthorAvatarInput struct {
@@ -56,8 +97,18 @@ func (service *authorService) CreateAuthor(input CreateAuthorInput) error {
 		input.SecondName,
 		input.MiddleName,
 		input.Nickname,
+		input.Biography,
+		maybe.Nothing[int](),
+		maybe.Nothing[int](),
+		input.Country,
+		model.NormalizeAuthorAliases(input.Aliases),
 	)
 
+	err := author.SetLifeYears(input.BirthYear, input.DeathYear)
+	if err != nil {
+		return err
+	}
+
 	return service.authorRepo.Store(author)
 }
 
@@ -71,6 +122,14 @@ func (service *authorService) EditAuthor(input EditAuthorInput) error {
 	author.SetSecondName(input.SecondName)
 	author.SetMiddleName(input.MiddleName)
 	author.SetNickname(input.Nickname)
+	author.SetBiography(input.Biography)
+	author.SetCountry(input.Country)
+	author.SetAliases(input.Aliases)
+
+	err = author.SetLifeYears(input.BirthYear, input.DeathYear)
+	if err != nil {
+		return err
+	}
 
 	return service.authorRepo.Store(author)
 }
@@ -86,6 +145,171 @@ func (service *authorService) EditAuthorAvatar(input EditAuthorAvatarInput) erro
 	return service.authorRepo.Store(author)
 }
 
-func (service *authorService) DeleteAuthor(authorID model.AuthorID) error {
-	return service.authorRepo.Delete(authorID)
+func (service *authorService) DeleteAuthor(authorID model.AuthorID, metadata AuditMetadata) error {
+	author, err := service.authorRepo.FindByID(authorID)
+	if err != nil {
+		return err
+	}
+
+	err = service.authorRepo.Delete(authorID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionDeleteAuthor,
+		model.AuditEntityAuthor,
+		authorID,
+		newAuthorAuditSnapshot(author),
+		nil,
+	)
+
+	return nil
+}
+
+func (service *authorService) RestoreAuthor(authorID model.AuthorID, metadata AuditMetadata) error {
+	err := service.authorRepo.Restore(authorID)
+	if err != nil {
+		return err
+	}
+
+	author, err := service.authorRepo.FindByID(authorID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionRestoreAuthor,
+		model.AuditEntityAuthor,
+		authorID,
+		nil,
+		newAuthorAuditSnapshot(author),
+	)
+
+	return nil
+}
+
+// FindDuplicateAuthors сравнивает попарно только авторов с общими инициалами имени,
+// иначе на каждый запрос пришлось бы сравнивать каждого автора с каждым
+func (service *authorService) FindDuplicateAuthors(minSimilarity float64) ([]AuthorDuplicate, error) {
+	authors, err := service.authorRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([][]string, len(authors))
+	groups := make(map[string][]int)
+	for i, author := range authors {
+		variants[i] = model.AuthorNameVariants(author)
+		for _, key := range model.AuthorNameBlockingKeys(variants[i]) {
+			groups[key] = append(groups[key], i)
+		}
+	}
+
+	var pairs [][2]int
+	compared := make(map[[2]int]struct{})
+	for _, group := range groups {
+		for x := range group {
+			for y := x + 1; y < len(group); y++ {
+				pair := [2]int{group[x], group[y]}
+				if _, ok := compared[pair]; ok {
+					continue
+				}
+				compared[pair] = struct{}{}
+
+				if model.AuthorNameVariantsSimilarity(variants[pair[0]], variants[pair[1]]) >= minSimilarity {
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+
+	duplicates := make([]AuthorDuplicate, len(pairs))
+	for i, pair := range pairs {
+		duplicates[i] = AuthorDuplicate{
+			First:      authors[pair[0]],
+			Second:     authors[pair[1]],
+			Similarity: model.AuthorNameVariantsSimilarity(variants[pair[0]], variants[pair[1]]),
+		}
+	}
+
+	// Группы обходятся в случайном порядке, поэтому при равной похожести порядок задают сами авторы
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Similarity != duplicates[j].Similarity {
+			return duplicates[i].Similarity > duplicates[j].Similarity
+		}
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	return duplicates, nil
+}
+
+// MergeAuthors переносит книги и аватар дубликата на оставшегося автора и удаляет дубликат.
+// Имя и псевдонимы дубликата сохраняются как псевдонимы, чтобы по ним продолжал работать поиск
+func (service *authorService) MergeAuthors(input MergeAuthorsInput) error {
+	if input.SurvivorID == input.DuplicateID {
+		return model.ErrMergeAuthorIntoItself
+	}
+
+	survivor, duplicate, err := service.authorRepo.Merge(input.SurvivorID, input.DuplicateID, mergeAuthor)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		input.Metadata,
+		model.AuditActionMergeAuthor,
+		model.AuditEntityAuthor,
+		input.DuplicateID,
+		newAuthorAuditSnapshot(duplicate),
+		newAuthorAuditSnapshot(survivor),
+	)
+
+	return nil
+}
+
+func mergeAuthor(survivor, duplicate model.Author) model.Author {
+	if _, ok := survivor.AvatarID().Get(); !ok {
+		survivor.SetAvatarID(duplicate.AvatarID())
+	}
+
+	aliases := append([]string{}, survivor.Aliases()...)
+	aliases = append(aliases, duplicateAuthorNames(duplicate)...)
+	survivor.SetAliases(excludeAuthorOwnName(survivor, aliases))
+
+	return survivor
+}
+
+func duplicateAuthorNames(author model.Author) []string {
+	names := []string{author.FirstName() + " " + author.SecondName()}
+	if nickname, ok := author.Nickname().Get(); ok {
+		names = append(names, nickname)
+	}
+
+	return append(names, author.Aliases()...)
+}
+
+// excludeAuthorOwnName убирает из псевдонимов совпадающие с собственным именем автора
+func excludeAuthorOwnName(author model.Author, aliases []string) []string {
+	ownNames := map[string]struct{}{
+		strings.ToLower(author.FirstName() + " " + author.SecondName()): {},
+	}
+	if nickname, ok := author.Nickname().Get(); ok {
+		ownNames[strings.ToLower(nickname)] = struct{}{}
+	}
+
+	result := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		if _, ok := ownNames[strings.ToLower(strings.Join(strings.Fields(alias), " "))]; ok {
+			continue
+		}
+		result = append(result, alias)
+	}
+
+	return result
 }
@@ -4,45 +4,76 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type BookService interface {
-	CreateBook(input CreateBookInput) error
+	CreateBook(input CreateBookInput) (CreateBookOutput, error)
 	EditBook(input EditBookInput) error
 	EditBookImage(input EditBookImageInput) error
 	PublishBook(input PublishBookInput) error
-	DeleteBook(bookID model.BookID) error
+	DeleteBook(bookID model.BookID, metadata AuditMetadata) error
+	RestoreBook(bookID model.BookID, metadata AuditMetadata) error
+	StoreBookLocalization(input StoreBookLocalizationInput) error
+	DeleteBookLocalization(bookID model.BookID, language string) error
 }
 
 type bookService struct {
 	bookRepo BookRepository
+	auditLog auditLogWriter
 }
 
-func NewBookService(bookRepo BookRepository) *bookService {
-	return &bookService{bookRepo: bookRepo}
+func NewBookService(bookRepo BookRepository, auditLogRepo AuditLogRepository) *bookService {
+	return &bookService{
+		bookRepo: bookRepo,
+		auditLog: newAuditLogWriter(auditLogRepo),
+	}
 }
 
 type BookRepository interface {
 	NextID() uuid.UUID
 	Store(book model.Book) error
 	Delete(bookID model.BookID) error
+	Restore(bookID model.BookID) error
+	PurgeDeleted(deletedBefore time.Time) error
 	FindByID(bookID model.BookID) (model.Book, error)
+	StoreLocalization(localization model.BookLocalization) error
+	DeleteLocalization(bookID model.BookID, language string) error
+	BookFingerprintRepository
 }
 
 type CreateBookInput struct {
-	Title       string
-	Description string
+	Title            string
+	Description      string
+	OriginalLanguage maybe.Maybe[string]
+	CreatedBy        maybe.Maybe[model.UserID]
+}
+
+// CreateBookOutput содержит ID новой книги и уже существующие похожие книги,
+// чтобы переводчик мог добавить перевод к ним вместо создания копии
+type CreateBookOutput struct {
+	BookID     model.BookID
+	Duplicates []model.BookDuplicate
 }
 
 type EditBookInput struct {
-	ID          model.BookID
-	Title       string
-	Description string
+	ID               model.BookID
+	Title            string
+	Description      string
+	OriginalLanguage maybe.Maybe[string]
 }
 
 type PublishBookInput struct {
 	ID          model.BookID
 	IsPublished bool
+	Metadata    AuditMetadata
+}
+
+type StoreBookLocalizationInput struct {
+	BookID      model.BookID
+	Language    string
+	Title       string
+	Description string
 }
 
 type EditBookImageInput struct {
@@ -50,16 +81,41 @@ type EditBookImageInput struct {
 	ImageID model.ImageID
 }
 
-func (service *bookService) CreateBook(input CreateBookInput) error {
+func (service *bookService) CreateBook(input CreateBookInput) (CreateBookOutput, error) {
+	originalLanguage, err := normalizeOptionalLanguageCode(input.OriginalLanguage)
+	if err != nil {
+		return CreateBookOutput{}, err
+	}
+
 	book := model.NewBook(
 		model.BookID(service.bookRepo.NextID()),
 		maybe.Nothing[model.ImageID](),
 		input.Title,
 		input.Description,
 		false,
+		maybe.Nothing[time.Time](),
+		originalLanguage,
 	)
 
-	return service.bookRepo.Store(book)
+	// Дубликаты ищутся до сохранения: если поиск не удастся, книга не создастся и повтор запроса не создаст копию
+	duplicates, err := findBookDuplicates(
+		service.bookRepo,
+		model.NewBookFingerprint(book.ID(), book.Title(), nil),
+		input.CreatedBy,
+	)
+	if err != nil {
+		return CreateBookOutput{}, err
+	}
+
+	err = service.bookRepo.Store(book)
+	if err != nil {
+		return CreateBookOutput{}, err
+	}
+
+	return CreateBookOutput{
+		BookID:     book.ID(),
+		Duplicates: duplicates,
+	}, nil
 }
 
 func (service *bookService) EditBook(input EditBookInput) error {
@@ -68,8 +124,14 @@ func (service *bookService) EditBook(input EditBookInput) error {
 		return err
 	}
 
+	originalLanguage, err := normalizeOptionalLanguageCode(input.OriginalLanguage)
+	if err != nil {
+		return err
+	}
+
 	book.SetTitle(input.Title)
 	book.SetDescription(input.Description)
+	book.SetOriginalLanguage(originalLanguage)
 
 	return service.bookRepo.Store(book)
 }
@@ -91,11 +153,117 @@ func (service *bookService) PublishBook(input PublishBookInput) error {
 		return err
 	}
 
+	before := newBookAuditSnapshot(book)
+	if input.IsPublished && !book.IsPublished() {
+		book.SetPublishedAt(maybe.Just(time.Now()))
+	}
+	if !input.IsPublished {
+		book.SetPublishedAt(maybe.Nothing[time.Time]())
+	}
 	book.SetIsPublished(input.IsPublished)
 
-	return service.bookRepo.Store(book)
+	err = service.bookRepo.Store(book)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		input.Metadata,
+		model.AuditActionPublishBook,
+		model.AuditEntityBook,
+		book.ID(),
+		before,
+		newBookAuditSnapshot(book),
+	)
+
+	return nil
 }
 
-func (service *bookService) DeleteBook(bookID model.BookID) error {
-	return service.bookRepo.Delete(bookID)
+func (service *bookService) DeleteBook(bookID model.BookID, metadata AuditMetadata) error {
+	book, err := service.bookRepo.FindByID(bookID)
+	if err != nil {
+		return err
+	}
+
+	err = service.bookRepo.Delete(bookID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionDeleteBook,
+		model.AuditEntityBook,
+		bookID,
+		newBookAuditSnapshot(book),
+		nil,
+	)
+
+	return nil
+}
+
+func (service *bookService) RestoreBook(bookID model.BookID, metadata AuditMetadata) error {
+	err := service.bookRepo.Restore(bookID)
+	if err != nil {
+		return err
+	}
+
+	book, err := service.bookRepo.FindByID(bookID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionRestoreBook,
+		model.AuditEntityBook,
+		bookID,
+		nil,
+		newBookAuditSnapshot(book),
+	)
+
+	return nil
+}
+
+// StoreBookLocalization создаёт или заменяет название и описание книги на указанном языке
+func (service *bookService) StoreBookLocalization(input StoreBookLocalizationInput) error {
+	_, err := service.bookRepo.FindByID(input.BookID)
+	if err != nil {
+		return err
+	}
+
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return err
+	}
+
+	return service.bookRepo.StoreLocalization(model.NewBookLocalization(
+		input.BookID,
+		language,
+		input.Title,
+		input.Description,
+	))
+}
+
+func (service *bookService) DeleteBookLocalization(bookID model.BookID, language string) error {
+	normalized, err := model.NormalizeLanguageCode(language)
+	if err != nil {
+		return err
+	}
+
+	return service.bookRepo.DeleteLocalization(bookID, normalized)
+}
+
+func normalizeOptionalLanguageCode(code maybe.Maybe[string]) (maybe.Maybe[string], error) {
+	value, ok := code.Get()
+	if !ok {
+		return code, nil
+	}
+
+	normalized, err := model.NormalizeLanguageCode(value)
+	if err != nil {
+		return maybe.Nothing[string](), err
+	}
+
+	return maybe.Just(normalized), nil
 }
@@ -1,12 +1,15 @@
 package service
 
 import (
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"sort"
 )
 
 type BookAuthorService interface {
-	StoreBookAuthor(bookID model.BookID, authorID model.AuthorID) error
+	StoreBookAuthor(input StoreBookAuthorInput) error
 	DeleteBookAuthor(bookID model.BookID, authorID model.AuthorID) error
+	ReorderBookAuthors(bookID model.BookID, authorIDs []model.AuthorID) error
 }
 
 type bookAuthorService struct {
@@ -19,15 +22,102 @@ func NewBookAuthorService(bookAuthorRepo BookAuthorRepository) *bookAuthorServic
 
 type BookAuthorRepository interface {
 	Store(bookAuthor model.BookAuthor) error
+	StoreAll(bookAuthors []model.BookAuthor) error
 	Delete(bookID model.BookID, authorID model.AuthorID) error
+	FindByBookID(bookID model.BookID) ([]model.BookAuthor, error)
 }
 
-func (service *bookAuthorService) StoreBookAuthor(bookID model.BookID, authorID model.AuthorID) error {
-	bookAuthor := model.NewBookAuthor(bookID, authorID)
+// StoreBookAuthorInput - без позиции новый автор добавляется в конец списка, а у существующего позиция не меняется
+type StoreBookAuthorInput struct {
+	BookID   model.BookID
+	AuthorID model.AuthorID
+	Role     model.BookAuthorRole
+	Position maybe.Maybe[int]
+}
+
+func (service *bookAuthorService) StoreBookAuthor(input StoreBookAuthorInput) error {
+	bookAuthors, err := service.bookAuthorRepo.FindByBookID(input.BookID)
+	if err != nil {
+		return err
+	}
+	sortBookAuthors(bookAuthors)
+
+	current := -1
+	for i, bookAuthor := range bookAuthors {
+		if bookAuthor.Author() == input.AuthorID {
+			current = i
+			break
+		}
+	}
+
+	position, hasPosition := input.Position.Get()
+	if !hasPosition {
+		if current >= 0 {
+			bookAuthor := bookAuthors[current]
+			bookAuthor.SetRole(input.Role)
+			return service.bookAuthorRepo.Store(bookAuthor)
+		}
 
-	return service.bookAuthorRepo.Store(bookAuthor)
+		nextPosition := 0
+		if len(bookAuthors) > 0 {
+			nextPosition = bookAuthors[len(bookAuthors)-1].Position() + 1
+		}
+		return service.bookAuthorRepo.Store(model.NewBookAuthor(input.BookID, input.AuthorID, input.Role, nextPosition))
+	}
+
+	if current >= 0 {
+		bookAuthors = append(bookAuthors[:current], bookAuthors[current+1:]...)
+	}
+	position = max(0, min(position, len(bookAuthors)))
+
+	bookAuthor := model.NewBookAuthor(input.BookID, input.AuthorID, input.Role, position)
+	bookAuthors = append(bookAuthors[:position], append([]model.BookAuthor{bookAuthor}, bookAuthors[position:]...)...)
+	renumberBookAuthors(bookAuthors)
+
+	return service.bookAuthorRepo.StoreAll(bookAuthors)
 }
 
 func (service *bookAuthorService) DeleteBookAuthor(bookID model.BookID, authorID model.AuthorID) error {
 	return service.bookAuthorRepo.Delete(bookID, authorID)
 }
+
+// ReorderBookAuthors задаёт порядок авторов книги, список должен содержать каждого автора книги ровно один раз
+func (service *bookAuthorService) ReorderBookAuthors(bookID model.BookID, authorIDs []model.AuthorID) error {
+	bookAuthors, err := service.bookAuthorRepo.FindByBookID(bookID)
+	if err != nil {
+		return err
+	}
+	if len(bookAuthors) != len(authorIDs) {
+		return model.ErrInvalidBookAuthorOrder
+	}
+
+	bookAuthorsByID := make(map[model.AuthorID]model.BookAuthor, len(bookAuthors))
+	for _, bookAuthor := range bookAuthors {
+		bookAuthorsByID[bookAuthor.Author()] = bookAuthor
+	}
+
+	reordered := make([]model.BookAuthor, 0, len(authorIDs))
+	for _, authorID := range authorIDs {
+		bookAuthor, ok := bookAuthorsByID[authorID]
+		if !ok {
+			return model.ErrInvalidBookAuthorOrder
+		}
+		delete(bookAuthorsByID, authorID)
+		reordered = append(reordered, bookAuthor)
+	}
+	renumberBookAuthors(reordered)
+
+	return service.bookAuthorRepo.StoreAll(reordered)
+}
+
+func sortBookAuthors(bookAuthors []model.BookAuthor) {
+	sort.SliceStable(bookAuthors, func(i, j int) bool {
+		return bookAuthors[i].Position() < bookAuthors[j].Position()
+	})
+}
+
+func renumberBookAuthors(bookAuthors []model.BookAuthor) {
+	for i := range bookAuthors {
+		bookAuthors[i].SetPosition(i)
+	}
+}
@@ -2,22 +2,38 @@ package service
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"strings"
+	"time"
 )
 
 type BookChapterService interface {
 	CreateBookChapter(input CreateBookChapterInput) error
 	EditBookChapter(input EditBookChapterInput) error
 	DeleteBookChapter(bookChapterID uuid.UUID) error
+	RestoreBookChapter(bookChapterID model.BookChapterID, metadata AuditMetadata) error
+	EditBookChapterOriginalText(input EditBookChapterOriginalTextInput) error
 }
 
 type bookChapterService struct {
 	bookChapterRepo BookChapterRepository
+	userRepo        UserRepository
+	bookOwnerRepo   BookOwnerRepository
+	auditLog        auditLogWriter
 }
 
-func NewBookChapterService(bookChapterRepo BookChapterRepository) *bookChapterService {
+func NewBookChapterService(
+	bookChapterRepo BookChapterRepository,
+	userRepo UserRepository,
+	bookOwnerRepo BookOwnerRepository,
+	auditLogRepo AuditLogRepository,
+) *bookChapterService {
 	return &bookChapterService{
 		bookChapterRepo: bookChapterRepo,
+		userRepo:        userRepo,
+		bookOwnerRepo:   bookOwnerRepo,
+		auditLog:        newAuditLogWriter(auditLogRepo),
 	}
 }
 
@@ -25,8 +41,12 @@ type BookChapterRepository interface {
 	NextID() uuid.UUID
 	Store(bookChapter model.BookChapter) error
 	Delete(bookChapterID model.BookChapterID) error
+	Restore(bookChapterID model.BookChapterID) error
+	PurgeDeleted(deletedBefore time.Time) error
 	FindByID(bookChapterID model.BookChapterID) (model.BookChapter, error)
 	ListOrderIndexesByBookID(bookID model.BookID) ([]model.BookChapter, error)
+	StoreOriginalText(bookChapterID model.BookChapterID, originalText maybe.Maybe[string]) error
+	FindOriginalText(bookChapterID model.BookChapterID) (maybe.Maybe[string], error)
 }
 
 type CreateBookChapterInput struct {
@@ -39,6 +59,12 @@ type EditBookChapterInput struct {
 	Title         string
 }
 
+type EditBookChapterOriginalTextInput struct {
+	BookChapterID model.BookChapterID
+	UserID        model.UserID
+	Text          string
+}
+
 func (service *bookChapterService) CreateBookChapter(input CreateBookChapterInput) error {
 	indexes, err := service.bookChapterRepo.ListOrderIndexesByBookID(input.BookID)
 	if err != nil {
@@ -88,6 +114,94 @@ func (service *bookChapterService) DeleteBookChapter(bookChapterID uuid.UUID) er
 	return service.restoreBookChaptersIndex(bookChapterID, bookChapters)
 }
 
+// RestoreBookChapter возвращает главу на прежнее место и сдвигает следующие за ней главы.
+// Удалённая глава хранит свой индекс, а индексы остальных глав при удалении уменьшились
+func (service *bookChapterService) RestoreBookChapter(bookChapterID model.BookChapterID, metadata AuditMetadata) error {
+	err := service.bookChapterRepo.Restore(bookChapterID)
+	if err != nil {
+		return err
+	}
+
+	bookChapter, err := service.bookChapterRepo.FindByID(bookChapterID)
+	if err != nil {
+		return err
+	}
+	bookChapters, err := service.bookChapterRepo.ListOrderIndexesByBookID(bookChapter.BookID())
+	if err != nil {
+		return err
+	}
+
+	// Пока глава была удалена, книгу могли сократить, тогда глава встаёт в конец
+	position := min(bookChapter.Index(), len(bookChapters)-1)
+	nextOrder := 0
+	for _, bookChap := range bookChapters {
+		if bookChap.ID() == bookChapterID {
+			continue
+		}
+		if nextOrder == position {
+			nextOrder++
+		}
+
+		if bookChap.Index() != nextOrder {
+			bookChap.SetIndex(nextOrder)
+
+			err = service.bookChapterRepo.Store(bookChap)
+			if err != nil {
+				return err
+			}
+		}
+		nextOrder++
+	}
+
+	bookChapter.SetIndex(position)
+
+	err = service.bookChapterRepo.Store(bookChapter)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionRestoreBookChapter,
+		model.AuditEntityBookChapter,
+		bookChapterID,
+		nil,
+		newBookChapterAuditSnapshot(bookChapter),
+	)
+
+	return nil
+}
+
+// EditBookChapterOriginalText сохраняет текст главы на языке оригинала, пустой текст удаляет его.
+// Менять оригинал могут администраторы и владелец книги
+func (service *bookChapterService) EditBookChapterOriginalText(input EditBookChapterOriginalTextInput) error {
+	bookChapter, err := service.bookChapterRepo.FindByID(input.BookChapterID)
+	if err != nil {
+		return err
+	}
+
+	user, err := service.userRepo.FindByID(input.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Role() != model.Admin {
+		isOwner, err2 := service.bookOwnerRepo.IsBookOwner(bookChapter.BookID(), input.UserID)
+		if err2 != nil {
+			return err2
+		}
+		if !isOwner {
+			return model.ErrNotBookOwner
+		}
+	}
+
+	originalText := maybe.Nothing[string]()
+	if text := strings.TrimSpace(input.Text); text != "" {
+		originalText = maybe.Just(text)
+	}
+
+	return service.bookChapterRepo.StoreOriginalText(input.BookChapterID, originalText)
+}
+
 func (service *bookChapterService) restoreBookChaptersIndex(bookChapterID uuid.UUID, bookChapters []model.BookChapter) error {
 	var isDec bool
 	for i, bookChap := range bookChapters {
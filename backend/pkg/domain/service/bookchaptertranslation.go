@@ -1,9 +1,22 @@
 package service
 
-import "server/pkg/domain/model"
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"log"
+	"server/pkg/domain/model"
+	"strings"
+	"time"
+)
 
 type BookChapterTranslationService interface {
 	StoreBookChapterTranslation(input StoreBookChapterTranslationInput) error
+	VoteBookChapterTranslation(input VoteBookChapterTranslationInput) error
+	DeleteBookChapterTranslationVote(input DeleteBookChapterTranslationVoteInput) error
+	CreateAnnotation(input CreateBookChapterTranslationAnnotationInput) (model.BookChapterTranslationAnnotationID, error)
+	EditAnnotation(input EditBookChapterTranslationAnnotationInput) error
+	DeleteAnnotation(annotationID model.BookChapterTranslationAnnotationID, translatorID model.UserID) error
 }
 
 type bookChapterTranslationService struct {
@@ -20,20 +33,279 @@ func NewBookChapterTranslationService(
 
 type BookChapterTranslationRepository interface {
 	Store(bookChapterTranslation model.BookChapterTranslation) error
+	Find(bookChapterID model.BookChapterID, translatorID model.UserID, language string) (model.BookChapterTranslation, error)
+	StoreVote(vote model.BookChapterTranslationVote) error
+	DeleteVote(bookChapterID model.BookChapterID, translatorID model.UserID, language string, userID model.UserID) error
+	NextAnnotationID() uuid.UUID
+	StoreAnnotation(annotation model.BookChapterTranslationAnnotation) error
+	FindAnnotation(annotationID model.BookChapterTranslationAnnotationID) (model.BookChapterTranslationAnnotation, error)
+	ListAnnotations(bookChapterID model.BookChapterID, translatorID model.UserID, language string) ([]model.BookChapterTranslationAnnotation, error)
+	DeleteAnnotation(annotationID model.BookChapterTranslationAnnotationID) error
 }
 
 type StoreBookChapterTranslationInput struct {
 	BookChapterID model.BookChapterID
 	TranslatorID  model.UserID
+	Language      string
 	Text          string
 }
 
+type VoteBookChapterTranslationInput struct {
+	BookChapterID model.BookChapterID
+	TranslatorID  model.UserID
+	Language      string
+	UserID        model.UserID
+	Value         int
+}
+
+type DeleteBookChapterTranslationVoteInput struct {
+	BookChapterID model.BookChapterID
+	TranslatorID  model.UserID
+	Language      string
+	UserID        model.UserID
+}
+
+type CreateBookChapterTranslationAnnotationInput struct {
+	BookChapterID model.BookChapterID
+	TranslatorID  model.UserID
+	Language      string
+	Anchor        AnnotationAnchorInput
+	Text          string
+}
+
+type EditBookChapterTranslationAnnotationInput struct {
+	AnnotationID model.BookChapterTranslationAnnotationID
+	TranslatorID model.UserID
+	Text         maybe.Maybe[string]
+	Anchor       maybe.Maybe[AnnotationAnchorInput]
+}
+
+type AnnotationAnchorInput struct {
+	ParagraphIndex int
+	Offset         int
+	Length         int
+}
+
 func (service *bookChapterTranslationService) StoreBookChapterTranslation(input StoreBookChapterTranslationInput) error {
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return err
+	}
+
+	err = model.ValidateTranslationMarkup(input.Text)
+	if err != nil {
+		return err
+	}
+	if model.IsMachineDraft(input.Text) {
+		return model.ErrUnreviewedMachineDraft
+	}
+
+	previous, err := service.bookChapterTranslationRepo.Find(input.BookChapterID, input.TranslatorID, language)
+	if err != nil && !errors.Is(err, model.ErrBookChapterTranslationNotFound) {
+		return err
+	}
+	isNew := errors.Is(err, model.ErrBookChapterTranslationNotFound)
+
 	bookChapterTranslation := model.NewBookChapterTranslation(
 		input.BookChapterID,
 		input.TranslatorID,
+		language,
 		input.Text,
 	)
 
-	return service.bookChapterTranslationRepo.Store(bookChapterTranslation)
+	err = service.bookChapterTranslationRepo.Store(bookChapterTranslation)
+	if err != nil || isNew || previous.Text() == input.Text {
+		return err
+	}
+
+	// Перевод уже сохранён, поэтому ошибка переноса не должна выглядеть для переводчика как неудачное сохранение.
+	// Непереложенное примечание останется на старом якоре, и его можно поправить вручную
+	err = service.relocateAnnotations(bookChapterTranslation)
+	if err != nil {
+		log.Printf("Failed to relocate annotations of translation %s/%s/%s: %s",
+			bookChapterTranslation.BookChapterID(), bookChapterTranslation.TranslatorID(), language, err)
+	}
+
+	return nil
+}
+
+// relocateAnnotations переносит примечания на новые места после правки перевода,
+// примечания, якорь которых больше не найден, отвязываются
+func (service *bookChapterTranslationService) relocateAnnotations(bookChapterTranslation model.BookChapterTranslation) error {
+	annotations, err := service.bookChapterTranslationRepo.ListAnnotations(
+		bookChapterTranslation.BookChapterID(),
+		bookChapterTranslation.TranslatorID(),
+		bookChapterTranslation.Language(),
+	)
+	if err != nil {
+		return err
+	}
+
+	paragraphs := model.TranslationParagraphs(bookChapterTranslation.Text())
+	for _, annotation := range annotations {
+		if annotation.Detached() {
+			continue
+		}
+
+		anchor, ok := annotation.Anchor().Relocate(paragraphs)
+		if ok {
+			annotation.SetAnchor(anchor)
+		} else {
+			annotation.Detach()
+		}
+
+		err = service.bookChapterTranslationRepo.StoreAnnotation(annotation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VoteBookChapterTranslation сохраняет голос пользователя, повторный голос заменяет предыдущий
+func (service *bookChapterTranslationService) VoteBookChapterTranslation(input VoteBookChapterTranslationInput) error {
+	if input.Value != model.TranslationVoteUp && input.Value != model.TranslationVoteDown {
+		return model.ErrInvalidTranslationVote
+	}
+	if input.UserID == input.TranslatorID {
+		return model.ErrVoteOwnTranslation
+	}
+
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.bookChapterTranslationRepo.Find(input.BookChapterID, input.TranslatorID, language)
+	if err != nil {
+		return err
+	}
+
+	return service.bookChapterTranslationRepo.StoreVote(model.NewBookChapterTranslationVote(
+		input.BookChapterID,
+		input.TranslatorID,
+		language,
+		input.UserID,
+		input.Value,
+	))
+}
+
+func (service *bookChapterTranslationService) DeleteBookChapterTranslationVote(input DeleteBookChapterTranslationVoteInput) error {
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return err
+	}
+
+	return service.bookChapterTranslationRepo.DeleteVote(input.BookChapterID, input.TranslatorID, language, input.UserID)
+}
+
+// CreateAnnotation добавляет примечание переводчика к его собственному переводу
+func (service *bookChapterTranslationService) CreateAnnotation(
+	input CreateBookChapterTranslationAnnotationInput,
+) (model.BookChapterTranslationAnnotationID, error) {
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return model.BookChapterTranslationAnnotationID{}, err
+	}
+
+	text := strings.TrimSpace(input.Text)
+	if text == "" {
+		return model.BookChapterTranslationAnnotationID{}, model.ErrEmptyAnnotationText
+	}
+
+	bookChapterTranslation, err := service.bookChapterTranslationRepo.Find(input.BookChapterID, input.TranslatorID, language)
+	if err != nil {
+		return model.BookChapterTranslationAnnotationID{}, err
+	}
+
+	anchor, err := model.NewTranslationAnnotationAnchor(
+		bookChapterTranslation.Text(),
+		input.Anchor.ParagraphIndex,
+		input.Anchor.Offset,
+		input.Anchor.Length,
+	)
+	if err != nil {
+		return model.BookChapterTranslationAnnotationID{}, err
+	}
+
+	annotation := model.NewBookChapterTranslationAnnotation(
+		model.BookChapterTranslationAnnotationID(service.bookChapterTranslationRepo.NextAnnotationID()),
+		input.BookChapterID,
+		input.TranslatorID,
+		language,
+		anchor,
+		text,
+		false,
+		time.Now(),
+	)
+
+	return annotation.ID(), service.bookChapterTranslationRepo.StoreAnnotation(annotation)
+}
+
+// EditAnnotation меняет текст примечания или переносит его на новое место, перенос снимает отвязку
+func (service *bookChapterTranslationService) EditAnnotation(input EditBookChapterTranslationAnnotationInput) error {
+	annotation, err := service.findOwnAnnotation(input.AnnotationID, input.TranslatorID)
+	if err != nil {
+		return err
+	}
+
+	if text, ok := input.Text.Get(); ok {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return model.ErrEmptyAnnotationText
+		}
+		annotation.SetText(text)
+	}
+
+	if anchorInput, ok := input.Anchor.Get(); ok {
+		bookChapterTranslation, err2 := service.bookChapterTranslationRepo.Find(
+			annotation.BookChapterID(),
+			annotation.TranslatorID(),
+			annotation.Language(),
+		)
+		if err2 != nil {
+			return err2
+		}
+
+		anchor, err2 := model.NewTranslationAnnotationAnchor(
+			bookChapterTranslation.Text(),
+			anchorInput.ParagraphIndex,
+			anchorInput.Offset,
+			anchorInput.Length,
+		)
+		if err2 != nil {
+			return err2
+		}
+		annotation.SetAnchor(anchor)
+	}
+
+	return service.bookChapterTranslationRepo.StoreAnnotation(annotation)
+}
+
+func (service *bookChapterTranslationService) DeleteAnnotation(
+	annotationID model.BookChapterTranslationAnnotationID,
+	translatorID model.UserID,
+) error {
+	_, err := service.findOwnAnnotation(annotationID, translatorID)
+	if err != nil {
+		return err
+	}
+
+	return service.bookChapterTranslationRepo.DeleteAnnotation(annotationID)
+}
+
+func (service *bookChapterTranslationService) findOwnAnnotation(
+	annotationID model.BookChapterTranslationAnnotationID,
+	translatorID model.UserID,
+) (model.BookChapterTranslationAnnotation, error) {
+	annotation, err := service.bookChapterTranslationRepo.FindAnnotation(annotationID)
+	if err != nil {
+		return model.BookChapterTranslationAnnotation{}, err
+	}
+	if annotation.TranslatorID() != translatorID {
+		return model.BookChapterTranslationAnnotation{}, model.ErrNotAnnotationTranslator
+	}
+
+	return annotation, nil
 }
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+)
+
+type BookFingerprintRepository interface {
+	FindFingerprint(bookID model.BookID) (model.BookFingerprint, error)
+	FindDuplicateCandidates(book model.BookFingerprint, viewerID maybe.Maybe[model.UserID]) ([]model.BookFingerprint, error)
+}
+
+// findBookDuplicates ищет книги, похожие на указанную, среди опубликованных книг и книг, которые видит пользователь
+func findBookDuplicates(
+	bookRepo BookFingerprintRepository,
+	book model.BookFingerprint,
+	viewerID maybe.Maybe[model.UserID],
+) ([]model.BookDuplicate, error) {
+	candidates, err := bookRepo.FindDuplicateCandidates(book, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.FindBookDuplicates(book, candidates), nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"strings"
+	"time"
+)
+
+type BookGlossaryService interface {
+	CreateTerm(input CreateBookGlossaryTermInput) (model.BookGlossaryTermID, error)
+	EditTerm(input EditBookGlossaryTermInput) error
+	DeleteTerm(termID model.BookGlossaryTermID, userID model.UserID) error
+	CheckConsistency(input CheckGlossaryConsistencyInput) ([]model.GlossaryIssue, error)
+}
+
+type bookGlossaryService struct {
+	bookGlossaryRepo BookGlossaryRepository
+	bookRepo         BookRepository
+	userRepo         UserRepository
+	bookOwnerRepo    BookOwnerRepository
+}
+
+func NewBookGlossaryService(
+	bookGlossaryRepo BookGlossaryRepository,
+	bookRepo BookRepository,
+	userRepo UserRepository,
+	bookOwnerRepo BookOwnerRepository,
+) *bookGlossaryService {
+	return &bookGlossaryService{
+		bookGlossaryRepo: bookGlossaryRepo,
+		bookRepo:         bookRepo,
+		userRepo:         userRepo,
+		bookOwnerRepo:    bookOwnerRepo,
+	}
+}
+
+type BookGlossaryRepository interface {
+	NextID() uuid.UUID
+	Store(term model.BookGlossaryTerm) error
+	FindByID(termID model.BookGlossaryTermID) (model.BookGlossaryTerm, error)
+	ListByBookID(bookID model.BookID, language string) ([]model.BookGlossaryTerm, error)
+	Delete(termID model.BookGlossaryTermID) error
+}
+
+type CreateBookGlossaryTermInput struct {
+	BookID     model.BookID
+	AuthorID   model.UserID
+	Language   string
+	SourceTerm string
+	TargetTerm string
+	Notes      maybe.Maybe[string]
+	IsShared   bool
+}
+
+type EditBookGlossaryTermInput struct {
+	TermID     model.BookGlossaryTermID
+	UserID     model.UserID
+	SourceTerm maybe.Maybe[string]
+	TargetTerm maybe.Maybe[string]
+	Notes      maybe.Maybe[string]
+	IsShared   maybe.Maybe[bool]
+}
+
+type CheckGlossaryConsistencyInput struct {
+	BookID       model.BookID
+	Language     string
+	TranslatorID maybe.Maybe[model.UserID]
+	Text         string
+}
+
+func (service *bookGlossaryService) CreateTerm(input CreateBookGlossaryTermInput) (model.BookGlossaryTermID, error) {
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return model.BookGlossaryTermID{}, err
+	}
+
+	sourceTerm, err := model.NormalizeGlossaryTerm(input.SourceTerm)
+	if err != nil {
+		return model.BookGlossaryTermID{}, err
+	}
+
+	targetTerm, err := model.NormalizeGlossaryTerm(input.TargetTerm)
+	if err != nil {
+		return model.BookGlossaryTermID{}, err
+	}
+
+	_, err = service.bookRepo.FindByID(input.BookID)
+	if err != nil {
+		return model.BookGlossaryTermID{}, err
+	}
+
+	term := model.NewBookGlossaryTerm(
+		model.BookGlossaryTermID(service.bookGlossaryRepo.NextID()),
+		input.BookID,
+		language,
+		sourceTerm,
+		targetTerm,
+		normalizeGlossaryNotes(input.Notes),
+		input.AuthorID,
+		input.IsShared,
+		time.Now(),
+	)
+
+	err = service.checkTermUnique(term)
+	if err != nil {
+		return model.BookGlossaryTermID{}, err
+	}
+
+	return term.ID(), service.bookGlossaryRepo.Store(term)
+}
+
+// EditTerm меняет термин. Личный термин правит только его автор, общий - ещё владелец книги и модераторы,
+// но делать общий термин личным или наоборот может только автор
+func (service *bookGlossaryService) EditTerm(input EditBookGlossaryTermInput) error {
+	term, err := service.findEditableTerm(input.TermID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if sourceTerm, ok := input.SourceTerm.Get(); ok {
+		sourceTerm, err = model.NormalizeGlossaryTerm(sourceTerm)
+		if err != nil {
+			return err
+		}
+		term.SetSourceTerm(sourceTerm)
+	}
+
+	if targetTerm, ok := input.TargetTerm.Get(); ok {
+		targetTerm, err = model.NormalizeGlossaryTerm(targetTerm)
+		if err != nil {
+			return err
+		}
+		term.SetTargetTerm(targetTerm)
+	}
+
+	if input.Notes.IsPresent() {
+		term.SetNotes(normalizeGlossaryNotes(input.Notes))
+	}
+
+	if isShared, ok := input.IsShared.Get(); ok && isShared != term.IsShared() {
+		if term.AuthorID() != input.UserID {
+			return model.ErrNotGlossaryTermAuthor
+		}
+		term.SetIsShared(isShared)
+	}
+
+	err = service.checkTermUnique(term)
+	if err != nil {
+		return err
+	}
+
+	return service.bookGlossaryRepo.Store(term)
+}
+
+func (service *bookGlossaryService) DeleteTerm(termID model.BookGlossaryTermID, userID model.UserID) error {
+	_, err := service.findEditableTerm(termID, userID)
+	if err != nil {
+		return err
+	}
+
+	return service.bookGlossaryRepo.Delete(termID)
+}
+
+// CheckConsistency проверяет текст по терминам, которые видит переводчик: общим и его личным.
+// Без переводчика проверка идёт только по общим терминам
+func (service *bookGlossaryService) CheckConsistency(input CheckGlossaryConsistencyInput) ([]model.GlossaryIssue, error) {
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = service.bookRepo.FindByID(input.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := service.bookGlossaryRepo.ListByBookID(input.BookID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.CheckGlossaryConsistency(model.ApplicableGlossaryTerms(terms, input.TranslatorID), input.Text), nil
+}
+
+func (service *bookGlossaryService) findEditableTerm(
+	termID model.BookGlossaryTermID,
+	userID model.UserID,
+) (model.BookGlossaryTerm, error) {
+	term, err := service.bookGlossaryRepo.FindByID(termID)
+	if err != nil {
+		return model.BookGlossaryTerm{}, err
+	}
+	if term.AuthorID() == userID {
+		return term, nil
+	}
+	if !term.IsShared() {
+		return model.BookGlossaryTerm{}, model.ErrNotGlossaryTermAuthor
+	}
+
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return model.BookGlossaryTerm{}, err
+	}
+	if user.Role() == model.Admin || user.Role() == model.Moderator {
+		return term, nil
+	}
+
+	isOwner, err := service.bookOwnerRepo.IsBookOwner(term.BookID(), userID)
+	if err != nil {
+		return model.BookGlossaryTerm{}, err
+	}
+	if !isOwner {
+		return model.BookGlossaryTerm{}, model.ErrNotGlossaryTermAuthor
+	}
+
+	return term, nil
+}
+
+func (service *bookGlossaryService) checkTermUnique(term model.BookGlossaryTerm) error {
+	terms, err := service.bookGlossaryRepo.ListByBookID(term.BookID(), term.Language())
+	if err != nil {
+		return err
+	}
+
+	for _, other := range terms {
+		if other.ID() != term.ID() && model.SameGlossaryScope(term, other) {
+			return model.ErrGlossaryTermExists
+		}
+	}
+
+	return nil
+}
+
+// normalizeGlossaryNotes - пустое пояснение не хранится
+func normalizeGlossaryNotes(notes maybe.Maybe[string]) maybe.Maybe[string] {
+	if value, ok := notes.Get(); ok {
+		if value = strings.TrimSpace(value); value != "" {
+			return maybe.Just(value)
+		}
+	}
+
+	return maybe.Nothing[string]()
+}
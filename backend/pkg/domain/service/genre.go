@@ -2,45 +2,62 @@ package service
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type GenreService interface {
 	CreateGenre(input CreateGenreInput) error
 	EditGenre(input EditGenreInput) error
-	DeleteGenre(genreID model.GenreID) error
+	DeleteGenre(genreID model.GenreID, metadata AuditMetadata) error
+	RestoreGenre(genreID model.GenreID, metadata AuditMetadata) error
 }
 
 type genreService struct {
 	genreRepo GenreRepository
+	auditLog  auditLogWriter
 }
 
-func NewGenreService(genreRepo GenreRepository) *genreService {
-	return &genreService{genreRepo: genreRepo}
+func NewGenreService(genreRepo GenreRepository, auditLogRepo AuditLogRepository) *genreService {
+	return &genreService{
+		genreRepo: genreRepo,
+		auditLog:  newAuditLogWriter(auditLogRepo),
+	}
 }
 
 type GenreRepository interface {
 	NextID() uuid.UUID
 	Store(genre model.Genre) error
 	Delete(genreID model.GenreID) error
+	Restore(genreID model.GenreID) error
+	PurgeDeleted(deletedBefore time.Time) error
 	FindByID(genreID model.GenreID) (model.Genre, error)
 }
 
 type CreateGenreInput struct {
-	Name string
+	ParentID maybe.Maybe[model.GenreID]
+	Name     string
 }
 
 type EditGenreInput struct {
-	ID   model.GenreID
-	Name string
+	ID       model.GenreID
+	ParentID maybe.Maybe[model.GenreID]
+	Name     string
 }
 
 func (service *genreService) CreateGenre(input CreateGenreInput) error {
 	genre := model.NewGenre(
 		model.GenreID(service.genreRepo.NextID()),
+		input.ParentID,
 		input.Name,
 	)
 
+	err := service.checkParent(genre)
+	if err != nil {
+		return err
+	}
+
 	return service.genreRepo.Store(genre)
 }
 
@@ -50,11 +67,78 @@ func (service *genreService) EditGenre(input EditGenreInput) error {
 		return err
 	}
 
+	genre.SetParentID(input.ParentID)
 	genre.SetName(input.Name)
 
+	err = service.checkParent(genre)
+	if err != nil {
+		return err
+	}
+
 	return service.genreRepo.Store(genre)
 }
 
-func (service *genreService) DeleteGenre(genreID model.GenreID) error {
-	return service.genreRepo.Delete(genreID)
+// checkParent проверяет, что родитель существует и жанр не оказывается среди собственных предков
+func (service *genreService) checkParent(genre model.Genre) error {
+	parentID, ok := genre.ParentID().Get()
+	for ok {
+		if parentID == genre.ID() {
+			return model.ErrGenreParentCycle
+		}
+
+		parent, err := service.genreRepo.FindByID(parentID)
+		if err != nil {
+			return err
+		}
+
+		parentID, ok = parent.ParentID().Get()
+	}
+
+	return nil
+}
+
+func (service *genreService) DeleteGenre(genreID model.GenreID, metadata AuditMetadata) error {
+	genre, err := service.genreRepo.FindByID(genreID)
+	if err != nil {
+		return err
+	}
+
+	err = service.genreRepo.Delete(genreID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionDeleteGenre,
+		model.AuditEntityGenre,
+		genreID,
+		newGenreAuditSnapshot(genre),
+		nil,
+	)
+
+	return nil
+}
+
+func (service *genreService) RestoreGenre(genreID model.GenreID, metadata AuditMetadata) error {
+	err := service.genreRepo.Restore(genreID)
+	if err != nil {
+		return err
+	}
+
+	genre, err := service.genreRepo.FindByID(genreID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionRestoreGenre,
+		model.AuditEntityGenre,
+		genreID,
+		nil,
+		newGenreAuditSnapshot(genre),
+	)
+
+	return nil
 }
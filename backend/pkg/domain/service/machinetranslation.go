@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+)
+
+// MachineTranslationProvider - движок машинного перевода. Реализации подключаются при сборке зависимостей
+type MachineTranslationProvider interface {
+	Name() string
+	// Translate переводит тексты по порядку и возвращает столько же текстов.
+	// Без языка оригинала движок определяет его сам, отмена ctx прерывает перевод
+	Translate(ctx context.Context, texts []string, sourceLanguage maybe.Maybe[string], targetLanguage string) ([]string, error)
+}
+
+type MachineTranslationService interface {
+	CreateDraft(ctx context.Context, input CreateMachineDraftInput) (MachineDraftOutput, error)
+}
+
+type machineTranslationService struct {
+	provider        MachineTranslationProvider
+	bookChapterRepo BookChapterRepository
+	bookRepo        BookRepository
+}
+
+func NewMachineTranslationService(
+	provider MachineTranslationProvider,
+	bookChapterRepo BookChapterRepository,
+	bookRepo BookRepository,
+) *machineTranslationService {
+	return &machineTranslationService{
+		provider:        provider,
+		bookChapterRepo: bookChapterRepo,
+		bookRepo:        bookRepo,
+	}
+}
+
+type CreateMachineDraftInput struct {
+	BookChapterID model.BookChapterID
+	Language      string
+}
+
+type MachineDraftOutput struct {
+	Text           string
+	Provider       string
+	SourceLanguage maybe.Maybe[string]
+	Language       string
+}
+
+// CreateDraft переводит оригинал главы машинным переводом. Черновик не сохраняется: переводчик правит его,
+// удаляет пометку черновика и сохраняет как свой перевод
+func (service *machineTranslationService) CreateDraft(ctx context.Context, input CreateMachineDraftInput) (MachineDraftOutput, error) {
+	language, err := model.NormalizeLanguageCode(input.Language)
+	if err != nil {
+		return MachineDraftOutput{}, err
+	}
+
+	bookChapter, err := service.bookChapterRepo.FindByID(input.BookChapterID)
+	if err != nil {
+		return MachineDraftOutput{}, err
+	}
+
+	originalText, err := service.bookChapterRepo.FindOriginalText(input.BookChapterID)
+	if err != nil {
+		return MachineDraftOutput{}, err
+	}
+	text, ok := originalText.Get()
+	if !ok {
+		return MachineDraftOutput{}, model.ErrBookChapterOriginalTextNotFound
+	}
+
+	book, err := service.bookRepo.FindByID(bookChapter.BookID())
+	if err != nil {
+		return MachineDraftOutput{}, err
+	}
+	sourceLanguage := book.OriginalLanguage()
+	if source, ok2 := sourceLanguage.Get(); ok2 && source == language {
+		return MachineDraftOutput{}, model.ErrMachineTranslationSameLanguage
+	}
+
+	blocks := model.SplitTranslationBlocks(text)
+	segments := model.MachineDraftSegments(blocks)
+	translated, err := service.provider.Translate(ctx, segments, sourceLanguage, language)
+	if err != nil {
+		return MachineDraftOutput{}, err
+	}
+	if len(translated) != len(segments) {
+		return MachineDraftOutput{}, model.ErrMachineTranslationFailed
+	}
+
+	return MachineDraftOutput{
+		Text:           model.BuildMachineDraft(blocks, translated, service.provider.Name()),
+		Provider:       service.provider.Name(),
+		SourceLanguage: sourceLanguage,
+		Language:       language,
+	}, nil
+}
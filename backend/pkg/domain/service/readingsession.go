@@ -1,12 +1,15 @@
 package service
 
 import (
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
 	"time"
 )
 
 type ReadingSessionService interface {
 	StoreReadingSession(input StoreReadingSessionInput) error
+	StorePreferredTranslator(input StorePreferredTranslatorInput) error
+	DeletePreferredTranslator(bookID model.BookID, userID model.UserID) error
 }
 
 type readingSessionService struct {
@@ -23,6 +26,9 @@ func NewReadingSessionService(
 
 type ReadingSessionRepository interface {
 	Store(readingSession model.ReadingSession) error
+	StorePreferredTranslator(preferredTranslator model.PreferredTranslator) error
+	DeletePreferredTranslator(bookID model.BookID, userID model.UserID) error
+	CountTranslatedChapters(bookID model.BookID, translatorID model.UserID, language maybe.Maybe[string]) (int, error)
 }
 
 type StoreReadingSessionInput struct {
@@ -31,6 +37,13 @@ type StoreReadingSessionInput struct {
 	UserID        model.UserID
 }
 
+type StorePreferredTranslatorInput struct {
+	BookID       model.BookID
+	UserID       model.UserID
+	TranslatorID model.UserID
+	Language     maybe.Maybe[string]
+}
+
 func (service *readingSessionService) StoreReadingSession(input StoreReadingSessionInput) error {
 	readingSession := model.NewReadingSession(
 		input.BookID,
@@ -41,3 +54,30 @@ func (service *readingSessionService) StoreReadingSession(input StoreReadingSess
 
 	return service.readingSessionRepo.Store(readingSession)
 }
+
+// StorePreferredTranslator запоминает переводчика книги для читателя, переводчик должен перевести хотя бы одну главу
+func (service *readingSessionService) StorePreferredTranslator(input StorePreferredTranslatorInput) error {
+	language, err := normalizeOptionalLanguageCode(input.Language)
+	if err != nil {
+		return err
+	}
+
+	count, err := service.readingSessionRepo.CountTranslatedChapters(input.BookID, input.TranslatorID, language)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.ErrTranslatorNotTranslatingBook
+	}
+
+	return service.readingSessionRepo.StorePreferredTranslator(model.NewPreferredTranslator(
+		input.UserID,
+		input.BookID,
+		input.TranslatorID,
+		language,
+	))
+}
+
+func (service *readingSessionService) DeletePreferredTranslator(bookID model.BookID, userID model.UserID) error {
+	return service.readingSessionRepo.DeletePreferredTranslator(bookID, userID)
+}
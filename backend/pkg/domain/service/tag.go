@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type TagService interface {
+	SuggestBookTag(input SuggestBookTagInput) (model.TagID, error)
+	DeleteBookTag(bookTag model.BookTag) error
+	ApproveTag(tagID model.TagID, metadata AuditMetadata) error
+	RejectTag(tagID model.TagID, metadata AuditMetadata) error
+}
+
+type tagService struct {
+	tagRepo  TagRepository
+	bookRepo BookRepository
+	auditLog auditLogWriter
+}
+
+func NewTagService(
+	tagRepo TagRepository,
+	bookRepo BookRepository,
+	auditLogRepo AuditLogRepository,
+) *tagService {
+	return &tagService{
+		tagRepo:  tagRepo,
+		bookRepo: bookRepo,
+		auditLog: newAuditLogWriter(auditLogRepo),
+	}
+}
+
+type TagRepository interface {
+	NextID() uuid.UUID
+	Store(tag model.Tag) error
+	FindByID(tagID model.TagID) (model.Tag, error)
+	FindByName(name string) (model.Tag, error)
+	StoreBookTag(bookTag model.BookTag) error
+	DeleteBookTag(bookTag model.BookTag) error
+}
+
+type SuggestBookTagInput struct {
+	BookID model.BookID
+	UserID model.UserID
+	Name   string
+}
+
+// SuggestBookTag отмечает книгу тегом от имени пользователя. Неизвестный тег создаётся
+// на рассмотрении и появляется в облаке тегов только после одобрения администратором
+func (service *tagService) SuggestBookTag(input SuggestBookTagInput) (model.TagID, error) {
+	name, err := model.NormalizeTagName(input.Name)
+	if err != nil {
+		return model.TagID{}, err
+	}
+
+	_, err = service.bookRepo.FindByID(input.BookID)
+	if err != nil {
+		return model.TagID{}, err
+	}
+
+	tag, err := service.tagRepo.FindByName(name)
+	if errors.Is(err, model.ErrTagNotFound) {
+		tag = model.NewTag(
+			model.TagID(service.tagRepo.NextID()),
+			name,
+			model.TagPending,
+			maybe.Just(input.UserID),
+			time.Now(),
+		)
+		err = service.tagRepo.Store(tag)
+	}
+	if err != nil {
+		return model.TagID{}, err
+	}
+
+	if tag.Status() == model.TagRejected {
+		return model.TagID{}, model.ErrTagRejected
+	}
+
+	err = service.tagRepo.StoreBookTag(model.NewBookTag(input.BookID, tag.ID(), input.UserID))
+	if err != nil {
+		return model.TagID{}, err
+	}
+
+	return tag.ID(), nil
+}
+
+func (service *tagService) DeleteBookTag(bookTag model.BookTag) error {
+	return service.tagRepo.DeleteBookTag(bookTag)
+}
+
+func (service *tagService) ApproveTag(tagID model.TagID, metadata AuditMetadata) error {
+	return service.moderateTag(tagID, model.TagApproved, model.AuditActionApproveTag, metadata)
+}
+
+func (service *tagService) RejectTag(tagID model.TagID, metadata AuditMetadata) error {
+	return service.moderateTag(tagID, model.TagRejected, model.AuditActionRejectTag, metadata)
+}
+
+func (service *tagService) moderateTag(
+	tagID model.TagID,
+	status model.TagStatus,
+	action model.AuditAction,
+	metadata AuditMetadata,
+) error {
+	tag, err := service.tagRepo.FindByID(tagID)
+	if err != nil {
+		return err
+	}
+
+	before := newTagAuditSnapshot(tag)
+	tag.SetStatus(status)
+
+	err = service.tagRepo.Store(tag)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		action,
+		model.AuditEntityTag,
+		tagID,
+		before,
+		newTagAuditSnapshot(tag),
+	)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"time"
+)
+
+type TrashService interface {
+	PurgeExpired() error
+}
+
+type trashService struct {
+	bookRepo        BookRepository
+	bookChapterRepo BookChapterRepository
+	authorRepo      AuthorRepository
+	genreRepo       GenreRepository
+	retention       time.Duration
+}
+
+func NewTrashService(
+	bookRepo BookRepository,
+	bookChapterRepo BookChapterRepository,
+	authorRepo AuthorRepository,
+	genreRepo GenreRepository,
+	retention time.Duration,
+) *trashService {
+	return &trashService{
+		bookRepo:        bookRepo,
+		bookChapterRepo: bookChapterRepo,
+		authorRepo:      authorRepo,
+		genreRepo:       genreRepo,
+		retention:       retention,
+	}
+}
+
+// PurgeExpired окончательно удаляет всё, что пролежало в корзине дольше срока хранения
+func (service *trashService) PurgeExpired() error {
+	deletedBefore := time.Now().Add(-service.retention)
+
+	purges := []func(deletedBefore time.Time) error{
+		service.bookChapterRepo.PurgeDeleted,
+		service.bookRepo.PurgeDeleted,
+		service.authorRepo.PurgeDeleted,
+		service.genreRepo.PurgeDeleted,
+	}
+	for _, purge := range purges {
+		err := purge(deletedBefore)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -10,15 +10,20 @@ type UserService interface {
 	CreateUser(input CreateUserInput) error
 	EditUser(input EditUserInput) error
 	EditImageUser(input EditUserImageInput) error
-	DeleteUser(userID model.UserID) error
+	DeleteUser(userID model.UserID, metadata AuditMetadata) error
+	DeleteCurrentUser(input DeleteCurrentUserInput) error
 }
 
 type userService struct {
 	userRepo UserRepository
+	auditLog auditLogWriter
 }
 
-func NewUserService(userRepo UserRepository) *userService {
-	return &userService{userRepo: userRepo}
+func NewUserService(userRepo UserRepository, auditLogRepo AuditLogRepository) *userService {
+	return &userService{
+		userRepo: userRepo,
+		auditLog: newAuditLogWriter(auditLogRepo),
+	}
 }
 
 type UserRepository interface {
@@ -39,6 +44,13 @@ type EditUserInput struct {
 	Login    string
 	Password string
 	AboutMe  string
+	Metadata AuditMetadata
+}
+
+type DeleteCurrentUserInput struct {
+	ID       model.UserID
+	Password string
+	Metadata AuditMetadata
 }
 
 type EditUserImageInput struct {
@@ -65,11 +77,26 @@ func (service *userService) EditUser(input EditUserInput) error {
 		return err
 	}
 
+	before := newUserAuditSnapshot(user)
 	user.SetLogin(input.Login)
 	user.SetPassword(input.Password)
 	user.SetAboutMe(input.AboutMe)
 
-	return service.userRepo.Store(user)
+	err = service.userRepo.Store(user)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		input.Metadata,
+		model.AuditActionEditUser,
+		model.AuditEntityUser,
+		uuid.UUID(user.ID()),
+		before,
+		newUserAuditSnapshot(user),
+	)
+
+	return nil
 }
 
 func (service *userService) EditImageUser(input EditUserImageInput) error {
@@ -83,15 +110,47 @@ func (service *userService) EditImageUser(input EditUserImageInput) error {
 	return service.userRepo.Store(user)
 }
 
-func (service *userService) DeleteUser(userID model.UserID) error {
+func (service *userService) DeleteUser(userID model.UserID, metadata AuditMetadata) error {
 	user, err := service.userRepo.FindByID(userID)
 	if err != nil {
 		return err
 	}
 
+	return service.deleteUser(user, metadata)
+}
+
+// DeleteCurrentUser требует подтверждения паролем, так как удаление аккаунта необратимо
+func (service *userService) DeleteCurrentUser(input DeleteCurrentUserInput) error {
+	user, err := service.userRepo.FindByID(input.ID)
+	if err != nil {
+		return err
+	}
+
+	if user.Password() != input.Password {
+		return model.ErrInvalidPassword
+	}
+
+	return service.deleteUser(user, input.Metadata)
+}
+
+func (service *userService) deleteUser(user model.User, metadata AuditMetadata) error {
 	if user.Role() == model.Admin {
 		return model.ErrNotDeleteAdmin
 	}
 
-	return service.userRepo.Delete(userID)
+	err := service.userRepo.Delete(user.ID())
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		metadata,
+		model.AuditActionDeleteUser,
+		model.AuditEntityUser,
+		uuid.UUID(user.ID()),
+		newUserAuditSnapshot(user),
+		nil,
+	)
+
+	return nil
 }
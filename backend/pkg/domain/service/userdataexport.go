@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type UserDataExportService interface {
+	RequestUserDataExport(userID model.UserID) (RequestUserDataExportOutput, error)
+	CompleteUserDataExport(userDataExportID model.UserDataExportID, archive []byte) error
+	FailUserDataExport(userDataExportID model.UserDataExportID) error
+	FailPendingUserDataExports() error
+	PurgeExpired() error
+}
+
+type userDataExportService struct {
+	userDataExportRepo UserDataExportRepository
+	retention          time.Duration
+}
+
+func NewUserDataExportService(userDataExportRepo UserDataExportRepository, retention time.Duration) *userDataExportService {
+	return &userDataExportService{
+		userDataExportRepo: userDataExportRepo,
+		retention:          retention,
+	}
+}
+
+type UserDataExportRepository interface {
+	NextID() uuid.UUID
+	Store(userDataExport model.UserDataExport) error
+	StorePending(userDataExport model.UserDataExport) error
+	StoreArchive(userDataExportID model.UserDataExportID, archive []byte) error
+	FindByID(userDataExportID model.UserDataExportID) (model.UserDataExport, error)
+	FindPendingByUserID(userID model.UserID) (model.UserDataExport, error)
+	FailPending(completedAt time.Time) error
+	PurgeCompleted(completedBefore time.Time) error
+}
+
+type RequestUserDataExportOutput struct {
+	UserDataExportID model.UserDataExportID
+	// IsNew - выгрузка только что поставлена в очередь и её нужно собрать
+	IsNew bool
+}
+
+// RequestUserDataExport ставит выгрузку в очередь. Пока предыдущая выгрузка пользователя не собрана,
+// новая не создаётся и возвращается предыдущая
+func (service *userDataExportService) RequestUserDataExport(userID model.UserID) (RequestUserDataExportOutput, error) {
+	userDataExport := model.NewUserDataExport(
+		model.UserDataExportID(service.userDataExportRepo.NextID()),
+		userID,
+		model.UserDataExportPending,
+		time.Now(),
+		maybe.Nothing[time.Time](),
+	)
+
+	err := service.userDataExportRepo.StorePending(userDataExport)
+	if errors.Is(err, model.ErrUserDataExportPending) {
+		pending, err2 := service.userDataExportRepo.FindPendingByUserID(userID)
+		if err2 != nil {
+			return RequestUserDataExportOutput{}, err2
+		}
+
+		return RequestUserDataExportOutput{UserDataExportID: pending.ID()}, nil
+	}
+	if err != nil {
+		return RequestUserDataExportOutput{}, err
+	}
+
+	return RequestUserDataExportOutput{UserDataExportID: userDataExport.ID(), IsNew: true}, nil
+}
+
+func (service *userDataExportService) CompleteUserDataExport(userDataExportID model.UserDataExportID, archive []byte) error {
+	userDataExport, err := service.userDataExportRepo.FindByID(userDataExportID)
+	if err != nil {
+		return err
+	}
+
+	err = service.userDataExportRepo.StoreArchive(userDataExportID, archive)
+	if err != nil {
+		return err
+	}
+
+	userDataExport.SetStatus(model.UserDataExportReady)
+	userDataExport.SetCompletedAt(maybe.Just(time.Now()))
+
+	return service.userDataExportRepo.Store(userDataExport)
+}
+
+func (service *userDataExportService) FailUserDataExport(userDataExportID model.UserDataExportID) error {
+	userDataExport, err := service.userDataExportRepo.FindByID(userDataExportID)
+	if err != nil {
+		return err
+	}
+
+	userDataExport.SetStatus(model.UserDataExportFailed)
+	userDataExport.SetCompletedAt(maybe.Just(time.Now()))
+
+	return service.userDataExportRepo.Store(userDataExport)
+}
+
+// FailPendingUserDataExports вызывается при запуске сервера: выгрузки собираются в его процессе,
+// поэтому оставшиеся в очереди выгрузки уже никто не соберёт, и пользователь может запросить новую
+func (service *userDataExportService) FailPendingUserDataExports() error {
+	return service.userDataExportRepo.FailPending(time.Now())
+}
+
+// PurgeExpired удаляет выгрузки, завершённые раньше срока хранения, чтобы архивы не копились в базе
+func (service *userDataExportService) PurgeExpired() error {
+	return service.userDataExportRepo.PurgeCompleted(time.Now().Add(-service.retention))
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type UserRestrictionService interface {
+	RestrictUser(input RestrictUserInput) error
+	LiftUserRestriction(input LiftUserRestrictionInput) error
+	CheckCanLogin(userID model.UserID) error
+	CheckCanPublish(userID model.UserID) error
+}
+
+type userRestrictionService struct {
+	userRestrictionRepo UserRestrictionRepository
+	userRepo            UserRepository
+	auditLog            auditLogWriter
+}
+
+func NewUserRestrictionService(
+	userRestrictionRepo UserRestrictionRepository,
+	userRepo UserRepository,
+	auditLogRepo AuditLogRepository,
+) *userRestrictionService {
+	return &userRestrictionService{
+		userRestrictionRepo: userRestrictionRepo,
+		userRepo:            userRepo,
+		auditLog:            newAuditLogWriter(auditLogRepo),
+	}
+}
+
+type UserRestrictionRepository interface {
+	NextID() uuid.UUID
+	Store(userRestriction model.UserRestriction) error
+	Delete(userRestrictionID model.UserRestrictionID) error
+	FindByID(userRestrictionID model.UserRestrictionID) (model.UserRestriction, error)
+	ListByUserID(userID model.UserID) ([]model.UserRestriction, error)
+}
+
+type RestrictUserInput struct {
+	UserID    model.UserID
+	IssuedBy  model.UserID
+	Type      model.UserRestrictionType
+	Reason    string
+	ExpiresAt maybe.Maybe[time.Time]
+	Metadata  AuditMetadata
+}
+
+type LiftUserRestrictionInput struct {
+	UserRestrictionID model.UserRestrictionID
+	LiftedBy          model.UserID
+	Metadata          AuditMetadata
+}
+
+func (service *userRestrictionService) RestrictUser(input RestrictUserInput) error {
+	err := service.checkCanManageRestrictions(input.IssuedBy, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	userRestriction := model.NewUserRestriction(
+		model.UserRestrictionID(service.userRestrictionRepo.NextID()),
+		input.UserID,
+		input.IssuedBy,
+		input.Type,
+		input.Reason,
+		time.Now(),
+		input.ExpiresAt,
+	)
+
+	err = service.userRestrictionRepo.Store(userRestriction)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		input.Metadata,
+		model.AuditActionRestrictUser,
+		model.AuditEntityUserRestriction,
+		userRestriction.ID(),
+		nil,
+		newUserRestrictionAuditSnapshot(userRestriction),
+	)
+
+	return nil
+}
+
+func (service *userRestrictionService) LiftUserRestriction(input LiftUserRestrictionInput) error {
+	userRestriction, err := service.userRestrictionRepo.FindByID(input.UserRestrictionID)
+	if err != nil {
+		return err
+	}
+
+	err = service.checkCanManageRestrictions(input.LiftedBy, userRestriction.UserID())
+	if err != nil {
+		return err
+	}
+
+	err = service.userRestrictionRepo.Delete(input.UserRestrictionID)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		input.Metadata,
+		model.AuditActionLiftUserRestriction,
+		model.AuditEntityUserRestriction,
+		input.UserRestrictionID,
+		newUserRestrictionAuditSnapshot(userRestriction),
+		nil,
+	)
+
+	return nil
+}
+
+// checkCanManageRestrictions не даёт снимать и выдавать ограничения себе, администраторам,
+// а модератору - другим модераторам
+func (service *userRestrictionService) checkCanManageRestrictions(actorID, targetID model.UserID) error {
+	if actorID == targetID {
+		return model.ErrNotRestrictSelf
+	}
+
+	target, err := service.userRepo.FindByID(targetID)
+	if err != nil {
+		return err
+	}
+
+	if target.Role() == model.Admin {
+		return model.ErrNotRestrictAdmin
+	}
+
+	if target.Role() != model.Moderator {
+		return nil
+	}
+
+	actor, err := service.userRepo.FindByID(actorID)
+	if err != nil {
+		return err
+	}
+
+	if actor.Role() != model.Admin {
+		return model.ErrNotRestrictModerator
+	}
+
+	return nil
+}
+
+func (service *userRestrictionService) CheckCanLogin(userID model.UserID) error {
+	restrictions, err := service.userRestrictionRepo.ListByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, restriction := range restrictions {
+		if restriction.Type() == model.SUSPENSION && restriction.IsActive(now) {
+			return model.ErrUserSuspended
+		}
+	}
+
+	return nil
+}
+
+// CheckCanPublish запрещает публикацию как заглушённым, так и заблокированным пользователям
+func (service *userRestrictionService) CheckCanPublish(userID model.UserID) error {
+	restrictions, err := service.userRestrictionRepo.ListByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, restriction := range restrictions {
+		if !restriction.IsActive(now) {
+			continue
+		}
+
+		switch restriction.Type() {
+		case model.SUSPENSION:
+			return model.ErrUserSuspended
+		case model.MUTE:
+			return model.ErrUserMuted
+		}
+	}
+
+	return nil
+}
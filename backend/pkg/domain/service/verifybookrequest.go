@@ -8,28 +8,40 @@ import (
 )
 
 type VerifyBookRequestService interface {
-	CreateVerifyBookRequest(input CreateVerifyBookRequestInput) error
+	CreateVerifyBookRequest(input CreateVerifyBookRequestInput) (CreateVerifyBookRequestOutput, error)
 	AcceptVerifyBookRequest(input AcceptVerifyBookRequestInput) error
 	DeleteVerifyBookRequest(verifyBookRequestID model.VerifyBookRequestID) error
 }
 
 type verifyBookRequestService struct {
 	verifyBookRequestRepo VerifyBookRequestRepository
+	bookRepo              BookFingerprintRepository
+	auditLog              auditLogWriter
 }
 
 func NewVerifyBookRequestService(
 	verifyBookRequestRepo VerifyBookRequestRepository,
+	bookRepo BookFingerprintRepository,
+	auditLogRepo AuditLogRepository,
 ) *verifyBookRequestService {
 	return &verifyBookRequestService{
 		verifyBookRequestRepo: verifyBookRequestRepo,
+		bookRepo:              bookRepo,
+		auditLog:              newAuditLogWriter(auditLogRepo),
 	}
 }
 
 type VerifyBookRequestRepository interface {
 	NextID() uuid.UUID
 	Store(verifyBookRequest model.VerifyBookRequest) error
+	StoreDuplicates(verifyBookRequestID model.VerifyBookRequestID, duplicates []model.BookDuplicate) error
 	Delete(verifyBookRequestID model.VerifyBookRequestID) error
 	FindByID(verifyBookRequestID model.VerifyBookRequestID) (model.VerifyBookRequest, error)
+	BookOwnerRepository
+}
+
+type BookOwnerRepository interface {
+	IsBookOwner(bookID model.BookID, userID model.UserID) (bool, error)
 }
 
 type CreateVerifyBookRequestInput struct {
@@ -37,12 +49,29 @@ type CreateVerifyBookRequestInput struct {
 	BookID       model.BookID
 }
 
+// CreateVerifyBookRequestOutput содержит похожие книги, которые администратор увидит в очереди проверки
+type CreateVerifyBookRequestOutput struct {
+	VerifyBookRequestID model.VerifyBookRequestID
+	Duplicates          []model.BookDuplicate
+}
+
 type AcceptVerifyBookRequestInput struct {
 	VerifyBookRequestID model.VerifyBookRequestID
 	Accept              bool
+	Metadata            AuditMetadata
 }
 
-func (service *verifyBookRequestService) CreateVerifyBookRequest(input CreateVerifyBookRequestInput) error {
+func (service *verifyBookRequestService) CreateVerifyBookRequest(input CreateVerifyBookRequestInput) (CreateVerifyBookRequestOutput, error) {
+	book, err := service.bookRepo.FindFingerprint(input.BookID)
+	if err != nil {
+		return CreateVerifyBookRequestOutput{}, err
+	}
+
+	duplicates, err := findBookDuplicates(service.bookRepo, book, maybe.Just(input.TranslatorID))
+	if err != nil {
+		return CreateVerifyBookRequestOutput{}, err
+	}
+
 	verifyBookRequest := model.NewVerifyBookRequest(
 		service.verifyBookRequestRepo.NextID(),
 		input.TranslatorID,
@@ -51,7 +80,20 @@ func (service *verifyBookRequestService) CreateVerifyBookRequest(input CreateVer
 		time.Now(),
 	)
 
-	return service.verifyBookRequestRepo.Store(verifyBookRequest)
+	err = service.verifyBookRequestRepo.Store(verifyBookRequest)
+	if err != nil {
+		return CreateVerifyBookRequestOutput{}, err
+	}
+
+	err = service.verifyBookRequestRepo.StoreDuplicates(verifyBookRequest.VerifyBookRequestID(), duplicates)
+	if err != nil {
+		return CreateVerifyBookRequestOutput{}, err
+	}
+
+	return CreateVerifyBookRequestOutput{
+		VerifyBookRequestID: verifyBookRequest.VerifyBookRequestID(),
+		Duplicates:          duplicates,
+	}, nil
 }
 
 func (service *verifyBookRequestService) AcceptVerifyBookRequest(input AcceptVerifyBookRequestInput) error {
@@ -60,9 +102,24 @@ func (service *verifyBookRequestService) AcceptVerifyBookRequest(input AcceptVer
 		return err
 	}
 
+	before := newVerifyBookRequestAuditSnapshot(verifyBookRequest)
 	verifyBookRequest.SetIsVerified(maybe.Just(input.Accept))
 
-	return service.verifyBookRequestRepo.Store(verifyBookRequest)
+	err = service.verifyBookRequestRepo.Store(verifyBookRequest)
+	if err != nil {
+		return err
+	}
+
+	service.auditLog.write(
+		input.Metadata,
+		model.AuditActionAcceptVerifyBookRequest,
+		model.AuditEntityVerifyBookRequest,
+		verifyBookRequest.VerifyBookRequestID(),
+		before,
+		newVerifyBookRequestAuditSnapshot(verifyBookRequest),
+	)
+
+	return nil
 }
 
 func (service *verifyBookRequestService) DeleteVerifyBookRequest(verifyBookRequestID model.VerifyBookRequestID) error {
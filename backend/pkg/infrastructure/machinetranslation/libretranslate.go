@@ -0,0 +1,129 @@
+package machinetranslation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mono83/maybe"
+	"net/http"
+	"server/pkg/domain/model"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// autoLanguage - код, по которому движок сам определяет язык оригинала
+	autoLanguage = "auto"
+
+	// libreTranslateTimeout - общее время на перевод главы целиком, а не на один запрос
+	libreTranslateTimeout = time.Minute
+	// maxBatchCharacters - сколько символов отправляется одним запросом, самостоятельно развёрнутые движки обычно ограничивают размер запроса
+	maxBatchCharacters = 5000
+)
+
+// libreTranslateProvider - драйвер для движков с API LibreTranslate (POST /translate)
+type libreTranslateProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewLibreTranslateProvider(baseURL, apiKey string) *libreTranslateProvider {
+	return &libreTranslateProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{},
+	}
+}
+
+func (provider *libreTranslateProvider) Name() string {
+	return "LibreTranslate"
+}
+
+// Translate отправляет тексты пачками, чтобы длинная глава не упиралась в ограничение движка на размер запроса
+func (provider *libreTranslateProvider) Translate(
+	ctx context.Context,
+	texts []string,
+	sourceLanguage maybe.Maybe[string],
+	targetLanguage string,
+) ([]string, error) {
+	source := sourceLanguage.OrElse(autoLanguage)
+
+	ctx, cancel := context.WithTimeout(ctx, libreTranslateTimeout)
+	defer cancel()
+
+	translated := make([]string, 0, len(texts))
+	for start := 0; start < len(texts); {
+		end, characters := start, 0
+		for end < len(texts) && (end == start || characters+utf8.RuneCountInString(texts[end]) <= maxBatchCharacters) {
+			characters += utf8.RuneCountInString(texts[end])
+			end++
+		}
+
+		batch, err := provider.translateBatch(ctx, texts[start:end], source, targetLanguage)
+		if err != nil {
+			return nil, err
+		}
+		translated = append(translated, batch...)
+		start = end
+	}
+
+	return translated, nil
+}
+
+func (provider *libreTranslateProvider) translateBatch(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	body, err := json.Marshal(libreTranslateRequest{
+		Q:      texts,
+		Source: source,
+		Target: target,
+		Format: "text",
+		APIKey: provider.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.baseURL+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := provider.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrMachineTranslationFailed, err)
+	}
+	defer response.Body.Close()
+
+	var result libreTranslateResponse
+	err = json.NewDecoder(response.Body).Decode(&result)
+	if response.StatusCode != http.StatusOK {
+		if result.Error == "" {
+			result.Error = response.Status
+		}
+		return nil, fmt.Errorf("%w: %s", model.ErrMachineTranslationFailed, result.Error)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrMachineTranslationFailed, err)
+	}
+	if len(result.TranslatedText) != len(texts) {
+		return nil, fmt.Errorf("%w: got %d texts instead of %d", model.ErrMachineTranslationFailed, len(result.TranslatedText), len(texts))
+	}
+
+	return result.TranslatedText, nil
+}
+
+type libreTranslateRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+	Error          string   `json:"error"`
+}
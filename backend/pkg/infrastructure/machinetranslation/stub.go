@@ -0,0 +1,30 @@
+package machinetranslation
+
+import (
+	"context"
+	"fmt"
+	"github.com/mono83/maybe"
+)
+
+// stubProvider ничего не переводит, а помечает каждый текст парой языков.
+// Используется, когда движок перевода не настроен, и даёт одинаковый результат при каждом вызове
+type stubProvider struct{}
+
+func NewStubProvider() *stubProvider {
+	return &stubProvider{}
+}
+
+func (provider *stubProvider) Name() string {
+	return "stub"
+}
+
+func (provider *stubProvider) Translate(_ context.Context, texts []string, sourceLanguage maybe.Maybe[string], targetLanguage string) ([]string, error) {
+	source := sourceLanguage.OrElse(autoLanguage)
+
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = fmt.Sprintf("[%s→%s] %s", source, targetLanguage, text)
+	}
+
+	return translated, nil
+}
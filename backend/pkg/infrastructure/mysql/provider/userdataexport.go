@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"mime"
+	"server/pkg/domain/model"
+	"strings"
+	"time"
+)
+
+type UserDataExportProvider interface {
+	BuildArchive(userID model.UserID) ([]byte, error)
+}
+
+type userDataExportProvider struct {
+	connection *sqlx.DB
+}
+
+func NewUserDataExportProvider(connection *sqlx.DB) *userDataExportProvider {
+	return &userDataExportProvider{connection}
+}
+
+// BuildArchive собирает ZIP-архив: по JSON-файлу на каждый вид данных и аватар в каталоге media
+func (provider *userDataExportProvider) BuildArchive(userID model.UserID) ([]byte, error) {
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var profile exportProfile
+	err = provider.connection.Get(&profile, `
+		SELECT
+			user_id,
+			login,
+			role,
+			about_me,
+			avatar_id
+		FROM user
+		WHERE user_id = ?
+	`, binaryUserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ratings []exportBookRating
+	err = provider.connection.Select(&ratings, `
+		SELECT
+			book_id,
+			value
+		FROM book_rating
+		WHERE user_id = ?
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var favourites []exportUserBookFavourites
+	err = provider.connection.Select(&favourites, `
+		SELECT
+			book_id,
+			type
+		FROM user_book_favourites
+		WHERE user_id = ?
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var readingSessions []exportReadingSession
+	err = provider.connection.Select(&readingSessions, `
+		SELECT
+			book_id,
+			book_chapter_id,
+			last_read_time
+		FROM reading_session
+		WHERE user_id = ?
+		ORDER BY last_read_time
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var preferredTranslators []exportPreferredTranslator
+	err = provider.connection.Select(&preferredTranslators, `
+		SELECT
+			book_id,
+			translator_id,
+			language
+		FROM preferred_translator
+		WHERE user_id = ?
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []exportBookComment
+	err = provider.connection.Select(&comments, `
+		SELECT
+			book_comment_id,
+			book_id,
+			comment,
+			created_at
+		FROM book_comment
+		WHERE user_id = ?
+		ORDER BY created_at
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var translations []exportBookChapterTranslation
+	err = provider.connection.Select(&translations, `
+		SELECT
+			bc.book_id,
+			bct.book_chapter_id,
+			bct.language,
+			bct.text
+		FROM book_chapter_translation bct
+		INNER JOIN book_chapter bc ON bc.book_chapter_id = bct.book_chapter_id
+		WHERE bct.translator_id = ?
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var translationAnnotations []exportBookChapterTranslationAnnotation
+	err = provider.connection.Select(&translationAnnotations, `
+		SELECT
+			annotation_id,
+			book_chapter_id,
+			language,
+			paragraph_index,
+			char_offset,
+			anchor_text,
+			text,
+			created_at
+		FROM book_chapter_translation_annotation
+		WHERE translator_id = ?
+		ORDER BY created_at
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var glossaryTerms []exportBookGlossaryTerm
+	err = provider.connection.Select(&glossaryTerms, `
+		SELECT
+			glossary_term_id,
+			book_id,
+			language,
+			source_term,
+			target_term,
+			notes,
+			is_shared,
+			created_at
+		FROM book_glossary_term
+		WHERE author_id = ?
+		ORDER BY created_at
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var translationVotes []exportBookChapterTranslationVote
+	err = provider.connection.Select(&translationVotes, `
+		SELECT
+			book_chapter_id,
+			translator_id,
+			language,
+			value
+		FROM book_chapter_translation_vote
+		WHERE user_id = ?
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var verifyBookRequests []exportVerifyBookRequest
+	err = provider.connection.Select(&verifyBookRequests, `
+		SELECT
+			verify_book_request_id,
+			book_id,
+			is_verified,
+			send_date
+		FROM verify_book_request
+		WHERE translator_id = ?
+		ORDER BY send_date
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var bookTags []exportBookTag
+	err = provider.connection.Select(&bookTags, `
+		SELECT
+			bt.book_id,
+			t.name
+		FROM book_tag bt
+		INNER JOIN tag t ON t.tag_id = bt.tag_id
+		WHERE bt.user_id = ?
+	`, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	archive := zip.NewWriter(buffer)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", profile},
+		{"ratings.json", nonNil(ratings)},
+		{"favourites.json", nonNil(favourites)},
+		{"reading_sessions.json", nonNil(readingSessions)},
+		{"preferred_translators.json", nonNil(preferredTranslators)},
+		{"comments.json", nonNil(comments)},
+		{"translations.json", nonNil(translations)},
+		{"translation_annotations.json", nonNil(translationAnnotations)},
+		{"glossary_terms.json", nonNil(glossaryTerms)},
+		{"translation_votes.json", nonNil(translationVotes)},
+		{"verify_book_requests.json", nonNil(verifyBookRequests)},
+		{"book_tags.json", nonNil(bookTags)},
+	}
+	for _, file := range files {
+		err = writeJSONToArchive(archive, file.name, file.data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if profile.AvatarID.Valid {
+		err = provider.writeAvatarToArchive(archive, profile.AvatarID.UUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (provider *userDataExportProvider) writeAvatarToArchive(archive *zip.Writer, avatarID uuid.UUID) error {
+	binaryAvatarID, err := avatarID.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var imageData string
+	err = provider.connection.Get(&imageData, `SELECT path FROM image WHERE image_id = ?`, binaryAvatarID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	name, data := decodeImageData(imageData)
+	writer, err := archive.Create("media/avatar" + name)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+	return err
+}
+
+// decodeImageData раскрывает data URL (data:image/png;base64,...), остальные данные сохраняются как есть
+func decodeImageData(imageData string) (string, []byte) {
+	header, payload, found := strings.Cut(imageData, ",")
+	if !found || !strings.HasPrefix(header, "data:") || !strings.HasSuffix(header, ";base64") {
+		return "", []byte(imageData)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", []byte(imageData)
+	}
+
+	extension := ""
+	mediaType := strings.TrimSuffix(strings.TrimPrefix(header, "data:"), ";base64")
+	if extensions, err2 := mime.ExtensionsByType(mediaType); err2 == nil && len(extensions) > 0 {
+		extension = extensions[0]
+	}
+
+	return extension, data
+}
+
+func writeJSONToArchive(archive *zip.Writer, name string, data any) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(data)
+}
+
+// nonNil нужен, чтобы пустые списки выгружались как [], а не null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
+
+type exportProfile struct {
+	UserID   uuid.UUID     `db:"user_id" json:"id"`
+	Login    string        `db:"login" json:"login"`
+	Role     int           `db:"role" json:"role"`
+	AboutMe  string        `db:"about_me" json:"aboutMe"`
+	AvatarID uuid.NullUUID `db:"avatar_id" json:"-"`
+}
+
+type exportBookRating struct {
+	BookID uuid.UUID `db:"book_id" json:"bookId"`
+	Value  int       `db:"value" json:"value"`
+}
+
+type exportUserBookFavourites struct {
+	BookID uuid.UUID `db:"book_id" json:"bookId"`
+	Type   int       `db:"type" json:"type"`
+}
+
+type exportReadingSession struct {
+	BookID        uuid.UUID `db:"book_id" json:"bookId"`
+	BookChapterID uuid.UUID `db:"book_chapter_id" json:"bookChapterId"`
+	LastReadTime  time.Time `db:"last_read_time" json:"lastReadTime"`
+}
+
+type exportPreferredTranslator struct {
+	BookID       uuid.UUID `db:"book_id" json:"bookId"`
+	TranslatorID uuid.UUID `db:"translator_id" json:"translatorId"`
+	Language     *string   `db:"language" json:"language"`
+}
+
+type exportBookComment struct {
+	BookCommentID uuid.UUID `db:"book_comment_id" json:"id"`
+	BookID        uuid.UUID `db:"book_id" json:"bookId"`
+	Comment       string    `db:"comment" json:"comment"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+}
+
+type exportBookChapterTranslation struct {
+	BookID        uuid.UUID `db:"book_id" json:"bookId"`
+	BookChapterID uuid.UUID `db:"book_chapter_id" json:"bookChapterId"`
+	Language      string    `db:"language" json:"language"`
+	Text          string    `db:"text" json:"text"`
+}
+
+type exportBookChapterTranslationAnnotation struct {
+	AnnotationID   uuid.UUID `db:"annotation_id" json:"id"`
+	BookChapterID  uuid.UUID `db:"book_chapter_id" json:"bookChapterId"`
+	Language       string    `db:"language" json:"language"`
+	ParagraphIndex int       `db:"paragraph_index" json:"paragraphIndex"`
+	Offset         int       `db:"char_offset" json:"offset"`
+	AnchorText     string    `db:"anchor_text" json:"anchorText"`
+	Text           string    `db:"text" json:"text"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+type exportBookGlossaryTerm struct {
+	GlossaryTermID uuid.UUID `db:"glossary_term_id" json:"id"`
+	BookID         uuid.UUID `db:"book_id" json:"bookId"`
+	Language       string    `db:"language" json:"language"`
+	SourceTerm     string    `db:"source_term" json:"sourceTerm"`
+	TargetTerm     string    `db:"target_term" json:"targetTerm"`
+	Notes          *string   `db:"notes" json:"notes"`
+	IsShared       bool      `db:"is_shared" json:"isShared"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+type exportBookChapterTranslationVote struct {
+	BookChapterID uuid.UUID `db:"book_chapter_id" json:"bookChapterId"`
+	TranslatorID  uuid.UUID `db:"translator_id" json:"translatorId"`
+	Language      string    `db:"language" json:"language"`
+	Value         int       `db:"value" json:"value"`
+}
+
+type exportVerifyBookRequest struct {
+	VerifyBookRequestID uuid.UUID     `db:"verify_book_request_id" json:"id"`
+	BookID              uuid.NullUUID `db:"book_id" json:"bookId"`
+	IsVerified          *bool         `db:"is_verified" json:"isVerified"`
+	SendDate            time.Time     `db:"send_date" json:"sendDate"`
+}
+
+type exportBookTag struct {
+	BookID uuid.UUID `db:"book_id" json:"bookId"`
+	Name   string    `db:"name" json:"name"`
+}
@@ -0,0 +1,151 @@
+package query
+
+import (
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"strings"
+	"time"
+)
+
+type AuditLogQueryService interface {
+	List(filter AuditLogFilter, page, size int) ([]AuditLogOutput, error)
+}
+
+type AuditLogFilter struct {
+	ActorID    maybe.Maybe[model.UserID]
+	EntityType maybe.Maybe[model.AuditEntityType]
+	EntityID   maybe.Maybe[uuid.UUID]
+	From       maybe.Maybe[time.Time]
+	To         maybe.Maybe[time.Time]
+}
+
+type AuditLogOutput struct {
+	AuditLogID uuid.UUID
+	ActorID    maybe.Maybe[uuid.UUID]
+	Action     model.AuditAction
+	EntityType model.AuditEntityType
+	EntityID   uuid.UUID
+	Before     maybe.Maybe[string]
+	After      maybe.Maybe[string]
+	IP         string
+	UserAgent  string
+	CreatedAt  time.Time
+}
+
+type auditLogQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewAuditLogQueryService(connection *sqlx.DB) *auditLogQueryService {
+	return &auditLogQueryService{connection: connection}
+}
+
+func (service *auditLogQueryService) List(filter AuditLogFilter, page, size int) ([]AuditLogOutput, error) {
+	query := `
+		SELECT
+			al.audit_log_id,
+			al.actor_id,
+			al.action,
+			al.entity_type,
+			al.entity_id,
+			al.before_data,
+			al.after_data,
+			al.ip,
+			al.user_agent,
+			al.created_at
+		FROM audit_log al
+	`
+
+	var conditions []string
+	var args []interface{}
+
+	if actorID, ok := filter.ActorID.Get(); ok {
+		binaryActorID, err := uuid.UUID(actorID).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "al.actor_id = ?")
+		args = append(args, binaryActorID)
+	}
+	if entityType, ok := filter.EntityType.Get(); ok {
+		conditions = append(conditions, "al.entity_type = ?")
+		args = append(args, entityType)
+	}
+	if entityID, ok := filter.EntityID.Get(); ok {
+		binaryEntityID, err := entityID.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "al.entity_id = ?")
+		args = append(args, binaryEntityID)
+	}
+	if from, ok := filter.From.Get(); ok {
+		conditions = append(conditions, "al.created_at >= ?")
+		args = append(args, from)
+	}
+	if to, ok := filter.To.Get(); ok {
+		conditions = append(conditions, "al.created_at < ?")
+		args = append(args, to)
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY al.created_at DESC, al.audit_log_id LIMIT ? OFFSET ?"
+	args = append(args, size, (page-1)*size)
+
+	var sqlxAuditLogs []sqlxAuditLog
+	err := service.connection.Select(&sqlxAuditLogs, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogOutputs := make([]AuditLogOutput, len(sqlxAuditLogs))
+	for i, a := range sqlxAuditLogs {
+		actorID := maybe.Nothing[uuid.UUID]()
+		if a.ActorID.Valid {
+			actorID = maybe.Just(a.ActorID.UUID)
+		}
+
+		before := maybe.Nothing[string]()
+		if a.Before.Valid {
+			before = maybe.Just(a.Before.String)
+		}
+
+		after := maybe.Nothing[string]()
+		if a.After.Valid {
+			after = maybe.Just(a.After.String)
+		}
+
+		auditLogOutputs[i] = AuditLogOutput{
+			AuditLogID: a.AuditLogID,
+			ActorID:    actorID,
+			Action:     model.AuditAction(a.Action),
+			EntityType: model.AuditEntityType(a.EntityType),
+			EntityID:   a.EntityID,
+			Before:     before,
+			After:      after,
+			IP:         a.IP,
+			UserAgent:  a.UserAgent,
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+
+	return auditLogOutputs, nil
+}
+
+type sqlxAuditLog struct {
+	AuditLogID uuid.UUID      `db:"audit_log_id"`
+	ActorID    uuid.NullUUID  `db:"actor_id"`
+	Action     int            `db:"action"`
+	EntityType int            `db:"entity_type"`
+	EntityID   uuid.UUID      `db:"entity_id"`
+	Before     sql.NullString `db:"before_data"`
+	After      sql.NullString `db:"after_data"`
+	IP         string         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
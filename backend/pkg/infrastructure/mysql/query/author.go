@@ -7,12 +7,17 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"strings"
+	"time"
 )
 
 type AuthorQueryService interface {
 	FindByID(authorID model.AuthorID) (AuthorOutput, error)
-	List() ([]AuthorOutput, error)
-	ListByBookID(bookID model.BookID) ([]AuthorOutput, error)
+	FindDetailByID(authorID model.AuthorID) (AuthorDetailOutput, error)
+	Search(name string, page, size int) ([]AuthorOutput, error)
+	CountSearch(name string) (int, error)
+	ListByBookID(bookID model.BookID) ([]BookAuthorOutput, error)
+	ListByBookIDs(bookIDs []model.BookID) (map[uuid.UUID][]BookAuthorOutput, error)
 }
 
 type AuthorOutput struct {
@@ -24,6 +29,29 @@ type AuthorOutput struct {
 	Nickname   maybe.Maybe[string]
 }
 
+// BookAuthorOutput - автор книги с его ролью, авторы книги возвращаются в заданном для неё порядке
+type BookAuthorOutput struct {
+	AuthorOutput
+	Role model.BookAuthorRole
+}
+
+// AuthorDetailOutput - страница автора с библиографией из опубликованных книг
+type AuthorDetailOutput struct {
+	AuthorOutput
+	Biography maybe.Maybe[string]
+	BirthYear maybe.Maybe[int]
+	DeathYear maybe.Maybe[int]
+	Country   maybe.Maybe[string]
+	Aliases   []string
+	Books     []AuthorBookOutput
+}
+
+type AuthorBookOutput struct {
+	BookID      uuid.UUID
+	Title       string
+	PublishedAt maybe.Maybe[time.Time]
+}
+
 type authorQueryService struct {
 	connection *sqlx.DB
 }
@@ -43,7 +71,7 @@ func (service *authorQueryService) FindByID(authorID model.AuthorID) (AuthorOutp
 			a.nickname
 		FROM author a
 		LEFT JOIN image i ON a.avatar_id = i.image_id
-		WHERE a.author_id = ?;
+		WHERE a.author_id = ? AND a.deleted_at IS NULL;
 	`
 
 	binaryAuthorID, err := uuid.UUID(authorID).MarshalBinary()
@@ -85,9 +113,106 @@ func (service *authorQueryService) FindByID(authorID model.AuthorID) (AuthorOutp
 	}, nil
 }
 
-func (service *authorQueryService) List() ([]AuthorOutput, error) {
+func (service *authorQueryService) FindDetailByID(authorID model.AuthorID) (AuthorDetailOutput, error) {
 	const query = `
-		SELECT 
+		SELECT
+			a.author_id,
+			i.path AS avatar,
+			a.first_name,
+			a.second_name,
+			a.middle_name,
+			a.nickname,
+			a.biography,
+			a.birth_year,
+			a.death_year,
+			a.country
+		FROM author a
+		LEFT JOIN image i ON a.avatar_id = i.image_id
+		WHERE a.author_id = ? AND a.deleted_at IS NULL
+	`
+
+	binaryAuthorID, err := uuid.UUID(authorID).MarshalBinary()
+	if err != nil {
+		return AuthorDetailOutput{}, err
+	}
+
+	var author sqlxAuthorDetail
+	err = service.connection.Get(&author, query, binaryAuthorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return AuthorDetailOutput{}, model.ErrAuthorNotFound
+		}
+		return AuthorDetailOutput{}, err
+	}
+
+	aliases := make([]string, 0)
+	err = service.connection.Select(&aliases, `SELECT alias FROM author_alias WHERE author_id = ? ORDER BY alias`, binaryAuthorID)
+	if err != nil {
+		return AuthorDetailOutput{}, err
+	}
+
+	const booksQuery = `
+		SELECT
+			b.book_id,
+			b.title,
+			b.published_at
+		FROM book_author ba
+		INNER JOIN book b ON b.book_id = ba.book_id
+		WHERE ba.author_id = ? AND b.is_publish = 1 AND b.deleted_at IS NULL
+		ORDER BY b.published_at, b.title, b.book_id
+	`
+
+	var sqlxBooks []sqlxAuthorBook
+	err = service.connection.Select(&sqlxBooks, booksQuery, binaryAuthorID)
+	if err != nil {
+		return AuthorDetailOutput{}, err
+	}
+
+	books := make([]AuthorBookOutput, len(sqlxBooks))
+	for i, b := range sqlxBooks {
+		publishedAt := maybe.Nothing[time.Time]()
+		if b.PublishedAt.Valid {
+			publishedAt = maybe.Just(b.PublishedAt.Time)
+		}
+
+		books[i] = AuthorBookOutput{
+			BookID:      b.BookID,
+			Title:       b.Title,
+			PublishedAt: publishedAt,
+		}
+	}
+
+	output := AuthorDetailOutput{
+		AuthorOutput: convertSqlxAuthor(author.sqlxAuthor),
+		Biography:    maybe.Nothing[string](),
+		BirthYear:    maybe.Nothing[int](),
+		DeathYear:    maybe.Nothing[int](),
+		Country:      maybe.Nothing[string](),
+		Aliases:      aliases,
+		Books:        books,
+	}
+	if author.Biography.Valid {
+		output.Biography = maybe.Just(author.Biography.String)
+	}
+	if author.BirthYear.Valid {
+		output.BirthYear = maybe.Just(int(author.BirthYear.Int64))
+	}
+	if author.DeathYear.Valid {
+		output.DeathYear = maybe.Just(int(author.DeathYear.Int64))
+	}
+	if author.Country.Valid {
+		output.Country = maybe.Just(author.Country.String)
+	}
+
+	return output, nil
+}
+
+// Search ищет авторов по имени: каждое слово запроса должно встретиться в имени, фамилии,
+// отчестве, никнейме или одном из псевдонимов. Пустой запрос возвращает всех авторов
+func (service *authorQueryService) Search(name string, page, size int) ([]AuthorOutput, error) {
+	condition, args := buildAuthorSearchCondition(name)
+	query := `
+		SELECT
 			a.author_id,
 			i.path AS avatar,
 			a.first_name,
@@ -95,57 +220,71 @@ func (service *authorQueryService) List() ([]AuthorOutput, error) {
 			a.middle_name,
 			a.nickname
 		FROM author a
-		LEFT JOIN image i ON a.avatar_id = i.image_id;
+		LEFT JOIN image i ON a.avatar_id = i.image_id
+		WHERE ` + condition + `
+		ORDER BY a.second_name, a.first_name, a.author_id
+		LIMIT ? OFFSET ?
 	`
+	args = append(args, size, (page-1)*size)
 
 	var sqlxAuthors []sqlxAuthor
-	err := service.connection.Select(&sqlxAuthors, query)
+	err := service.connection.Select(&sqlxAuthors, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	authorOutputs := make([]AuthorOutput, len(sqlxAuthors))
 	for i, a := range sqlxAuthors {
-		avatar := maybe.Nothing[string]()
-		if a.Avatar.Valid {
-			avatar = maybe.Just(a.Avatar.String)
-		}
+		authorOutputs[i] = convertSqlxAuthor(a)
+	}
 
-		middleName := maybe.Nothing[string]()
-		if a.MiddleName.Valid {
-			middleName = maybe.Just(a.MiddleName.String)
-		}
+	return authorOutputs, nil
+}
 
-		nickname := maybe.Nothing[string]()
-		if a.Nickname.Valid {
-			nickname = maybe.Just(a.Nickname.String)
-		}
+func (service *authorQueryService) CountSearch(name string) (int, error) {
+	condition, args := buildAuthorSearchCondition(name)
+	query := `SELECT COUNT(*) FROM author a WHERE ` + condition
 
-		authorOutputs[i] = AuthorOutput{
-			AuthorID:   a.AuthorID,
-			Avatar:     avatar,
-			FirstName:  a.FirstName,
-			SecondName: a.SecondName,
-			MiddleName: middleName,
-			Nickname:   nickname,
-		}
+	var count int
+	err := service.connection.Get(&count, query, args...)
+
+	return count, err
+}
+
+func buildAuthorSearchCondition(name string) (string, []interface{}) {
+	conditions := []string{"a.deleted_at IS NULL"}
+	var args []interface{}
+	for _, word := range strings.Fields(name) {
+		pattern := "%" + escapeLike(word) + "%"
+		conditions = append(conditions, `(
+			a.first_name LIKE ? OR
+			a.second_name LIKE ? OR
+			a.middle_name LIKE ? OR
+			a.nickname LIKE ? OR
+			EXISTS (SELECT 1 FROM author_alias aa WHERE aa.author_id = a.author_id AND aa.alias LIKE ?)
+		)`)
+		args = append(args, pattern, pattern, pattern, pattern, pattern)
 	}
 
-	return authorOutputs, nil
+	return strings.Join(conditions, " AND "), args
 }
 
-func (service *authorQueryService) ListByBookID(bookID model.BookID) ([]AuthorOutput, error) {
+func (service *authorQueryService) ListByBookID(bookID model.BookID) ([]BookAuthorOutput, error) {
 	const query = `
-		SELECT 
+		SELECT
+			ba.book_id,
+			ba.role,
 			a.author_id,
 			i.path AS avatar,
 			a.first_name,
 			a.second_name,
 			a.middle_name,
 			a.nickname
-		FROM author a
-		LEFT JOIN book_author ba ON a.author_id = ba.author_id AND ba.book_id = ?;
-		LEFT JOIN image i ON a.avatar_id = i.image_id;
+		FROM book_author ba
+		INNER JOIN author a ON a.author_id = ba.author_id
+		LEFT JOIN image i ON a.avatar_id = i.image_id
+		WHERE ba.book_id = ? AND a.deleted_at IS NULL
+		ORDER BY ba.position, a.author_id
 	`
 
 	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
@@ -153,40 +292,104 @@ func (service *authorQueryService) ListByBookID(bookID model.BookID) ([]AuthorOu
 		return nil, err
 	}
 
-	var sqlxAuthors []sqlxAuthor
-	err = service.connection.Select(&sqlxAuthors, query, binaryBookID)
+	var sqlxBookAuthors []sqlxBookAuthor
+	err = service.connection.Select(&sqlxBookAuthors, query, binaryBookID)
 	if err != nil {
 		return nil, err
 	}
 
-	authorOutputs := make([]AuthorOutput, len(sqlxAuthors))
-	for i, a := range sqlxAuthors {
-		avatar := maybe.Nothing[string]()
-		if a.Avatar.Valid {
-			avatar = maybe.Just(a.Avatar.String)
-		}
+	authorOutputs := make([]BookAuthorOutput, len(sqlxBookAuthors))
+	for i, a := range sqlxBookAuthors {
+		authorOutputs[i] = convertSqlxBookAuthor(a)
+	}
 
-		middleName := maybe.Nothing[string]()
-		if a.MiddleName.Valid {
-			middleName = maybe.Just(a.MiddleName.String)
-		}
+	return authorOutputs, nil
+}
 
-		nickname := maybe.Nothing[string]()
-		if a.Nickname.Valid {
-			nickname = maybe.Just(a.Nickname.String)
-		}
+// ListByBookIDs загружает авторов сразу для нескольких книг одним запросом
+func (service *authorQueryService) ListByBookIDs(bookIDs []model.BookID) (map[uuid.UUID][]BookAuthorOutput, error) {
+	authorsByBookID := make(map[uuid.UUID][]BookAuthorOutput, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return authorsByBookID, nil
+	}
 
-		authorOutputs[i] = AuthorOutput{
-			AuthorID:   a.AuthorID,
-			Avatar:     avatar,
-			FirstName:  a.FirstName,
-			SecondName: a.SecondName,
-			MiddleName: middleName,
-			Nickname:   nickname,
-		}
+	const query = `
+		SELECT
+			ba.book_id,
+			ba.role,
+			a.author_id,
+			i.path AS avatar,
+			a.first_name,
+			a.second_name,
+			a.middle_name,
+			a.nickname
+		FROM book_author ba
+		INNER JOIN author a ON a.author_id = ba.author_id
+		LEFT JOIN image i ON a.avatar_id = i.image_id
+		WHERE ba.book_id IN (?) AND a.deleted_at IS NULL
+		ORDER BY ba.position, a.author_id
+	`
+
+	binaryBookIDs, err := marshalUUIDs(bookIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	return authorOutputs, nil
+	inQuery, args, err := sqlx.In(query, binaryBookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxBookAuthors []sqlxBookAuthor
+	err = service.connection.Select(&sqlxBookAuthors, service.connection.Rebind(inQuery), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range sqlxBookAuthors {
+		authorsByBookID[a.BookID] = append(authorsByBookID[a.BookID], convertSqlxBookAuthor(a))
+	}
+
+	return authorsByBookID, nil
+}
+
+func convertSqlxAuthor(author sqlxAuthor) AuthorOutput {
+	avatar := maybe.Nothing[string]()
+	if author.Avatar.Valid {
+		avatar = maybe.Just(author.Avatar.String)
+	}
+
+	middleName := maybe.Nothing[string]()
+	if author.MiddleName.Valid {
+		middleName = maybe.Just(author.MiddleName.String)
+	}
+
+	nickname := maybe.Nothing[string]()
+	if author.Nickname.Valid {
+		nickname = maybe.Just(author.Nickname.String)
+	}
+
+	return AuthorOutput{
+		AuthorID:   author.AuthorID,
+		Avatar:     avatar,
+		FirstName:  author.FirstName,
+		SecondName: author.SecondName,
+		MiddleName: middleName,
+		Nickname:   nickname,
+	}
+}
+
+func convertSqlxBookAuthor(author sqlxBookAuthor) BookAuthorOutput {
+	return BookAuthorOutput{
+		AuthorOutput: convertSqlxAuthor(author.sqlxAuthor),
+		Role:         model.BookAuthorRole(author.Role),
+	}
+}
+
+type sqlxBookAuthor struct {
+	BookID uuid.UUID `db:"book_id"`
+	Role   int       `db:"role"`
+	sqlxAuthor
 }
 
 type sqlxAuthor struct {
@@ -197,3 +400,17 @@ type sqlxAuthor struct {
 	MiddleName sql.NullString `db:"middle_name"`
 	Nickname   sql.NullString `db:"nickname"`
 }
+
+type sqlxAuthorDetail struct {
+	sqlxAuthor
+	Biography sql.NullString `db:"biography"`
+	BirthYear sql.NullInt64  `db:"birth_year"`
+	DeathYear sql.NullInt64  `db:"death_year"`
+	Country   sql.NullString `db:"country"`
+}
+
+type sqlxAuthorBook struct {
+	BookID      uuid.UUID    `db:"book_id"`
+	Title       string       `db:"title"`
+	PublishedAt sql.NullTime `db:"published_at"`
+}
@@ -2,23 +2,75 @@ package query
 
 import (
 	"database/sql"
+	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"strings"
 )
 
 type BookQueryService interface {
 	FindByID(bookID model.BookID) (BookOutput, error)
-	List(page, size int) ([]BookOutput, error)
-	CountBook(isPublished bool) (int, error)
+	List(filter BookFilter, sort BookSort, page, size int) ([]BookOutput, maybe.Maybe[Cursor], error)
+	ListAfter(filter BookFilter, sort BookSort, cursor Cursor, size int) ([]BookOutput, maybe.Maybe[Cursor], error)
+	CountBook(filter BookFilter) (int, error)
+	ListRatingStatsByBookIDs(bookIDs []model.BookID) (map[uuid.UUID]BookRatingStatsOutput, error)
+	ListLocalizationsByBookIDs(bookIDs []model.BookID) (map[uuid.UUID][]BookLocalizationOutput, error)
 }
 
+// BookFilter ограничивает выборку опубликованных книг, пустые поля не фильтруют
+type BookFilter struct {
+	GenreIDs        []model.GenreID
+	GenreMatchAll   bool
+	AuthorIDs       []model.AuthorID
+	MinRating       maybe.Maybe[float64]
+	HasTranslation  maybe.Maybe[bool]
+	MinChapterCount maybe.Maybe[int]
+	MaxChapterCount maybe.Maybe[int]
+	// OriginalLanguage - язык оригинала книги, TranslationLanguage - язык, на который есть хотя бы один перевод
+	OriginalLanguage    maybe.Maybe[string]
+	TranslationLanguage maybe.Maybe[string]
+	// Title ищет слова в оригинальном названии и в названиях на всех языках
+	Title maybe.Maybe[string]
+}
+
+type BookSort int
+
+const (
+	BookSortTitle BookSort = iota
+	BookSortRating
+	BookSortRatingCount
+	BookSortReaders
+	BookSortPublishedAt
+	BookSortLastTranslatedAt
+)
+
+// bayesianPriorWeight - число "виртуальных" оценок со средним значением по всем книгам,
+// которые добавляются к оценкам книги, чтобы книги с парой оценок не обгоняли популярные
+const bayesianPriorWeight = 10
+
+// minSortTime подставляется вместо отсутствующей даты, чтобы такие книги оказались в конце списка
+const minSortTime = "TIMESTAMP('1000-01-01')"
+
 type BookOutput struct {
 	BookID      uuid.UUID
 	Cover       maybe.Maybe[string]
 	Title       string
 	Description string
+	// OriginalLanguage - язык оригинала по ISO 639-1, если известен
+	OriginalLanguage maybe.Maybe[string]
+}
+
+type BookLocalizationOutput struct {
+	Language    string
+	Title       string
+	Description string
+}
+
+type BookRatingStatsOutput struct {
+	Average float64
+	Count   int
 }
 
 type bookQueryService struct {
@@ -31,10 +83,10 @@ func NewBookQueryService(connection *sqlx.DB) *bookQueryService {
 
 func (service *bookQueryService) FindByID(bookID model.BookID) (BookOutput, error) {
 	const query = `
-		SELECT b.book_id, i.path, b.title, b.description
+		SELECT b.book_id, i.path, b.title, b.description, b.original_language
 		FROM book b
 		LEFT OUTER JOIN image i ON b.cover_id = i.image_id
-		WHERE b.book_id = ?;
+		WHERE b.book_id = ? AND b.deleted_at IS NULL;
 	`
 
 	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
@@ -54,29 +106,78 @@ func (service *bookQueryService) FindByID(bookID model.BookID) (BookOutput, erro
 	}
 
 	return BookOutput{
-		BookID:      book.BookID,
-		Cover:       cover,
-		Title:       book.Title,
-		Description: book.Description,
+		BookID:           book.BookID,
+		Cover:            cover,
+		Title:            book.Title,
+		Description:      book.Description,
+		OriginalLanguage: maybeString(book.OriginalLanguage),
 	}, nil
 }
 
-func (service *bookQueryService) List(page, size int) ([]BookOutput, error) {
-	const query = `
-		SELECT b.book_id, i.path, b.title, b.description
+func (service *bookQueryService) List(filter BookFilter, sort BookSort, page, size int) ([]BookOutput, maybe.Maybe[Cursor], error) {
+	return service.list(filter, sort, maybe.Nothing[Cursor](), size, (page-1)*size)
+}
+
+// ListAfter продолжает выборку с записи, следующей за курсором, не используя OFFSET
+func (service *bookQueryService) ListAfter(filter BookFilter, sort BookSort, cursor Cursor, size int) ([]BookOutput, maybe.Maybe[Cursor], error) {
+	if cursor.Order != int(sort) {
+		return nil, maybe.Nothing[Cursor](), ErrInvalidCursor
+	}
+
+	return service.list(filter, sort, maybe.Just(cursor), size, 0)
+}
+
+// list запрашивает на одну книгу больше, чтобы понять, есть ли следующая страница
+func (service *bookQueryService) list(
+	filter BookFilter,
+	sort BookSort,
+	cursor maybe.Maybe[Cursor],
+	size int,
+	offset int,
+) ([]BookOutput, maybe.Maybe[Cursor], error) {
+	conditions, args, err := buildBookFilterConditions(filter)
+	if err != nil {
+		return nil, maybe.Nothing[Cursor](), err
+	}
+
+	clauses := buildBookSortClauses(sort)
+
+	var sortArgs []interface{}
+	if value, ok := cursor.Get(); ok {
+		condition, cursorArgs, err2 := keysetCondition(clauses.key, "b.book_id", clauses.descending, value)
+		if err2 != nil {
+			return nil, maybe.Nothing[Cursor](), err2
+		}
+		conditions += " AND " + condition
+		sortArgs = cursorArgs
+	}
+
+	query := `
+		SELECT b.book_id, i.path, b.title, b.description, b.original_language, CAST(` + clauses.key + ` AS CHAR) AS sort_key
 		FROM book b
 		LEFT OUTER JOIN image i ON b.cover_id = i.image_id
-		WHERE b.is_publish = 1
-		ORDER BY b.title
+		` + clauses.joins + `
+		WHERE ` + conditions + `
+		ORDER BY ` + clauses.orderBy() + `
 		LIMIT ? OFFSET ?;
 	`
 
-	offset := (page - 1) * size
+	args = append(args, sortArgs...)
+	args = append(args, size+1, offset)
 
-	var sqlxBooks []sqlxBook
-	err := service.connection.Select(&sqlxBooks, query, size, offset)
+	var sqlxBooks []sqlxSortedBook
+	err = service.connection.Select(&sqlxBooks, service.connection.Rebind(query), args...)
 	if err != nil {
-		return nil, err
+		return nil, maybe.Nothing[Cursor](), err
+	}
+
+	nextCursor := maybe.Nothing[Cursor]()
+	if len(sqlxBooks) > size {
+		sqlxBooks = sqlxBooks[:size]
+		if size > 0 {
+			last := sqlxBooks[size-1]
+			nextCursor = maybe.Just(Cursor{Order: int(sort), Key: last.SortKey, ID: last.BookID})
+		}
 	}
 
 	bookOutputs := make([]BookOutput, len(sqlxBooks))
@@ -87,21 +188,27 @@ func (service *bookQueryService) List(page, size int) ([]BookOutput, error) {
 		}
 
 		bookOutputs[i] = BookOutput{
-			BookID:      b.BookID,
-			Cover:       cover,
-			Title:       b.Title,
-			Description: b.Description,
+			BookID:           b.BookID,
+			Cover:            cover,
+			Title:            b.Title,
+			Description:      b.Description,
+			OriginalLanguage: maybeString(b.OriginalLanguage),
 		}
 	}
 
-	return bookOutputs, nil
+	return bookOutputs, nextCursor, nil
 }
 
-func (service *bookQueryService) CountBook(isPublished bool) (int, error) {
-	const query = `SELECT COUNT(*) FROM book b WHERE b.is_publish = ?`
+func (service *bookQueryService) CountBook(filter BookFilter) (int, error) {
+	conditions, args, err := buildBookFilterConditions(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `SELECT COUNT(*) FROM book b WHERE ` + conditions
 
 	var countBook int
-	err := service.connection.Get(&countBook, query, isPublished)
+	err = service.connection.Get(&countBook, service.connection.Rebind(query), args...)
 	if err != nil {
 		return 0, err
 	}
@@ -109,9 +216,351 @@ func (service *bookQueryService) CountBook(isPublished bool) (int, error) {
 	return countBook, nil
 }
 
+type bookSortClauses struct {
+	joins      string
+	key        string
+	descending bool
+}
+
+// orderBy всегда добавляет book_id последним ключом, чтобы порядок книг с равными значениями не менялся между страницами
+func (clauses bookSortClauses) orderBy() string {
+	if clauses.descending {
+		return clauses.key + " DESC, b.book_id"
+	}
+
+	return clauses.key + ", b.book_id"
+}
+
+// ListRatingStatsByBookIDs возвращает статистику оценок только для книг, у которых есть оценки
+func (service *bookQueryService) ListRatingStatsByBookIDs(bookIDs []model.BookID) (map[uuid.UUID]BookRatingStatsOutput, error) {
+	statsByBookID := make(map[uuid.UUID]BookRatingStatsOutput, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return statsByBookID, nil
+	}
+
+	const query = `
+		SELECT
+			book_id,
+			AVG(value) AS average,
+			COUNT(*) AS count
+		FROM book_rating
+		WHERE book_id IN (?)
+		GROUP BY book_id
+	`
+
+	binaryBookIDs, err := marshalUUIDs(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inQuery, args, err := sqlx.In(query, binaryBookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxStats []sqlxBookRatingStats
+	err = service.connection.Select(&sqlxStats, service.connection.Rebind(inQuery), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stats := range sqlxStats {
+		statsByBookID[stats.BookID] = BookRatingStatsOutput{
+			Average: stats.Average,
+			Count:   stats.Count,
+		}
+	}
+
+	return statsByBookID, nil
+}
+
+func (service *bookQueryService) ListLocalizationsByBookIDs(bookIDs []model.BookID) (map[uuid.UUID][]BookLocalizationOutput, error) {
+	localizationsByBookID := make(map[uuid.UUID][]BookLocalizationOutput, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return localizationsByBookID, nil
+	}
+
+	const query = `
+		SELECT
+			book_id,
+			language,
+			title,
+			description
+		FROM book_localization
+		WHERE book_id IN (?)
+		ORDER BY language
+	`
+
+	binaryBookIDs, err := marshalUUIDs(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inQuery, args, err := sqlx.In(query, binaryBookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxLocalizations []sqlxBookLocalization
+	err = service.connection.Select(&sqlxLocalizations, service.connection.Rebind(inQuery), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, localization := range sqlxLocalizations {
+		localizationsByBookID[localization.BookID] = append(localizationsByBookID[localization.BookID], BookLocalizationOutput{
+			Language:    localization.Language,
+			Title:       localization.Title,
+			Description: localization.Description.String,
+		})
+	}
+
+	return localizationsByBookID, nil
+}
+
+// buildBookSortClauses возвращает дополнительные JOIN и ключ сортировки. Ключ не бывает NULL,
+// иначе по нему нельзя было бы продолжить выборку с курсора
+func buildBookSortClauses(sort BookSort) bookSortClauses {
+	switch sort {
+	case BookSortRating:
+		return bookSortClauses{
+			joins: `
+				LEFT OUTER JOIN (
+					SELECT book_id, COUNT(*) AS rating_count, SUM(value) AS rating_sum
+					FROM book_rating
+					GROUP BY book_id
+				) rs ON rs.book_id = b.book_id
+				CROSS JOIN (SELECT COALESCE(AVG(value), 0) AS mean FROM book_rating) rm
+			`,
+			key: fmt.Sprintf(
+				"((%[1]d * rm.mean + COALESCE(rs.rating_sum, 0)) / (%[1]d + COALESCE(rs.rating_count, 0)))",
+				bayesianPriorWeight,
+			),
+			descending: true,
+		}
+	case BookSortRatingCount:
+		return bookSortClauses{
+			joins: `
+				LEFT OUTER JOIN (
+					SELECT book_id, COUNT(*) AS rating_count
+					FROM book_rating
+					GROUP BY book_id
+				) rs ON rs.book_id = b.book_id
+			`,
+			key:        "COALESCE(rs.rating_count, 0)",
+			descending: true,
+		}
+	case BookSortReaders:
+		return bookSortClauses{
+			joins: `
+				LEFT OUTER JOIN (
+					SELECT book_id, COUNT(DISTINCT user_id) AS reader_count
+					FROM user_book_favourites
+					GROUP BY book_id
+				) rd ON rd.book_id = b.book_id
+			`,
+			key:        "COALESCE(rd.reader_count, 0)",
+			descending: true,
+		}
+	case BookSortPublishedAt:
+		return bookSortClauses{
+			key:        "COALESCE(b.published_at, " + minSortTime + ")",
+			descending: true,
+		}
+	case BookSortLastTranslatedAt:
+		return bookSortClauses{
+			joins: `
+				LEFT OUTER JOIN (
+					SELECT bc.book_id, MAX(bct.created_at) AS last_translated_at
+					FROM book_chapter_translation bct
+					INNER JOIN book_chapter bc ON bc.book_chapter_id = bct.book_chapter_id
+					WHERE bc.deleted_at IS NULL
+					GROUP BY bc.book_id
+				) lt ON lt.book_id = b.book_id
+			`,
+			key:        "COALESCE(lt.last_translated_at, " + minSortTime + ")",
+			descending: true,
+		}
+	default:
+		return bookSortClauses{key: "b.title"}
+	}
+}
+
+// bookGenreTreeCondition проверяет, что у книги есть хотя бы один из жанров или любой из их поджанров
+const bookGenreTreeCondition = `EXISTS (
+	WITH RECURSIVE genre_tree (genre_id) AS (
+		SELECT g.genre_id FROM genre g WHERE g.genre_id IN (?) AND g.deleted_at IS NULL
+		UNION
+		SELECT g.genre_id FROM genre g INNER JOIN genre_tree gt ON g.parent_id = gt.genre_id WHERE g.deleted_at IS NULL
+	)
+	SELECT 1
+	FROM book_genre bg
+	WHERE bg.book_id = b.book_id AND bg.genre_id IN (SELECT genre_id FROM genre_tree)
+)`
+
+func buildBookFilterConditions(filter BookFilter) (string, []interface{}, error) {
+	conditions := []string{"b.is_publish = 1", "b.deleted_at IS NULL"}
+	var args []interface{}
+
+	if len(filter.GenreIDs) > 0 {
+		binaryGenreIDs, err := marshalUUIDs(filter.GenreIDs)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Жанр в фильтре включает все свои поджанры
+		if filter.GenreMatchAll {
+			for _, binaryGenreID := range distinctBinaryIDs(binaryGenreIDs) {
+				condition, inArgs, err2 := sqlx.In(bookGenreTreeCondition, []interface{}{binaryGenreID})
+				if err2 != nil {
+					return "", nil, err2
+				}
+				conditions = append(conditions, condition)
+				args = append(args, inArgs...)
+			}
+		} else {
+			condition, inArgs, err2 := sqlx.In(bookGenreTreeCondition, binaryGenreIDs)
+			if err2 != nil {
+				return "", nil, err2
+			}
+			conditions = append(conditions, condition)
+			args = append(args, inArgs...)
+		}
+	}
+
+	if len(filter.AuthorIDs) > 0 {
+		binaryAuthorIDs, err := marshalUUIDs(filter.AuthorIDs)
+		if err != nil {
+			return "", nil, err
+		}
+
+		condition, inArgs, err := sqlx.In(`EXISTS (
+			SELECT 1
+			FROM book_author ba
+			INNER JOIN author a ON ba.author_id = a.author_id
+			WHERE ba.book_id = b.book_id AND ba.author_id IN (?) AND a.deleted_at IS NULL
+		)`, binaryAuthorIDs)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, condition)
+		args = append(args, inArgs...)
+	}
+
+	if minRating, ok := filter.MinRating.Get(); ok {
+		conditions = append(conditions, `(SELECT AVG(br.value) FROM book_rating br WHERE br.book_id = b.book_id) >= ?`)
+		args = append(args, minRating)
+	}
+
+	if hasTranslation, ok := filter.HasTranslation.Get(); ok {
+		condition := `EXISTS (
+			SELECT 1
+			FROM book_chapter bc
+			INNER JOIN book_chapter_translation bct ON bc.book_chapter_id = bct.book_chapter_id
+			WHERE bc.book_id = b.book_id AND bc.deleted_at IS NULL
+		)`
+		if !hasTranslation {
+			condition = "NOT " + condition
+		}
+		conditions = append(conditions, condition)
+	}
+
+	const chapterCount = `(SELECT COUNT(*) FROM book_chapter bc WHERE bc.book_id = b.book_id AND bc.deleted_at IS NULL)`
+	if minChapterCount, ok := filter.MinChapterCount.Get(); ok {
+		conditions = append(conditions, chapterCount+` >= ?`)
+		args = append(args, minChapterCount)
+	}
+	if maxChapterCount, ok := filter.MaxChapterCount.Get(); ok {
+		conditions = append(conditions, chapterCount+` <= ?`)
+		args = append(args, maxChapterCount)
+	}
+
+	if title, ok := filter.Title.Get(); ok {
+		for _, word := range strings.Fields(title) {
+			pattern := "%" + escapeLike(word) + "%"
+			conditions = append(conditions, `(b.title LIKE ? OR EXISTS (
+				SELECT 1
+				FROM book_localization bl
+				WHERE bl.book_id = b.book_id AND bl.title LIKE ?
+			))`)
+			args = append(args, pattern, pattern)
+		}
+	}
+
+	if originalLanguage, ok := filter.OriginalLanguage.Get(); ok {
+		conditions = append(conditions, `b.original_language = ?`)
+		args = append(args, originalLanguage)
+	}
+
+	if translationLanguage, ok := filter.TranslationLanguage.Get(); ok {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1
+			FROM book_chapter bc
+			INNER JOIN book_chapter_translation bct ON bc.book_chapter_id = bct.book_chapter_id
+			WHERE bc.book_id = b.book_id AND bc.deleted_at IS NULL AND bct.language = ?
+		)`)
+		args = append(args, translationLanguage)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+func maybeString(value sql.NullString) maybe.Maybe[string] {
+	if value.Valid {
+		return maybe.Just(value.String)
+	}
+	return maybe.Nothing[string]()
+}
+
+func marshalUUIDs[T ~[16]byte](ids []T) ([][]byte, error) {
+	binaryIDs := make([][]byte, len(ids))
+	for i, id := range ids {
+		binaryID, err := uuid.UUID(id).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		binaryIDs[i] = binaryID
+	}
+
+	return binaryIDs, nil
+}
+
+func distinctBinaryIDs(binaryIDs [][]byte) [][]byte {
+	seen := make(map[string]struct{}, len(binaryIDs))
+	var distinct [][]byte
+	for _, binaryID := range binaryIDs {
+		if _, ok := seen[string(binaryID)]; ok {
+			continue
+		}
+		seen[string(binaryID)] = struct{}{}
+		distinct = append(distinct, binaryID)
+	}
+
+	return distinct
+}
+
 type sqlxBook struct {
+	BookID           uuid.UUID      `db:"book_id"`
+	Cover            sql.NullString `db:"path"`
+	Title            string         `db:"title"`
+	Description      string         `db:"description"`
+	OriginalLanguage sql.NullString `db:"original_language"`
+}
+
+type sqlxSortedBook struct {
+	sqlxBook
+	SortKey string `db:"sort_key"`
+}
+
+type sqlxBookLocalization struct {
 	BookID      uuid.UUID      `db:"book_id"`
-	Cover       sql.NullString `db:"path"`
+	Language    string         `db:"language"`
 	Title       string         `db:"title"`
-	Description string         `db:"description"`
+	Description sql.NullString `db:"description"`
+}
+
+type sqlxBookRatingStats struct {
+	BookID  uuid.UUID `db:"book_id"`
+	Average float64   `db:"average"`
+	Count   int       `db:"count"`
 }
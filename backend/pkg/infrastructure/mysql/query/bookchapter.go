@@ -1,17 +1,25 @@
 package query
 
 import (
+	"database/sql"
+	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"strconv"
 )
 
 type BookChapterQueryService interface {
+	FindByID(bookChapterID model.BookChapterID) (BookChapterOutput, error)
 	ListByBookID(bookID model.BookID) ([]BookChapterOutput, error)
+	CountByBookIDs(bookIDs []model.BookID) (map[uuid.UUID]int, error)
+	ListPageByBookID(bookID model.BookID, cursor maybe.Maybe[Cursor], size int) ([]BookChapterOutput, maybe.Maybe[Cursor], error)
 }
 
 type BookChapterOutput struct {
 	BookChapterID uuid.UUID
+	BookID        uuid.UUID
 	Index         int
 	Title         string
 }
@@ -26,11 +34,36 @@ func NewBookChapterQueryService(connection *sqlx.DB) *bookChapterQueryService {
 	}
 }
 
+func (service *bookChapterQueryService) FindByID(bookChapterID model.BookChapterID) (BookChapterOutput, error) {
+	const query = `
+		SELECT bc.book_chapter_id, bc.book_id, bc.chapter_index, bc.title
+		FROM book_chapter bc
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE bc.book_chapter_id = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
+	`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return BookChapterOutput{}, err
+	}
+
+	var bookChapter sqlxBookChapter
+	err = service.connection.Get(&bookChapter, query, binaryBookChapterID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return BookChapterOutput{}, model.ErrBookChapterNotFound
+	}
+	if err != nil {
+		return BookChapterOutput{}, err
+	}
+
+	return convertSqlxBookChapter(bookChapter), nil
+}
+
 func (service *bookChapterQueryService) ListByBookID(bookID model.BookID) ([]BookChapterOutput, error) {
 	const query = `
-		SELECT bc.book_chapter_id, bc.chapter_index, bc.title
+		SELECT bc.book_chapter_id, bc.book_id, bc.chapter_index, bc.title
 		FROM book_chapter bc
-		WHERE bc.book_id = ?
+		WHERE bc.book_id = ? AND bc.deleted_at IS NULL
 		ORDER BY bc.book_chapter_id ASC
 	`
 
@@ -47,18 +80,123 @@ func (service *bookChapterQueryService) ListByBookID(bookID model.BookID) ([]Boo
 
 	bookChapterOutputs := make([]BookChapterOutput, len(sqlxBookChapters))
 	for i, b := range sqlxBookChapters {
-		bookChapterOutputs[i] = BookChapterOutput{
-			BookChapterID: b.BookChapterID,
-			Index:         b.Index,
-			Title:         b.Title,
-		}
+		bookChapterOutputs[i] = convertSqlxBookChapter(b)
 	}
 
 	return bookChapterOutputs, nil
 }
 
+// ListPageByBookID возвращает главы по порядку, начиная с главы, следующей за курсором
+func (service *bookChapterQueryService) ListPageByBookID(
+	bookID model.BookID,
+	cursor maybe.Maybe[Cursor],
+	size int,
+) ([]BookChapterOutput, maybe.Maybe[Cursor], error) {
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, maybe.Nothing[Cursor](), err
+	}
+
+	conditions := "bc.book_id = ? AND bc.deleted_at IS NULL"
+	args := []interface{}{binaryBookID}
+
+	if value, ok := cursor.Get(); ok {
+		condition, cursorArgs, err2 := keysetCondition("bc.chapter_index", "bc.book_chapter_id", false, value)
+		if err2 != nil {
+			return nil, maybe.Nothing[Cursor](), err2
+		}
+		conditions += " AND " + condition
+		args = append(args, cursorArgs...)
+	}
+
+	query := `
+		SELECT bc.book_chapter_id, bc.book_id, bc.chapter_index, bc.title
+		FROM book_chapter bc
+		WHERE ` + conditions + `
+		ORDER BY bc.chapter_index, bc.book_chapter_id
+		LIMIT ?
+	`
+	args = append(args, size+1)
+
+	var sqlxBookChapters []sqlxBookChapter
+	err = service.connection.Select(&sqlxBookChapters, query, args...)
+	if err != nil {
+		return nil, maybe.Nothing[Cursor](), err
+	}
+
+	nextCursor := maybe.Nothing[Cursor]()
+	if len(sqlxBookChapters) > size {
+		sqlxBookChapters = sqlxBookChapters[:size]
+		if size > 0 {
+			last := sqlxBookChapters[size-1]
+			nextCursor = maybe.Just(Cursor{Key: strconv.Itoa(last.Index), ID: last.BookChapterID})
+		}
+	}
+
+	bookChapterOutputs := make([]BookChapterOutput, len(sqlxBookChapters))
+	for i, b := range sqlxBookChapters {
+		bookChapterOutputs[i] = convertSqlxBookChapter(b)
+	}
+
+	return bookChapterOutputs, nextCursor, nil
+}
+
+// CountByBookIDs считает неудалённые главы сразу для нескольких книг, книги без глав в результат не попадают
+func (service *bookChapterQueryService) CountByBookIDs(bookIDs []model.BookID) (map[uuid.UUID]int, error) {
+	countByBookID := make(map[uuid.UUID]int, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return countByBookID, nil
+	}
+
+	const query = `
+		SELECT
+			bc.book_id,
+			COUNT(*) AS count
+		FROM book_chapter bc
+		WHERE bc.book_id IN (?) AND bc.deleted_at IS NULL
+		GROUP BY bc.book_id
+	`
+
+	binaryBookIDs, err := marshalUUIDs(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inQuery, args, err := sqlx.In(query, binaryBookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxCounts []sqlxBookChapterCount
+	err = service.connection.Select(&sqlxCounts, service.connection.Rebind(inQuery), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range sqlxCounts {
+		countByBookID[c.BookID] = c.Count
+	}
+
+	return countByBookID, nil
+}
+
+type sqlxBookChapterCount struct {
+	BookID uuid.UUID `db:"book_id"`
+	Count  int       `db:"count"`
+}
+
+func convertSqlxBookChapter(bookChapter sqlxBookChapter) BookChapterOutput {
+	return BookChapterOutput{
+		BookChapterID: bookChapter.BookChapterID,
+		BookID:        bookChapter.BookID,
+		Index:         bookChapter.Index,
+		Title:         bookChapter.Title,
+	}
+}
+
 type sqlxBookChapter struct {
 	BookChapterID uuid.UUID `db:"book_chapter_id"`
+	BookID        uuid.UUID `db:"book_id"`
 	Index         int       `db:"chapter_index"`
 	Title         string    `db:"title"`
 }
@@ -5,18 +5,71 @@ import (
 	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type BookChapterTranslationQueryService interface {
-	GetByBookChapterIDAndTranslatorID(bookChapterID model.BookChapterID, translatorID model.UserID) (BookChapterTranslationOutput, error)
-	ListTranslatorsByBookChapterId(bookChapterID model.BookChapterID) ([]uuid.UUID, error)
+	GetByBookChapterIDAndTranslatorID(
+		bookChapterID model.BookChapterID,
+		translatorID model.UserID,
+		language string,
+	) (BookChapterTranslationOutput, error)
+	GetDefaultByBookChapterID(bookChapterID model.BookChapterID, language maybe.Maybe[string]) (BookChapterTranslationOutput, error)
+	ListTranslatorsByBookChapterId(bookChapterID model.BookChapterID, language maybe.Maybe[string]) ([]BookChapterTranslatorOutput, error)
+	ListChaptersNotTranslatedBy(bookID model.BookID, translatorID model.UserID, language maybe.Maybe[string]) ([]BookChapterOutput, error)
+	GetAlignedByBookChapterIDAndTranslatorID(
+		bookChapterID model.BookChapterID,
+		translatorID model.UserID,
+		language string,
+	) (AlignedBookChapterTranslationOutput, error)
 }
 
 type BookChapterTranslationOutput struct {
-	Text string
+	TranslatorID uuid.UUID
+	Language     string
+	Text         string
+	HTML         string
+	Score        int
+	Annotations  []BookChapterTranslationAnnotationOutput
 }
 
+type BookChapterTranslationAnnotationOutput struct {
+	AnnotationID   uuid.UUID
+	ParagraphIndex int
+	Offset         int
+	AnchorText     string
+	Text           string
+	IsDetached     bool
+	CreatedAt      time.Time
+}
+
+type AlignedBookChapterTranslationOutput struct {
+	TranslatorID     uuid.UUID
+	Language         string
+	OriginalLanguage maybe.Maybe[string]
+	Paragraphs       []AlignedParagraphsOutput
+}
+
+type AlignedParagraphsOutput struct {
+	Original    []string
+	Translation []string
+}
+
+type BookChapterTranslatorOutput struct {
+	TranslatorID uuid.UUID
+	Language     string
+	Score        int
+}
+
+// translationScore - сумма голосов за перевод bct
+const translationScore = `COALESCE((
+	SELECT SUM(v.value)
+	FROM book_chapter_translation_vote v
+	WHERE v.book_chapter_id = bct.book_chapter_id AND v.translator_id = bct.translator_id AND v.language = bct.language
+), 0)`
+
 type bookChapterTranslationQueryService struct {
 	connection *sqlx.DB
 }
@@ -30,11 +83,19 @@ func NewBookChapterTranslationQueryService(connection *sqlx.DB) *bookChapterTran
 func (service *bookChapterTranslationQueryService) GetByBookChapterIDAndTranslatorID(
 	bookChapterID model.BookChapterID,
 	translatorID model.UserID,
+	language string,
 ) (BookChapterTranslationOutput, error) {
 	const query = `
-		SELECT bct.text
+		SELECT
+			bct.translator_id,
+			bct.language,
+			bct.text,
+			bct.html,
+			` + translationScore + ` AS score
 		FROM book_chapter_translation bct
-		WHERE bct.book_chapter_id = ? AND bct.translator_id = ?
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE bct.book_chapter_id = ? AND bct.translator_id = ? AND bct.language = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
 	`
 
 	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
@@ -47,8 +108,8 @@ func (service *bookChapterTranslationQueryService) GetByBookChapterIDAndTranslat
 		return BookChapterTranslationOutput{}, err
 	}
 
-	var text string
-	err = service.connection.Get(&text, query, binaryBookChapterID, binaryTranslatorID)
+	var translation sqlxBookChapterTranslation
+	err = service.connection.Get(&translation, query, binaryBookChapterID, binaryTranslatorID, language)
 	if errors.Is(err, sql.ErrNoRows) {
 		return BookChapterTranslationOutput{}, model.ErrBookChapterTranslationNotFound
 	}
@@ -56,16 +117,77 @@ func (service *bookChapterTranslationQueryService) GetByBookChapterIDAndTranslat
 		return BookChapterTranslationOutput{}, err
 	}
 
-	return BookChapterTranslationOutput{
-		Text: text,
-	}, nil
+	output := convertSqlxBookChapterTranslation(translation)
+	output.Annotations, err = service.listAnnotations(binaryBookChapterID, translation.TranslatorID, translation.Language)
+	if err != nil {
+		return BookChapterTranslationOutput{}, err
+	}
+
+	return output, nil
 }
 
-func (service *bookChapterTranslationQueryService) ListTranslatorsByBookChapterId(bookChapterID model.BookChapterID) ([]uuid.UUID, error) {
-	const query = `
-		SELECT bct.translator_id
+// GetDefaultByBookChapterID возвращает перевод с наибольшей суммой голосов, при равенстве - более ранний.
+// Его показывают читателю, который не выбрал переводчика
+func (service *bookChapterTranslationQueryService) GetDefaultByBookChapterID(
+	bookChapterID model.BookChapterID,
+	language maybe.Maybe[string],
+) (BookChapterTranslationOutput, error) {
+	query := `
+		SELECT
+			bct.translator_id,
+			bct.language,
+			bct.text,
+			bct.html,
+			` + translationScore + ` AS score
 		FROM book_chapter_translation bct
-		WHERE bct.book_chapter_id = ?
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE bct.book_chapter_id = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
+	`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return BookChapterTranslationOutput{}, err
+	}
+
+	args := []interface{}{binaryBookChapterID}
+	if value, ok := language.Get(); ok {
+		query += ` AND bct.language = ?`
+		args = append(args, value)
+	}
+	query += ` ORDER BY score DESC, bct.created_at, bct.translator_id LIMIT 1`
+
+	var translation sqlxBookChapterTranslation
+	err = service.connection.Get(&translation, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return BookChapterTranslationOutput{}, model.ErrBookChapterTranslationNotFound
+	}
+	if err != nil {
+		return BookChapterTranslationOutput{}, err
+	}
+
+	output := convertSqlxBookChapterTranslation(translation)
+	output.Annotations, err = service.listAnnotations(binaryBookChapterID, translation.TranslatorID, translation.Language)
+	if err != nil {
+		return BookChapterTranslationOutput{}, err
+	}
+
+	return output, nil
+}
+
+func (service *bookChapterTranslationQueryService) ListTranslatorsByBookChapterId(
+	bookChapterID model.BookChapterID,
+	language maybe.Maybe[string],
+) ([]BookChapterTranslatorOutput, error) {
+	query := `
+		SELECT
+			bct.translator_id,
+			bct.language,
+			` + translationScore + ` AS score
+		FROM book_chapter_translation bct
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE bct.book_chapter_id = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
 	`
 
 	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
@@ -73,8 +195,15 @@ func (service *bookChapterTranslationQueryService) ListTranslatorsByBookChapterI
 		return nil, err
 	}
 
+	args := []interface{}{binaryBookChapterID}
+	if value, ok := language.Get(); ok {
+		query += ` AND bct.language = ?`
+		args = append(args, value)
+	}
+	query += ` ORDER BY score DESC, bct.created_at`
+
 	var sqlxBookChapterTranslators []sqlxBookChapterTranslator
-	err = service.connection.Select(&sqlxBookChapterTranslators, query, binaryBookChapterID)
+	err = service.connection.Select(&sqlxBookChapterTranslators, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, model.ErrBookChapterTranslationNotFound
 	}
@@ -82,14 +211,217 @@ func (service *bookChapterTranslationQueryService) ListTranslatorsByBookChapterI
 		return nil, err
 	}
 
-	translatorsID := make([]uuid.UUID, len(sqlxBookChapterTranslators))
+	translators := make([]BookChapterTranslatorOutput, len(sqlxBookChapterTranslators))
 	for i, translator := range sqlxBookChapterTranslators {
-		translatorsID[i] = translator.BookChapterID
+		translators[i] = BookChapterTranslatorOutput{
+			TranslatorID: translator.TranslatorID,
+			Language:     translator.Language,
+			Score:        translator.Score,
+		}
+	}
+
+	return translators, nil
+}
+
+// listAnnotations возвращает примечания в порядке их мест в тексте, отвязанные - в конце
+func (service *bookChapterTranslationQueryService) listAnnotations(
+	binaryBookChapterID []byte,
+	translatorID uuid.UUID,
+	language string,
+) ([]BookChapterTranslationAnnotationOutput, error) {
+	const query = `
+		SELECT
+			annotation_id,
+			paragraph_index,
+			char_offset,
+			anchor_text,
+			text,
+			is_detached,
+			created_at
+		FROM book_chapter_translation_annotation
+		WHERE book_chapter_id = ? AND translator_id = ? AND language = ?
+		ORDER BY is_detached, paragraph_index, char_offset, created_at
+	`
+
+	binaryTranslatorID, err := translatorID.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxAnnotations []sqlxBookChapterTranslationAnnotation
+	err = service.connection.Select(&sqlxAnnotations, query, binaryBookChapterID, binaryTranslatorID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make([]BookChapterTranslationAnnotationOutput, len(sqlxAnnotations))
+	for i, annotation := range sqlxAnnotations {
+		annotations[i] = BookChapterTranslationAnnotationOutput{
+			AnnotationID:   annotation.AnnotationID,
+			ParagraphIndex: annotation.ParagraphIndex,
+			Offset:         annotation.CharOffset,
+			AnchorText:     annotation.AnchorText,
+			Text:           annotation.Text,
+			IsDetached:     annotation.IsDetached,
+			CreatedAt:      annotation.CreatedAt,
+		}
 	}
 
-	return translatorsID, nil
+	return annotations, nil
+}
+
+// ListChaptersNotTranslatedBy возвращает по порядку главы книги, которые переводчик ещё не перевёл
+func (service *bookChapterTranslationQueryService) ListChaptersNotTranslatedBy(
+	bookID model.BookID,
+	translatorID model.UserID,
+	language maybe.Maybe[string],
+) ([]BookChapterOutput, error) {
+	translationCondition := `bct.book_chapter_id = bc.book_chapter_id AND bct.translator_id = ?`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	binaryTranslatorID, err := uuid.UUID(translatorID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{binaryTranslatorID}
+	if value, ok := language.Get(); ok {
+		translationCondition += ` AND bct.language = ?`
+		args = append(args, value)
+	}
+	args = append(args, binaryBookID)
+
+	query := `
+		SELECT bc.book_chapter_id, bc.book_id, bc.chapter_index, bc.title
+		FROM book_chapter bc
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM book_chapter_translation bct WHERE ` + translationCondition + `
+		) AND bc.book_id = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
+		ORDER BY bc.chapter_index, bc.book_chapter_id
+	`
+
+	var sqlxBookChapters []sqlxBookChapter
+	err = service.connection.Select(&sqlxBookChapters, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	bookChapterOutputs := make([]BookChapterOutput, len(sqlxBookChapters))
+	for i, b := range sqlxBookChapters {
+		bookChapterOutputs[i] = convertSqlxBookChapter(b)
+	}
+
+	return bookChapterOutputs, nil
+}
+
+// GetAlignedByBookChapterIDAndTranslatorID делит оригинал главы и перевод на абзацы и сопоставляет их для параллельного чтения
+func (service *bookChapterTranslationQueryService) GetAlignedByBookChapterIDAndTranslatorID(
+	bookChapterID model.BookChapterID,
+	translatorID model.UserID,
+	language string,
+) (AlignedBookChapterTranslationOutput, error) {
+	const query = `
+		SELECT
+			bc.original_text,
+			b.original_language
+		FROM book_chapter bc
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE bc.book_chapter_id = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
+	`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return AlignedBookChapterTranslationOutput{}, err
+	}
+
+	var original sqlxBookChapterOriginal
+	err = service.connection.Get(&original, query, binaryBookChapterID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return AlignedBookChapterTranslationOutput{}, model.ErrBookChapterNotFound
+	}
+	if err != nil {
+		return AlignedBookChapterTranslationOutput{}, err
+	}
+	if !original.OriginalText.Valid {
+		return AlignedBookChapterTranslationOutput{}, model.ErrBookChapterOriginalTextNotFound
+	}
+
+	translation, err := service.GetByBookChapterIDAndTranslatorID(bookChapterID, translatorID, language)
+	if err != nil {
+		return AlignedBookChapterTranslationOutput{}, err
+	}
+
+	originalParagraphs := model.TranslationParagraphs(original.OriginalText.String)
+	translationParagraphs := model.TranslationParagraphs(translation.Text)
+	alignment := model.AlignParagraphs(originalParagraphs, translationParagraphs)
+
+	paragraphs := make([]AlignedParagraphsOutput, len(alignment))
+	for i, row := range alignment {
+		paragraphs[i] = AlignedParagraphsOutput{
+			Original:    make([]string, len(row.Original)),
+			Translation: make([]string, len(row.Translation)),
+		}
+		for j, index := range row.Original {
+			paragraphs[i].Original[j] = originalParagraphs[index]
+		}
+		for j, index := range row.Translation {
+			paragraphs[i].Translation[j] = translationParagraphs[index]
+		}
+	}
+
+	return AlignedBookChapterTranslationOutput{
+		TranslatorID:     translation.TranslatorID,
+		Language:         translation.Language,
+		OriginalLanguage: maybeString(original.OriginalLanguage),
+		Paragraphs:       paragraphs,
+	}, nil
+}
+
+// convertSqlxBookChapterTranslation отрисовывает переводы, сохранённые до появления колонки html
+func convertSqlxBookChapterTranslation(translation sqlxBookChapterTranslation) BookChapterTranslationOutput {
+	html := translation.HTML.String
+	if !translation.HTML.Valid {
+		html = model.RenderTranslationMarkup(translation.Text)
+	}
+
+	return BookChapterTranslationOutput{
+		TranslatorID: translation.TranslatorID,
+		Language:     translation.Language,
+		Text:         translation.Text,
+		HTML:         html,
+		Score:        translation.Score,
+	}
+}
+
+type sqlxBookChapterTranslation struct {
+	TranslatorID uuid.UUID      `db:"translator_id"`
+	Language     string         `db:"language"`
+	Text         string         `db:"text"`
+	HTML         sql.NullString `db:"html"`
+	Score        int            `db:"score"`
+}
+
+type sqlxBookChapterOriginal struct {
+	OriginalText     sql.NullString `db:"original_text"`
+	OriginalLanguage sql.NullString `db:"original_language"`
 }
 
 type sqlxBookChapterTranslator struct {
-	BookChapterID uuid.UUID `db:"translator_id"`
+	TranslatorID uuid.UUID `db:"translator_id"`
+	Language     string    `db:"language"`
+	Score        int       `db:"score"`
+}
+
+type sqlxBookChapterTranslationAnnotation struct {
+	AnnotationID   uuid.UUID `db:"annotation_id"`
+	ParagraphIndex int       `db:"paragraph_index"`
+	CharOffset     int       `db:"char_offset"`
+	AnchorText     string    `db:"anchor_text"`
+	Text           string    `db:"text"`
+	IsDetached     bool      `db:"is_detached"`
+	CreatedAt      time.Time `db:"created_at"`
 }
@@ -0,0 +1,104 @@
+package query
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type BookCommentQueryService interface {
+	ListByBookID(bookID model.BookID, cursor maybe.Maybe[Cursor], size int) ([]BookCommentOutput, maybe.Maybe[Cursor], error)
+}
+
+type BookCommentOutput struct {
+	BookCommentID uuid.UUID
+	UserID        uuid.UUID
+	UserLogin     string
+	Comment       string
+	CreatedAt     time.Time
+}
+
+type bookCommentQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewBookCommentQueryService(connection *sqlx.DB) *bookCommentQueryService {
+	return &bookCommentQueryService{connection: connection}
+}
+
+// ListByBookID возвращает комментарии от новых к старым, начиная с комментария, следующего за курсором
+func (service *bookCommentQueryService) ListByBookID(
+	bookID model.BookID,
+	cursor maybe.Maybe[Cursor],
+	size int,
+) ([]BookCommentOutput, maybe.Maybe[Cursor], error) {
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, maybe.Nothing[Cursor](), err
+	}
+
+	conditions := "bc.book_id = ?"
+	args := []interface{}{binaryBookID}
+
+	if value, ok := cursor.Get(); ok {
+		condition, cursorArgs, err2 := keysetCondition("bc.created_at", "bc.book_comment_id", true, value)
+		if err2 != nil {
+			return nil, maybe.Nothing[Cursor](), err2
+		}
+		conditions += " AND " + condition
+		args = append(args, cursorArgs...)
+	}
+
+	query := `
+		SELECT
+			bc.book_comment_id,
+			bc.user_id,
+			u.login,
+			bc.comment,
+			bc.created_at
+		FROM book_comment bc
+		INNER JOIN user u ON u.user_id = bc.user_id
+		WHERE ` + conditions + `
+		ORDER BY bc.created_at DESC, bc.book_comment_id
+		LIMIT ?
+	`
+	args = append(args, size+1)
+
+	var sqlxBookComments []sqlxBookComment
+	err = service.connection.Select(&sqlxBookComments, query, args...)
+	if err != nil {
+		return nil, maybe.Nothing[Cursor](), err
+	}
+
+	nextCursor := maybe.Nothing[Cursor]()
+	if len(sqlxBookComments) > size {
+		sqlxBookComments = sqlxBookComments[:size]
+		if size > 0 {
+			last := sqlxBookComments[size-1]
+			nextCursor = maybe.Just(Cursor{Key: last.CreatedAt.Format(time.DateTime), ID: last.BookCommentID})
+		}
+	}
+
+	bookCommentOutputs := make([]BookCommentOutput, len(sqlxBookComments))
+	for i, c := range sqlxBookComments {
+		bookCommentOutputs[i] = BookCommentOutput{
+			BookCommentID: c.BookCommentID,
+			UserID:        c.UserID,
+			UserLogin:     c.UserLogin,
+			Comment:       c.Comment,
+			CreatedAt:     c.CreatedAt,
+		}
+	}
+
+	return bookCommentOutputs, nextCursor, nil
+}
+
+type sqlxBookComment struct {
+	BookCommentID uuid.UUID `db:"book_comment_id"`
+	UserID        uuid.UUID `db:"user_id"`
+	UserLogin     string    `db:"login"`
+	Comment       string    `db:"comment"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type BookGlossaryQueryService interface {
+	ListByBookID(bookID model.BookID, language maybe.Maybe[string], userID maybe.Maybe[model.UserID]) ([]BookGlossaryTermOutput, error)
+}
+
+type BookGlossaryTermOutput struct {
+	TermID      uuid.UUID
+	Language    string
+	SourceTerm  string
+	TargetTerm  string
+	Notes       maybe.Maybe[string]
+	AuthorID    uuid.UUID
+	AuthorLogin string
+	IsShared    bool
+	CreatedAt   time.Time
+}
+
+type bookGlossaryQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewBookGlossaryQueryService(connection *sqlx.DB) *bookGlossaryQueryService {
+	return &bookGlossaryQueryService{connection: connection}
+}
+
+// ListByBookID возвращает общие термины книги и личные термины пользователя, чужие личные термины не показываются
+func (service *bookGlossaryQueryService) ListByBookID(
+	bookID model.BookID,
+	language maybe.Maybe[string],
+	userID maybe.Maybe[model.UserID],
+) ([]BookGlossaryTermOutput, error) {
+	query := `
+		SELECT
+			bgt.glossary_term_id,
+			bgt.language,
+			bgt.source_term,
+			bgt.target_term,
+			bgt.notes,
+			bgt.author_id,
+			u.login AS author_login,
+			bgt.is_shared,
+			bgt.created_at
+		FROM book_glossary_term bgt
+		INNER JOIN user u ON bgt.author_id = u.user_id
+		WHERE bgt.book_id = ?
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{binaryBookID}
+	if value, ok := language.Get(); ok {
+		query += ` AND bgt.language = ?`
+		args = append(args, value)
+	}
+	if value, ok := userID.Get(); ok {
+		binaryUserID, err2 := uuid.UUID(value).MarshalBinary()
+		if err2 != nil {
+			return nil, err2
+		}
+		query += ` AND (bgt.is_shared = 1 OR bgt.author_id = ?)`
+		args = append(args, binaryUserID)
+	} else {
+		query += ` AND bgt.is_shared = 1`
+	}
+	query += ` ORDER BY bgt.language, bgt.source_term, bgt.is_shared DESC`
+
+	var sqlxTerms []sqlxBookGlossaryTerm
+	err = service.connection.Select(&sqlxTerms, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]BookGlossaryTermOutput, len(sqlxTerms))
+	for i, term := range sqlxTerms {
+		terms[i] = BookGlossaryTermOutput{
+			TermID:      term.GlossaryTermID,
+			Language:    term.Language,
+			SourceTerm:  term.SourceTerm,
+			TargetTerm:  term.TargetTerm,
+			Notes:       maybeString(term.Notes),
+			AuthorID:    term.AuthorID,
+			AuthorLogin: term.AuthorLogin,
+			IsShared:    term.IsShared,
+			CreatedAt:   term.CreatedAt,
+		}
+	}
+
+	return terms, nil
+}
+
+type sqlxBookGlossaryTerm struct {
+	GlossaryTermID uuid.UUID      `db:"glossary_term_id"`
+	Language       string         `db:"language"`
+	SourceTerm     string         `db:"source_term"`
+	TargetTerm     string         `db:"target_term"`
+	Notes          sql.NullString `db:"notes"`
+	AuthorID       uuid.UUID      `db:"author_id"`
+	AuthorLogin    string         `db:"author_login"`
+	IsShared       bool           `db:"is_shared"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
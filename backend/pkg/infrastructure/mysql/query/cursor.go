@@ -0,0 +1,57 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gofrs/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor указывает на последнюю запись предыдущей страницы: значение ключа сортировки и ID записи.
+// Order хранит порядок сортировки, для которого выдан курсор, чтобы его нельзя было применить к другому
+type Cursor struct {
+	Order int       `json:"o"`
+	Key   string    `json:"k"`
+	ID    uuid.UUID `json:"i"`
+}
+
+// EncodeCursor возвращает непрозрачную для клиента строку
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// keysetCondition выбирает записи строго после курсора при сортировке по ключу и затем по возрастанию ID
+func keysetCondition(keyExpression, idExpression string, descending bool, cursor Cursor) (string, []interface{}, error) {
+	binaryID, err := cursor.ID.MarshalBinary()
+	if err != nil {
+		return "", nil, err
+	}
+
+	operator := ">"
+	if descending {
+		operator = "<"
+	}
+
+	condition := "(" + keyExpression + " " + operator + " ? OR (" + keyExpression + " = ? AND " + idExpression + " > ?))"
+
+	return condition, []interface{}{cursor.Key, cursor.Key, binaryID}, nil
+}
@@ -3,15 +3,26 @@ package query
 import (
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
 )
 
 type GenreQueryService interface {
 	List() ([]GenreOutput, error)
+	ListByBookID(bookID model.BookID) ([]GenreOutput, error)
+	ListByBookIDs(bookIDs []model.BookID) (map[uuid.UUID][]GenreOutput, error)
+	ListWithBookCount() ([]GenreBookCountOutput, error)
 }
 
 type GenreOutput struct {
-	GenreID uuid.UUID `json:"id"`
-	Name    string    `json:"name"`
+	GenreID  uuid.UUID              `json:"id"`
+	ParentID maybe.Maybe[uuid.UUID] `json:"-"`
+	Name     string                 `json:"name"`
+}
+
+type GenreBookCountOutput struct {
+	GenreOutput
+	BookCount int
 }
 
 type genreQueryService struct {
@@ -26,8 +37,10 @@ func (service *genreQueryService) List() ([]GenreOutput, error) {
 	const query = `
 		SELECT 
 			genre_id,
+			parent_id,
 			name
-		FROM genre;
+		FROM genre
+		WHERE deleted_at IS NULL;
 	`
 
 	var sqlxGenres []sqlxGenre
@@ -38,16 +51,121 @@ func (service *genreQueryService) List() ([]GenreOutput, error) {
 
 	genreOutputs := make([]GenreOutput, len(sqlxGenres))
 	for i, g := range sqlxGenres {
-		genreOutputs[i] = GenreOutput{
-			GenreID: g.GenreID,
-			Name:    g.Name,
+		genreOutputs[i] = convertSqlxGenre(g)
+	}
+
+	return genreOutputs, nil
+}
+
+func (service *genreQueryService) ListByBookID(bookID model.BookID) ([]GenreOutput, error) {
+	genresByBookID, err := service.ListByBookIDs([]model.BookID{bookID})
+	if err != nil {
+		return nil, err
+	}
+
+	return genresByBookID[bookID], nil
+}
+
+// ListWithBookCount возвращает все жанры с числом опубликованных книг, в том числе жанры без книг
+func (service *genreQueryService) ListWithBookCount() ([]GenreBookCountOutput, error) {
+	const query = `
+		SELECT
+			g.genre_id,
+			g.parent_id,
+			g.name,
+			COUNT(b.book_id) AS book_count
+		FROM genre g
+		LEFT JOIN book_genre bg ON bg.genre_id = g.genre_id
+		LEFT JOIN book b ON b.book_id = bg.book_id AND b.is_publish = 1 AND b.deleted_at IS NULL
+		WHERE g.deleted_at IS NULL
+		GROUP BY g.genre_id, g.parent_id, g.name
+		ORDER BY book_count DESC, g.name, g.genre_id
+	`
+
+	var sqlxGenres []sqlxGenreBookCount
+	err := service.connection.Select(&sqlxGenres, query)
+	if err != nil {
+		return nil, err
+	}
+
+	genreOutputs := make([]GenreBookCountOutput, len(sqlxGenres))
+	for i, g := range sqlxGenres {
+		genreOutputs[i] = GenreBookCountOutput{
+			GenreOutput: convertSqlxGenre(g.sqlxGenre),
+			BookCount:   g.BookCount,
 		}
 	}
 
 	return genreOutputs, nil
 }
 
+// ListByBookIDs загружает жанры сразу для нескольких книг одним запросом
+func (service *genreQueryService) ListByBookIDs(bookIDs []model.BookID) (map[uuid.UUID][]GenreOutput, error) {
+	genresByBookID := make(map[uuid.UUID][]GenreOutput, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return genresByBookID, nil
+	}
+
+	const query = `
+		SELECT
+			bg.book_id,
+			g.genre_id,
+			g.parent_id,
+			g.name
+		FROM book_genre bg
+		INNER JOIN genre g ON g.genre_id = bg.genre_id
+		WHERE bg.book_id IN (?) AND g.deleted_at IS NULL
+		ORDER BY g.name, g.genre_id
+	`
+
+	binaryBookIDs, err := marshalUUIDs(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inQuery, args, err := sqlx.In(query, binaryBookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxBookGenres []sqlxBookGenre
+	err = service.connection.Select(&sqlxBookGenres, service.connection.Rebind(inQuery), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range sqlxBookGenres {
+		genresByBookID[g.BookID] = append(genresByBookID[g.BookID], convertSqlxGenre(g.sqlxGenre))
+	}
+
+	return genresByBookID, nil
+}
+
+type sqlxGenreBookCount struct {
+	sqlxGenre
+	BookCount int `db:"book_count"`
+}
+
+type sqlxBookGenre struct {
+	BookID uuid.UUID `db:"book_id"`
+	sqlxGenre
+}
+
+func convertSqlxGenre(genre sqlxGenre) GenreOutput {
+	parentID := maybe.Nothing[uuid.UUID]()
+	if genre.ParentID.Valid {
+		parentID = maybe.Just(genre.ParentID.UUID)
+	}
+
+	return GenreOutput{
+		GenreID:  genre.GenreID,
+		ParentID: parentID,
+		Name:     genre.Name,
+	}
+}
+
 type sqlxGenre struct {
-	GenreID uuid.UUID `db:"genre_id"`
-	Name    string    `db:"name"`
+	GenreID  uuid.UUID     `db:"genre_id"`
+	ParentID uuid.NullUUID `db:"parent_id"`
+	Name     string        `db:"name"`
 }
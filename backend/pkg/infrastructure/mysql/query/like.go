@@ -0,0 +1,10 @@
+package query
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike экранирует спецсимволы LIKE, чтобы пользовательский ввод искался как обычный текст
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -5,17 +5,24 @@ import (
 	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
 )
 
 type ReadingSessionQueryService interface {
 	GetLastReadingSession(bookID model.BookID, userID model.UserID) (ReadingSessionOutput, error)
+	GetPreferredTranslator(bookID model.BookID, userID model.UserID) (PreferredTranslatorOutput, error)
 }
 
 type ReadingSessionOutput struct {
 	BookChapterId uuid.UUID
 }
 
+type PreferredTranslatorOutput struct {
+	TranslatorID uuid.UUID
+	Language     maybe.Maybe[string]
+}
+
 type readingSessionQueryService struct {
 	connection *sqlx.DB
 }
@@ -31,7 +38,8 @@ func (service *readingSessionQueryService) GetLastReadingSession(
 	const query = `
 		SELECT rs.book_chapter_id
 		FROM reading_session rs
-		WHERE rs.book_id = ? AND rs.user_id = ?
+		INNER JOIN book_chapter bc ON rs.book_chapter_id = bc.book_chapter_id
+		WHERE rs.book_id = ? AND rs.user_id = ? AND bc.deleted_at IS NULL
 		ORDER BY rs.last_read_time DESC;
 	`
 
@@ -61,3 +69,44 @@ func (service *readingSessionQueryService) GetLastReadingSession(
 type sqlxReadingSession struct {
 	BookChapterId uuid.UUID `db:"book_chapter_id"`
 }
+
+func (service *readingSessionQueryService) GetPreferredTranslator(
+	bookID model.BookID,
+	userID model.UserID,
+) (PreferredTranslatorOutput, error) {
+	const query = `
+		SELECT
+			translator_id,
+			language
+		FROM preferred_translator
+		WHERE book_id = ? AND user_id = ?
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return PreferredTranslatorOutput{}, err
+	}
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return PreferredTranslatorOutput{}, err
+	}
+
+	var preferredTranslator sqlxPreferredTranslator
+	err = service.connection.Get(&preferredTranslator, query, binaryBookID, binaryUserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PreferredTranslatorOutput{}, model.ErrPreferredTranslatorNotFound
+	}
+	if err != nil {
+		return PreferredTranslatorOutput{}, err
+	}
+
+	return PreferredTranslatorOutput{
+		TranslatorID: preferredTranslator.TranslatorID,
+		Language:     maybeString(preferredTranslator.Language),
+	}, nil
+}
+
+type sqlxPreferredTranslator struct {
+	TranslatorID uuid.UUID      `db:"translator_id"`
+	Language     sql.NullString `db:"language"`
+}
@@ -0,0 +1,174 @@
+package query
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"strings"
+	"time"
+)
+
+type TagQueryService interface {
+	Search(name string, page, size int) ([]TagOutput, error)
+	ListByStatus(status model.TagStatus, page, size int) ([]TagOutput, error)
+	ListCloudByBookID(bookID model.BookID) ([]TagCloudOutput, error)
+}
+
+type TagOutput struct {
+	TagID       uuid.UUID
+	Name        string
+	Status      model.TagStatus
+	SuggestedBy maybe.Maybe[uuid.UUID]
+	CreatedAt   time.Time
+	BookCount   int
+}
+
+// TagCloudOutput - тег книги с числом пользователей, которые отметили им книгу
+type TagCloudOutput struct {
+	TagID  uuid.UUID
+	Name   string
+	Weight int
+}
+
+type tagQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewTagQueryService(connection *sqlx.DB) *tagQueryService {
+	return &tagQueryService{connection: connection}
+}
+
+// Search ищет одобренные теги по подстроке, теги, начинающиеся с запроса, идут первыми
+func (service *tagQueryService) Search(name string, page, size int) ([]TagOutput, error) {
+	const query = `
+		SELECT
+			t.tag_id,
+			t.name,
+			t.status,
+			t.suggested_by,
+			t.created_at,
+			COUNT(DISTINCT bt.book_id) AS book_count
+		FROM tag t
+		LEFT JOIN book_tag bt ON bt.tag_id = t.tag_id
+		WHERE t.status = ? AND t.name LIKE ?
+		GROUP BY t.tag_id, t.name, t.status, t.suggested_by, t.created_at
+		ORDER BY t.name LIKE ? DESC, book_count DESC, t.name, t.tag_id
+		LIMIT ? OFFSET ?
+	`
+
+	normalized := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	escaped := escapeLike(normalized)
+
+	var sqlxTags []sqlxTagOutput
+	err := service.connection.Select(&sqlxTags, query,
+		model.TagApproved,
+		"%"+escaped+"%",
+		escaped+"%",
+		size,
+		(page-1)*size,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSqlxTagOutputs(sqlxTags), nil
+}
+
+func (service *tagQueryService) ListByStatus(status model.TagStatus, page, size int) ([]TagOutput, error) {
+	const query = `
+		SELECT
+			t.tag_id,
+			t.name,
+			t.status,
+			t.suggested_by,
+			t.created_at,
+			COUNT(DISTINCT bt.book_id) AS book_count
+		FROM tag t
+		LEFT JOIN book_tag bt ON bt.tag_id = t.tag_id
+		WHERE t.status = ?
+		GROUP BY t.tag_id, t.name, t.status, t.suggested_by, t.created_at
+		ORDER BY t.created_at, t.tag_id
+		LIMIT ? OFFSET ?
+	`
+
+	var sqlxTags []sqlxTagOutput
+	err := service.connection.Select(&sqlxTags, query, status, size, (page-1)*size)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSqlxTagOutputs(sqlxTags), nil
+}
+
+func (service *tagQueryService) ListCloudByBookID(bookID model.BookID) ([]TagCloudOutput, error) {
+	const query = `
+		SELECT
+			t.tag_id,
+			t.name,
+			COUNT(*) AS weight
+		FROM book_tag bt
+		INNER JOIN tag t ON t.tag_id = bt.tag_id
+		WHERE bt.book_id = ? AND t.status = ?
+		GROUP BY t.tag_id, t.name
+		ORDER BY weight DESC, t.name
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxTagCloud []sqlxTagCloudOutput
+	err = service.connection.Select(&sqlxTagCloud, query, binaryBookID, model.TagApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	tagCloudOutputs := make([]TagCloudOutput, len(sqlxTagCloud))
+	for i, t := range sqlxTagCloud {
+		tagCloudOutputs[i] = TagCloudOutput{
+			TagID:  t.TagID,
+			Name:   t.Name,
+			Weight: t.Weight,
+		}
+	}
+
+	return tagCloudOutputs, nil
+}
+
+func convertSqlxTagOutputs(sqlxTags []sqlxTagOutput) []TagOutput {
+	tagOutputs := make([]TagOutput, len(sqlxTags))
+	for i, t := range sqlxTags {
+		suggestedBy := maybe.Nothing[uuid.UUID]()
+		if t.SuggestedBy.Valid {
+			suggestedBy = maybe.Just(t.SuggestedBy.UUID)
+		}
+
+		tagOutputs[i] = TagOutput{
+			TagID:       t.TagID,
+			Name:        t.Name,
+			Status:      model.TagStatus(t.Status),
+			SuggestedBy: suggestedBy,
+			CreatedAt:   t.CreatedAt,
+			BookCount:   t.BookCount,
+		}
+	}
+
+	return tagOutputs
+}
+
+type sqlxTagOutput struct {
+	TagID       uuid.UUID     `db:"tag_id"`
+	Name        string        `db:"name"`
+	Status      int           `db:"status"`
+	SuggestedBy uuid.NullUUID `db:"suggested_by"`
+	CreatedAt   time.Time     `db:"created_at"`
+	BookCount   int           `db:"book_count"`
+}
+
+type sqlxTagCloudOutput struct {
+	TagID  uuid.UUID `db:"tag_id"`
+	Name   string    `db:"name"`
+	Weight int       `db:"weight"`
+}
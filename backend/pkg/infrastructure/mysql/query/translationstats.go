@@ -0,0 +1,234 @@
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"math"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type TranslationStatsQueryService interface {
+	GetBookStats(bookID model.BookID) (BookTranslationStatsOutput, error)
+	GetTranslatorStats(translatorID model.UserID) (TranslatorStatsOutput, error)
+}
+
+type BookTranslationStatsOutput struct {
+	TotalChapters      int
+	TranslatedChapters int
+	WordCount          int
+	LastUpdatedAt      maybe.Maybe[time.Time]
+	Coverage           float64
+	Translators        []BookTranslatorStatsOutput
+}
+
+type BookTranslatorStatsOutput struct {
+	TranslatorID       uuid.UUID
+	Login              string
+	Language           string
+	TranslatedChapters int
+	WordCount          int
+	LastUpdatedAt      time.Time
+	Coverage           float64
+}
+
+type TranslatorStatsOutput struct {
+	TranslatedChapters int
+	WordCount          int
+	LastUpdatedAt      maybe.Maybe[time.Time]
+	Books              []TranslatorBookStatsOutput
+}
+
+type TranslatorBookStatsOutput struct {
+	BookID             uuid.UUID
+	Title              string
+	Language           string
+	TotalChapters      int
+	TranslatedChapters int
+	WordCount          int
+	LastUpdatedAt      time.Time
+	Coverage           float64
+}
+
+type translationStatsQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewTranslationStatsQueryService(connection *sqlx.DB) *translationStatsQueryService {
+	return &translationStatsQueryService{connection: connection}
+}
+
+// GetBookStats считает главу переведённой, если у неё есть перевод хотя бы на одном языке,
+// по переводчикам статистика считается отдельно для каждого языка
+func (service *translationStatsQueryService) GetBookStats(bookID model.BookID) (BookTranslationStatsOutput, error) {
+	const totalQuery = `
+		SELECT
+			(SELECT COUNT(*) FROM book_chapter c WHERE c.book_id = b.book_id AND c.deleted_at IS NULL) AS total_chapters,
+			COUNT(DISTINCT bct.book_chapter_id) AS translated_chapters,
+			COALESCE(SUM(bct.word_count), 0) AS word_count,
+			MAX(bct.updated_at) AS last_updated_at
+		FROM book b
+		LEFT JOIN book_chapter bc ON bc.book_id = b.book_id AND bc.deleted_at IS NULL
+		LEFT JOIN book_chapter_translation bct ON bct.book_chapter_id = bc.book_chapter_id
+		WHERE b.book_id = ? AND b.deleted_at IS NULL
+		GROUP BY b.book_id
+	`
+	const translatorsQuery = `
+		SELECT
+			bct.translator_id,
+			u.login,
+			bct.language,
+			COUNT(*) AS translated_chapters,
+			SUM(bct.word_count) AS word_count,
+			MAX(bct.updated_at) AS last_updated_at
+		FROM book_chapter_translation bct
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		INNER JOIN user u ON bct.translator_id = u.user_id
+		WHERE bc.book_id = ? AND bc.deleted_at IS NULL
+		GROUP BY bct.translator_id, u.login, bct.language
+		ORDER BY translated_chapters DESC, word_count DESC, u.login
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return BookTranslationStatsOutput{}, err
+	}
+
+	var total sqlxBookTranslationStats
+	err = service.connection.Get(&total, totalQuery, binaryBookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return BookTranslationStatsOutput{}, model.ErrBookNotFound
+	}
+	if err != nil {
+		return BookTranslationStatsOutput{}, err
+	}
+
+	var sqlxTranslators []sqlxBookTranslatorStats
+	err = service.connection.Select(&sqlxTranslators, translatorsQuery, binaryBookID)
+	if err != nil {
+		return BookTranslationStatsOutput{}, err
+	}
+
+	translators := make([]BookTranslatorStatsOutput, len(sqlxTranslators))
+	for i, translator := range sqlxTranslators {
+		translators[i] = BookTranslatorStatsOutput{
+			TranslatorID:       translator.TranslatorID,
+			Login:              translator.Login,
+			Language:           translator.Language,
+			TranslatedChapters: translator.TranslatedChapters,
+			WordCount:          translator.WordCount,
+			LastUpdatedAt:      translator.LastUpdatedAt,
+			Coverage:           coveragePercent(translator.TranslatedChapters, total.TotalChapters),
+		}
+	}
+
+	return BookTranslationStatsOutput{
+		TotalChapters:      total.TotalChapters,
+		TranslatedChapters: total.TranslatedChapters,
+		WordCount:          total.WordCount,
+		LastUpdatedAt:      maybeTime(total.LastUpdatedAt),
+		Coverage:           coveragePercent(total.TranslatedChapters, total.TotalChapters),
+		Translators:        translators,
+	}, nil
+}
+
+// GetTranslatorStats возвращает прогресс переводчика по каждой паре книга-язык, последние обновлённые первыми
+func (service *translationStatsQueryService) GetTranslatorStats(translatorID model.UserID) (TranslatorStatsOutput, error) {
+	const query = `
+		SELECT
+			b.book_id,
+			b.title,
+			bct.language,
+			(SELECT COUNT(*) FROM book_chapter c WHERE c.book_id = b.book_id AND c.deleted_at IS NULL) AS total_chapters,
+			COUNT(*) AS translated_chapters,
+			SUM(bct.word_count) AS word_count,
+			MAX(bct.updated_at) AS last_updated_at
+		FROM book_chapter_translation bct
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		INNER JOIN book b ON bc.book_id = b.book_id
+		WHERE bct.translator_id = ? AND bc.deleted_at IS NULL AND b.deleted_at IS NULL
+		GROUP BY b.book_id, b.title, bct.language
+		ORDER BY last_updated_at DESC, b.title
+	`
+
+	binaryTranslatorID, err := uuid.UUID(translatorID).MarshalBinary()
+	if err != nil {
+		return TranslatorStatsOutput{}, err
+	}
+
+	var sqlxBooks []sqlxTranslatorBookStats
+	err = service.connection.Select(&sqlxBooks, query, binaryTranslatorID)
+	if err != nil {
+		return TranslatorStatsOutput{}, err
+	}
+
+	output := TranslatorStatsOutput{
+		LastUpdatedAt: maybe.Nothing[time.Time](),
+		Books:         make([]TranslatorBookStatsOutput, len(sqlxBooks)),
+	}
+	for i, book := range sqlxBooks {
+		output.TranslatedChapters += book.TranslatedChapters
+		output.WordCount += book.WordCount
+		if lastUpdatedAt, ok := output.LastUpdatedAt.Get(); !ok || book.LastUpdatedAt.After(lastUpdatedAt) {
+			output.LastUpdatedAt = maybe.Just(book.LastUpdatedAt)
+		}
+
+		output.Books[i] = TranslatorBookStatsOutput{
+			BookID:             book.BookID,
+			Title:              book.Title,
+			Language:           book.Language,
+			TotalChapters:      book.TotalChapters,
+			TranslatedChapters: book.TranslatedChapters,
+			WordCount:          book.WordCount,
+			LastUpdatedAt:      book.LastUpdatedAt,
+			Coverage:           coveragePercent(book.TranslatedChapters, book.TotalChapters),
+		}
+	}
+
+	return output, nil
+}
+
+// coveragePercent - доля переведённых глав в процентах с точностью до десятых
+func coveragePercent(translated, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(translated)/float64(total)*1000) / 10
+}
+
+func maybeTime(value sql.NullTime) maybe.Maybe[time.Time] {
+	if !value.Valid {
+		return maybe.Nothing[time.Time]()
+	}
+	return maybe.Just(value.Time)
+}
+
+type sqlxBookTranslationStats struct {
+	TotalChapters      int          `db:"total_chapters"`
+	TranslatedChapters int          `db:"translated_chapters"`
+	WordCount          int          `db:"word_count"`
+	LastUpdatedAt      sql.NullTime `db:"last_updated_at"`
+}
+
+type sqlxBookTranslatorStats struct {
+	TranslatorID       uuid.UUID `db:"translator_id"`
+	Login              string    `db:"login"`
+	Language           string    `db:"language"`
+	TranslatedChapters int       `db:"translated_chapters"`
+	WordCount          int       `db:"word_count"`
+	LastUpdatedAt      time.Time `db:"last_updated_at"`
+}
+
+type sqlxTranslatorBookStats struct {
+	BookID             uuid.UUID `db:"book_id"`
+	Title              string    `db:"title"`
+	Language           string    `db:"language"`
+	TotalChapters      int       `db:"total_chapters"`
+	TranslatedChapters int       `db:"translated_chapters"`
+	WordCount          int       `db:"word_count"`
+	LastUpdatedAt      time.Time `db:"last_updated_at"`
+}
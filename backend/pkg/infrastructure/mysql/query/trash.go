@@ -0,0 +1,89 @@
+package query
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type TrashQueryService interface {
+	List(entityType maybe.Maybe[model.TrashEntityType], page, size int) ([]TrashItemOutput, error)
+}
+
+type TrashItemOutput struct {
+	ID         uuid.UUID
+	EntityType model.TrashEntityType
+	Title      string
+	DeletedAt  time.Time
+}
+
+type trashQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewTrashQueryService(connection *sqlx.DB) *trashQueryService {
+	return &trashQueryService{connection: connection}
+}
+
+func (service *trashQueryService) List(entityType maybe.Maybe[model.TrashEntityType], page, size int) ([]TrashItemOutput, error) {
+	query := `
+		SELECT id, entity_type, title, deleted_at
+		FROM (
+			SELECT b.book_id AS id, ? AS entity_type, b.title AS title, b.deleted_at
+			FROM book b
+			WHERE b.deleted_at IS NOT NULL
+			UNION ALL
+			SELECT bc.book_chapter_id, ?, bc.title, bc.deleted_at
+			FROM book_chapter bc
+			WHERE bc.deleted_at IS NOT NULL
+			UNION ALL
+			SELECT a.author_id, ?, CONCAT(a.first_name, ' ', a.second_name), a.deleted_at
+			FROM author a
+			WHERE a.deleted_at IS NOT NULL
+			UNION ALL
+			SELECT g.genre_id, ?, g.name, g.deleted_at
+			FROM genre g
+			WHERE g.deleted_at IS NOT NULL
+		) trash
+	`
+	args := []interface{}{
+		model.TrashEntityBook,
+		model.TrashEntityBookChapter,
+		model.TrashEntityAuthor,
+		model.TrashEntityGenre,
+	}
+
+	if value, ok := entityType.Get(); ok {
+		query += " WHERE entity_type = ?"
+		args = append(args, value)
+	}
+	query += " ORDER BY deleted_at DESC, id LIMIT ? OFFSET ?"
+	args = append(args, size, (page-1)*size)
+
+	var sqlxTrashItems []sqlxTrashItem
+	err := service.connection.Select(&sqlxTrashItems, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	trashItemOutputs := make([]TrashItemOutput, len(sqlxTrashItems))
+	for i, item := range sqlxTrashItems {
+		trashItemOutputs[i] = TrashItemOutput{
+			ID:         item.ID,
+			EntityType: model.TrashEntityType(item.EntityType),
+			Title:      item.Title,
+			DeletedAt:  item.DeletedAt,
+		}
+	}
+
+	return trashItemOutputs, nil
+}
+
+type sqlxTrashItem struct {
+	ID         uuid.UUID `db:"id"`
+	EntityType int       `db:"entity_type"`
+	Title      string    `db:"title"`
+	DeletedAt  time.Time `db:"deleted_at"`
+}
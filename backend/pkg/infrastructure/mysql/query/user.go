@@ -32,11 +32,11 @@ func (service *userQueryService) FindByLogin(login string) (model.User, error) {
 			password,
        		about_me
 		FROM user
-		WHERE login = ?;
+		WHERE login = ? AND role <> ?;
 `
 
 	var user sqlxUser
-	err := service.connection.Get(&user, query, login)
+	err := service.connection.Get(&user, query, login, model2.Deleted)
 	if errors.Is(err, sql.ErrNoRows) {
 		return model.User{}, model2.ErrUserNotFound
 	}
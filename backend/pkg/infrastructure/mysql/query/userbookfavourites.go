@@ -3,12 +3,10 @@ package query
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
-	"strings"
 )
 
 type UserBookFavouritesQueryService interface {
@@ -69,33 +67,31 @@ func (service *userBookFavouritesQueryService) ListBookByUserBookFavourites(
 		return nil, nil
 	}
 
-	query := `
+	const query = `
 		SELECT
 			ubf.type,
 			b.book_id,
 			i.path,
 			b.title,
-			b.description
+			b.description,
+			b.original_language
 		FROM user_book_favourites ubf
-		LEFT JOIN book b ON ubf.book_id = b.id
+		INNER JOIN book b ON ubf.book_id = b.book_id
 		LEFT JOIN image i ON b.cover_id = i.image_id
-		WHERE user_id = ?;
+		WHERE ubf.user_id = ? AND ubf.type IN (?) AND b.deleted_at IS NULL
 `
 	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
 
-	query += " AND IN (?)"
-	values := make([]string, len(userBookFavouritesTypes))
-	for i, v := range userBookFavouritesTypes {
-		values[i] = fmt.Sprintf("%v", v)
+	inQuery, args, err := sqlx.In(query, binaryUserID, userBookFavouritesTypes)
+	if err != nil {
+		return nil, err
 	}
-	valuesStr := strings.Join(values, ", ")
-	query = strings.Replace(query, "?", valuesStr, 1)
 
 	var userBookFavouritesBooksOutput []sqlxUserBookFavouritesBooksOutput
-	err = service.connection.Select(&userBookFavouritesBooksOutput, query, binaryUserID)
+	err = service.connection.Select(&userBookFavouritesBooksOutput, service.connection.Rebind(inQuery), args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, model.ErrUserBookFavouritesNotFound
 	}
@@ -116,10 +112,11 @@ func convertToUserBookFavouritesBooksOutput(sqlxOutputs []sqlxUserBookFavourites
 		}
 
 		book := BookOutput{
-			BookID:      output.BookID,
-			Cover:       cover,
-			Title:       output.Title,
-			Description: output.Description,
+			BookID:           output.BookID,
+			Cover:            cover,
+			Title:            output.Title,
+			Description:      output.Description,
+			OriginalLanguage: maybeString(output.OriginalLanguage),
 		}
 		grouped[model.UserBookFavouritesType(output.Type)] = append(
 			grouped[model.UserBookFavouritesType(output.Type)],
@@ -139,9 +136,10 @@ func convertToUserBookFavouritesBooksOutput(sqlxOutputs []sqlxUserBookFavourites
 }
 
 type sqlxUserBookFavouritesBooksOutput struct {
-	Type        int            `db:"type"`
-	BookID      uuid.UUID      `db:"book_id"`
-	Cover       sql.NullString `db:"path"`
-	Title       string         `db:"title"`
-	Description string         `db:"description"`
+	Type             int            `db:"type"`
+	BookID           uuid.UUID      `db:"book_id"`
+	Cover            sql.NullString `db:"path"`
+	Title            string         `db:"title"`
+	Description      string         `db:"description"`
+	OriginalLanguage sql.NullString `db:"original_language"`
 }
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type UserDataExportQueryService interface {
+	FindByID(userDataExportID model.UserDataExportID) (UserDataExportOutput, error)
+	FindArchiveByID(userDataExportID model.UserDataExportID) ([]byte, error)
+}
+
+type UserDataExportOutput struct {
+	UserDataExportID uuid.UUID
+	UserID           uuid.UUID
+	Status           model.UserDataExportStatus
+	CreatedAt        time.Time
+	CompletedAt      maybe.Maybe[time.Time]
+}
+
+type userDataExportQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewUserDataExportQueryService(connection *sqlx.DB) *userDataExportQueryService {
+	return &userDataExportQueryService{connection: connection}
+}
+
+func (service *userDataExportQueryService) FindByID(userDataExportID model.UserDataExportID) (UserDataExportOutput, error) {
+	const query = `
+		SELECT
+			ude.user_data_export_id,
+			ude.user_id,
+			ude.status,
+			ude.created_at,
+			ude.completed_at
+		FROM user_data_export ude
+		WHERE ude.user_data_export_id = ?
+	`
+
+	binaryUserDataExportID, err := uuid.UUID(userDataExportID).MarshalBinary()
+	if err != nil {
+		return UserDataExportOutput{}, err
+	}
+
+	var userDataExport sqlxUserDataExport
+	err = service.connection.Get(&userDataExport, query, binaryUserDataExportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserDataExportOutput{}, model.ErrUserDataExportNotFound
+	}
+	if err != nil {
+		return UserDataExportOutput{}, err
+	}
+
+	completedAt := maybe.Nothing[time.Time]()
+	if userDataExport.CompletedAt.Valid {
+		completedAt = maybe.Just(userDataExport.CompletedAt.Time)
+	}
+
+	return UserDataExportOutput{
+		UserDataExportID: userDataExport.UserDataExportID,
+		UserID:           userDataExport.UserID,
+		Status:           model.UserDataExportStatus(userDataExport.Status),
+		CreatedAt:        userDataExport.CreatedAt,
+		CompletedAt:      completedAt,
+	}, nil
+}
+
+func (service *userDataExportQueryService) FindArchiveByID(userDataExportID model.UserDataExportID) ([]byte, error) {
+	const query = `
+		SELECT ude.archive
+		FROM user_data_export ude
+		WHERE ude.user_data_export_id = ?
+	`
+
+	binaryUserDataExportID, err := uuid.UUID(userDataExportID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var archive []byte
+	err = service.connection.Get(&archive, query, binaryUserDataExportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrUserDataExportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if archive == nil {
+		return nil, model.ErrUserDataExportNotReady
+	}
+
+	return archive, nil
+}
+
+type sqlxUserDataExport struct {
+	UserDataExportID uuid.UUID    `db:"user_data_export_id"`
+	UserID           uuid.UUID    `db:"user_id"`
+	Status           int          `db:"status"`
+	CreatedAt        time.Time    `db:"created_at"`
+	CompletedAt      sql.NullTime `db:"completed_at"`
+}
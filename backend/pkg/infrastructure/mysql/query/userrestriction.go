@@ -0,0 +1,89 @@
+package query
+
+import (
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type UserRestrictionQueryService interface {
+	ListByUserID(userID model.UserID) ([]UserRestrictionOutput, error)
+}
+
+type UserRestrictionOutput struct {
+	UserRestrictionID uuid.UUID
+	UserID            uuid.UUID
+	IssuedBy          uuid.UUID
+	Type              model.UserRestrictionType
+	Reason            string
+	CreatedAt         time.Time
+	ExpiresAt         maybe.Maybe[time.Time]
+}
+
+type userRestrictionQueryService struct {
+	connection *sqlx.DB
+}
+
+func NewUserRestrictionQueryService(connection *sqlx.DB) *userRestrictionQueryService {
+	return &userRestrictionQueryService{connection: connection}
+}
+
+func (service *userRestrictionQueryService) ListByUserID(userID model.UserID) ([]UserRestrictionOutput, error) {
+	const query = `
+		SELECT
+			ur.user_restriction_id,
+			ur.user_id,
+			ur.issued_by,
+			ur.type,
+			ur.reason,
+			ur.created_at,
+			ur.expires_at
+		FROM user_restriction ur
+		WHERE ur.user_id = ?
+		ORDER BY ur.created_at DESC;
+	`
+
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxUserRestrictions []sqlxUserRestriction
+	err = service.connection.Select(&sqlxUserRestrictions, query, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRestrictionOutputs := make([]UserRestrictionOutput, len(sqlxUserRestrictions))
+	for i, r := range sqlxUserRestrictions {
+		expiresAt := maybe.Nothing[time.Time]()
+		if r.ExpiresAt.Valid {
+			expiresAt = maybe.Just(r.ExpiresAt.Time)
+		}
+
+		userRestrictionOutputs[i] = UserRestrictionOutput{
+			UserRestrictionID: r.UserRestrictionID,
+			UserID:            r.UserID,
+			IssuedBy:          r.IssuedBy,
+			Type:              model.UserRestrictionType(r.Type),
+			Reason:            r.Reason,
+			CreatedAt:         r.CreatedAt,
+			ExpiresAt:         expiresAt,
+		}
+	}
+
+	return userRestrictionOutputs, nil
+}
+
+type sqlxUserRestriction struct {
+	UserRestrictionID uuid.UUID    `db:"user_restriction_id"`
+	UserID            uuid.UUID    `db:"user_id"`
+	IssuedBy          uuid.UUID    `db:"issued_by"`
+	Type              int          `db:"type"`
+	Reason            string       `db:"reason"`
+	CreatedAt         time.Time    `db:"created_at"`
+	ExpiresAt         sql.NullTime `db:"expires_at"`
+}
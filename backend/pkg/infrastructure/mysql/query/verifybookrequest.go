@@ -20,6 +20,16 @@ type VerifyBookRequestOutput struct {
 	BookID              uuid.UUID
 	IsVerified          maybe.Maybe[bool]
 	SendDate            time.Time
+	Duplicates          []VerifyBookRequestDuplicateOutput
+}
+
+// VerifyBookRequestDuplicateOutput - похожая книга, найденная при отправке запроса на проверку
+type VerifyBookRequestDuplicateOutput struct {
+	BookID          uuid.UUID
+	Title           string
+	TitleSimilarity float64
+	AuthorOverlap   float64
+	Score           float64
 }
 
 type verifyBookRequestQueryService struct {
@@ -32,8 +42,10 @@ func NewVerifyBookRequestQueryService(connection *sqlx.DB) *verifyBookRequestQue
 
 func (service *verifyBookRequestQueryService) List() ([]VerifyBookRequestOutput, error) {
 	const query = `
-		SELECT *
+		SELECT vbr.*
 		FROM verify_book_request vbr
+		INNER JOIN book b ON vbr.book_id = b.book_id
+		WHERE b.deleted_at IS NULL
 		ORDER BY vbr.send_date DESC;
 	`
 
@@ -63,9 +75,71 @@ func (service *verifyBookRequestQueryService) List() ([]VerifyBookRequestOutput,
 		}
 	}
 
+	requestIDs := make([]uuid.UUID, len(verifyBookRequestsOutput))
+	for i, v := range verifyBookRequestsOutput {
+		requestIDs[i] = v.VerifyBookRequestID
+	}
+
+	duplicatesByRequestID, err := service.listDuplicates(requestIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range verifyBookRequestsOutput {
+		verifyBookRequestsOutput[i].Duplicates = duplicatesByRequestID[v.VerifyBookRequestID]
+	}
+
 	return verifyBookRequestsOutput, nil
 }
 
+func (service *verifyBookRequestQueryService) listDuplicates(
+	requestIDs []uuid.UUID,
+) (map[uuid.UUID][]VerifyBookRequestDuplicateOutput, error) {
+	if len(requestIDs) == 0 {
+		return nil, nil
+	}
+
+	binaryRequestIDs, err := marshalUUIDs(requestIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT
+			d.verify_book_request_id,
+			d.book_id,
+			b.title,
+			d.title_similarity,
+			d.author_overlap,
+			d.score
+		FROM verify_book_request_duplicate d
+		INNER JOIN book b ON b.book_id = d.book_id
+		WHERE d.verify_book_request_id IN (?) AND b.deleted_at IS NULL
+		ORDER BY d.score DESC, b.title
+	`, binaryRequestIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxDuplicates []sqlxVerifyBookRequestDuplicate
+	err = service.connection.Select(&sqlxDuplicates, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicatesByRequestID := make(map[uuid.UUID][]VerifyBookRequestDuplicateOutput)
+	for _, d := range sqlxDuplicates {
+		duplicatesByRequestID[d.VerifyBookRequestID] = append(duplicatesByRequestID[d.VerifyBookRequestID], VerifyBookRequestDuplicateOutput{
+			BookID:          d.BookID,
+			Title:           d.Title,
+			TitleSimilarity: d.TitleSimilarity,
+			AuthorOverlap:   d.AuthorOverlap,
+			Score:           d.Score,
+		})
+	}
+
+	return duplicatesByRequestID, nil
+}
+
 type sqlxVerifyBookRequest struct {
 	VerifyBookRequestID uuid.UUID    `db:"verify_book_request_id"`
 	TranslatorID        uuid.UUID    `db:"translator_id"`
@@ -73,3 +147,12 @@ type sqlxVerifyBookRequest struct {
 	IsVerified          sql.NullBool `db:"is_verified"`
 	SendDate            time.Time    `db:"send_date"`
 }
+
+type sqlxVerifyBookRequestDuplicate struct {
+	VerifyBookRequestID uuid.UUID `db:"verify_book_request_id"`
+	BookID              uuid.UUID `db:"book_id"`
+	Title               string    `db:"title"`
+	TitleSimilarity     float64   `db:"title_similarity"`
+	AuthorOverlap       float64   `db:"author_overlap"`
+	Score               float64   `db:"score"`
+}
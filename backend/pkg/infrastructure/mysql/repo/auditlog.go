@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"server/pkg/domain/model"
+)
+
+// auditLogRepository только добавляет записи: журнал не редактируется и не удаляется
+type auditLogRepository struct {
+	connection *sqlx.DB
+}
+
+func NewAuditLogRepository(connection *sqlx.DB) *auditLogRepository {
+	return &auditLogRepository{connection: connection}
+}
+
+func (repo *auditLogRepository) NextID() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+func (repo *auditLogRepository) Store(auditLog model.AuditLog) error {
+	const query = `
+		INSERT INTO
+			audit_log (
+			      audit_log_id,
+			      actor_id,
+			      action,
+			      entity_type,
+			      entity_id,
+			      before_data,
+			      after_data,
+			      ip,
+			      user_agent,
+			      created_at
+			)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	binaryAuditLogID, err := uuid.UUID(auditLog.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var binaryActorID *[]byte
+	if actorID, ok := auditLog.ActorID().Get(); ok {
+		uid, err2 := uuid.UUID(actorID).MarshalBinary()
+		if err2 != nil {
+			return err2
+		}
+		binaryActorID = &uid
+	}
+
+	binaryEntityID, err := auditLog.EntityID().MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var before sql.NullString
+	if value, ok := auditLog.Before().Get(); ok {
+		before = sql.NullString{String: value, Valid: true}
+	}
+
+	var after sql.NullString
+	if value, ok := auditLog.After().Get(); ok {
+		after = sql.NullString{String: value, Valid: true}
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryAuditLogID,
+		binaryActorID,
+		auditLog.Action(),
+		auditLog.EntityType(),
+		binaryEntityID,
+		before,
+		after,
+		auditLog.IP(),
+		auditLog.UserAgent(),
+		auditLog.CreatedAt(),
+	)
+
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type AuthorRepository struct {
@@ -21,56 +22,134 @@ func (repo *AuthorRepository) NextID() uuid.UUID {
 	return uuid.Must(uuid.NewV4())
 }
 
-func (repo *AuthorRepository) Store(author model.Author) error {
-	const query = `
-		INSERT INTO
-			author (
-				author_id,
-				avatar_id,
-				first_name,
-				second_name,
-				middle_name,
-				nickname
-			)
-		VALUES (?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			avatar_id = VALUES(avatar_id),
-			first_name = VALUES(first_name),
-			second_name = VALUES(second_name),
-			middle_name = VALUES(middle_name),
-			nickname = VALUES(nickname)
-	`
+func (repo *AuthorRepository) Store(author model.Author) (err error) {
+	tx, err := repo.connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
 
-	binaryAuthorID, err := uuid.UUID(author.ID()).MarshalBinary()
+	err = storeAuthor(tx, author)
 	if err != nil {
 		return err
 	}
 
-	var binaryAvatarID *[]byte
-	if avatarID, ok := author.AvatarID().Get(); ok {
-		uid, err2 := uuid.UUID(avatarID).MarshalBinary()
+	return tx.Commit()
+}
+
+// Merge блокирует обоих авторов, объединяет их функцией merge, сохраняет результат, переносит на него книги
+// дубликата и удаляет дубликат вместе с его аватаром, если аватар не достался оставшемуся автору.
+// Всё выполняется в одной транзакции, поэтому параллельные правки авторов не теряются.
+// Возвращает оставшегося автора после объединения и удалённый дубликат
+func (repo *AuthorRepository) Merge(
+	survivorID model.AuthorID,
+	duplicateID model.AuthorID,
+	merge func(survivor, duplicate model.Author) model.Author,
+) (merged model.Author, duplicate model.Author, err error) {
+	binarySurvivorID, err := uuid.UUID(survivorID).MarshalBinary()
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	binaryDuplicateID, err := uuid.UUID(duplicateID).MarshalBinary()
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	tx, err := repo.connection.Beginx()
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	survivor, err := findAuthorForUpdate(tx, binarySurvivorID)
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	duplicate, err = findAuthorForUpdate(tx, binaryDuplicateID)
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	merged = merge(survivor, duplicate)
+	err = storeAuthor(tx, merged)
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	// Книги, у которых уже указаны оба автора, не дублируются
+	_, err = tx.Exec(`
+		INSERT IGNORE INTO book_author (book_id, author_id, role, position)
+		SELECT book_id, ?, role, position FROM book_author WHERE author_id = ?
+	`, binarySurvivorID, binaryDuplicateID)
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM book_author WHERE author_id = ?`, binaryDuplicateID)
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM author WHERE author_id = ?`, binaryDuplicateID)
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
+
+	duplicateAvatarID, hasDuplicateAvatar := duplicate.AvatarID().Get()
+	mergedAvatarID, hasMergedAvatar := merged.AvatarID().Get()
+	if hasDuplicateAvatar && (!hasMergedAvatar || mergedAvatarID != duplicateAvatarID) {
+		binaryAvatarID, err2 := uuid.UUID(duplicateAvatarID).MarshalBinary()
 		if err2 != nil {
-			return err2
+			return model.Author{}, model.Author{}, err2
+		}
+
+		_, err = tx.Exec(`DELETE FROM image WHERE image_id = ?`, binaryAvatarID)
+		if err != nil {
+			return model.Author{}, model.Author{}, err
 		}
-		binaryAvatarID = &uid
-	} else {
-		binaryAvatarID = nil
 	}
 
-	_, err = repo.connection.Exec(query,
-		binaryAuthorID,
-		binaryAvatarID,
-		author.FirstName(),
-		author.SecondName(),
-		author.MiddleName(),
-		author.Nickname(),
-	)
+	err = tx.Commit()
+	if err != nil {
+		return model.Author{}, model.Author{}, err
+	}
 
-	return err
+	return merged, duplicate, nil
 }
 
 func (repo *AuthorRepository) Delete(authorID model.AuthorID) error {
-	const query = `DELETE FROM author WHERE author_id = ?`
+	const query = `UPDATE author SET deleted_at = ? WHERE author_id = ? AND deleted_at IS NULL`
+
+	binaryAuthorID, err := uuid.UUID(authorID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, time.Now(), binaryAuthorID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrAuthorNotFound
+	}
+
+	return err
+}
+
+func (repo *AuthorRepository) Restore(authorID model.AuthorID) error {
+	const query = `UPDATE author SET deleted_at = NULL WHERE author_id = ? AND deleted_at IS NOT NULL`
 
 	binaryAuthorID, err := uuid.UUID(authorID).MarshalBinary()
 	if err != nil {
@@ -90,15 +169,29 @@ func (repo *AuthorRepository) Delete(authorID model.AuthorID) error {
 	return err
 }
 
+func (repo *AuthorRepository) PurgeDeleted(deletedBefore time.Time) error {
+	const query = `DELETE FROM author WHERE deleted_at < ?`
+
+	_, err := repo.connection.Exec(query, deletedBefore)
+
+	return err
+}
+
 func (repo *AuthorRepository) FindByID(authorID model.AuthorID) (model.Author, error) {
 	const query = `
 		SELECT
+			author_id,
+			avatar_id,
 			first_name,
 			second_name,
 			middle_name,
-			nickname
+			nickname,
+			biography,
+			birth_year,
+			death_year,
+			country
 		FROM author
-		WHERE author_id = ?
+		WHERE author_id = ? AND deleted_at IS NULL
 	`
 
 	var author sqlxAuthor
@@ -115,20 +208,235 @@ func (repo *AuthorRepository) FindByID(authorID model.AuthorID) (model.Author, e
 		return model.Author{}, err
 	}
 
+	var aliases []string
+	err = repo.connection.Select(&aliases, `SELECT alias FROM author_alias WHERE author_id = ? ORDER BY alias`, binaryAuthorID)
+	if err != nil {
+		return model.Author{}, err
+	}
+
+	return convertSqlxAuthor(author, aliases), nil
+}
+
+// FindAll загружает всех неудалённых авторов вместе с псевдонимами
+func (repo *AuthorRepository) FindAll() ([]model.Author, error) {
+	const query = `
+		SELECT
+			author_id,
+			avatar_id,
+			first_name,
+			second_name,
+			middle_name,
+			nickname,
+			biography,
+			birth_year,
+			death_year,
+			country
+		FROM author
+		WHERE deleted_at IS NULL
+		ORDER BY second_name, first_name, author_id
+	`
+
+	var sqlxAuthors []sqlxAuthor
+	err := repo.connection.Select(&sqlxAuthors, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxAliases []sqlxAuthorAlias
+	err = repo.connection.Select(&sqlxAliases, `SELECT author_id, alias FROM author_alias ORDER BY alias`)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasesByAuthorID := make(map[uuid.UUID][]string)
+	for _, alias := range sqlxAliases {
+		aliasesByAuthorID[alias.AuthorID] = append(aliasesByAuthorID[alias.AuthorID], alias.Alias)
+	}
+
+	authors := make([]model.Author, len(sqlxAuthors))
+	for i, author := range sqlxAuthors {
+		authors[i] = convertSqlxAuthor(author, aliasesByAuthorID[author.AuthorID])
+	}
+
+	return authors, nil
+}
+
+func findAuthorForUpdate(tx *sqlx.Tx, binaryAuthorID []byte) (model.Author, error) {
+	const query = `
+		SELECT
+			author_id,
+			avatar_id,
+			first_name,
+			second_name,
+			middle_name,
+			nickname,
+			biography,
+			birth_year,
+			death_year,
+			country
+		FROM author
+		WHERE author_id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	var author sqlxAuthor
+	err := tx.Get(&author, query, binaryAuthorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Author{}, model.ErrAuthorNotFound
+	}
+	if err != nil {
+		return model.Author{}, err
+	}
+
+	var aliases []string
+	err = tx.Select(&aliases, `SELECT alias FROM author_alias WHERE author_id = ? ORDER BY alias`, binaryAuthorID)
+	if err != nil {
+		return model.Author{}, err
+	}
+
+	return convertSqlxAuthor(author, aliases), nil
+}
+
+func convertSqlxAuthor(author sqlxAuthor, aliases []string) model.Author {
+	avatarID := maybe.Nothing[model.ImageID]()
+	if author.AvatarID.Valid {
+		avatarID = maybe.Just(model.ImageID(author.AvatarID.UUID))
+	}
+
 	return model.NewAuthor(
-		authorID,
-		maybe.Nothing[model.ImageID](),
+		model.AuthorID(author.AuthorID),
+		avatarID,
 		author.FirstName,
 		author.SecondName,
-		maybe.Just(author.MiddleName),
-		maybe.Just(author.Nickname),
-	), nil
+		maybeString(author.MiddleName),
+		maybeString(author.Nickname),
+		maybeString(author.Biography),
+		maybeInt(author.BirthYear),
+		maybeInt(author.DeathYear),
+		maybeString(author.Country),
+		aliases,
+	)
+}
+
+func storeAuthor(tx *sqlx.Tx, author model.Author) error {
+	const query = `
+		INSERT INTO
+			author (
+				author_id,
+				avatar_id,
+				first_name,
+				second_name,
+				middle_name,
+				nickname,
+				biography,
+				birth_year,
+				death_year,
+				country
+			)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			avatar_id = VALUES(avatar_id),
+			first_name = VALUES(first_name),
+			second_name = VALUES(second_name),
+			middle_name = VALUES(middle_name),
+			nickname = VALUES(nickname),
+			biography = VALUES(biography),
+			birth_year = VALUES(birth_year),
+			death_year = VALUES(death_year),
+			country = VALUES(country)
+	`
+
+	binaryAuthorID, err := uuid.UUID(author.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var binaryAvatarID *[]byte
+	if avatarID, ok := author.AvatarID().Get(); ok {
+		uid, err2 := uuid.UUID(avatarID).MarshalBinary()
+		if err2 != nil {
+			return err2
+		}
+		binaryAvatarID = &uid
+	} else {
+		binaryAvatarID = nil
+	}
+
+	_, err = tx.Exec(query,
+		binaryAuthorID,
+		binaryAvatarID,
+		author.FirstName(),
+		author.SecondName(),
+		nullString(author.MiddleName()),
+		nullString(author.Nickname()),
+		nullString(author.Biography()),
+		nullInt(author.BirthYear()),
+		nullInt(author.DeathYear()),
+		nullString(author.Country()),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Псевдонимы перезаписываются целиком
+	_, err = tx.Exec(`DELETE FROM author_alias WHERE author_id = ?`, binaryAuthorID)
+	if err != nil {
+		return err
+	}
+
+	for _, alias := range author.Aliases() {
+		_, err = tx.Exec(`INSERT INTO author_alias (author_id, alias) VALUES (?, ?)`, binaryAuthorID, alias)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nullString(value maybe.Maybe[string]) sql.NullString {
+	if v, ok := value.Get(); ok {
+		return sql.NullString{String: v, Valid: true}
+	}
+	return sql.NullString{}
+}
+
+func nullInt(value maybe.Maybe[int]) sql.NullInt64 {
+	if v, ok := value.Get(); ok {
+		return sql.NullInt64{Int64: int64(v), Valid: true}
+	}
+	return sql.NullInt64{}
+}
+
+func maybeString(value sql.NullString) maybe.Maybe[string] {
+	if value.Valid {
+		return maybe.Just(value.String)
+	}
+	return maybe.Nothing[string]()
+}
+
+func maybeInt(value sql.NullInt64) maybe.Maybe[int] {
+	if value.Valid {
+		return maybe.Just(int(value.Int64))
+	}
+	return maybe.Nothing[int]()
 }
 
 // sqlxAuthor представляет структуру автора, используемую для сканирования данных из базы данных
 type sqlxAuthor struct {
-	FirstName  string `db:"first_name"`
-	SecondName string `db:"second_name"`
-	MiddleName string `db:"middle_name"`
-	Nickname   string `db:"nickname"`
+	AuthorID   uuid.UUID      `db:"author_id"`
+	AvatarID   uuid.NullUUID  `db:"avatar_id"`
+	FirstName  string         `db:"first_name"`
+	SecondName string         `db:"second_name"`
+	MiddleName sql.NullString `db:"middle_name"`
+	Nickname   sql.NullString `db:"nickname"`
+	Biography  sql.NullString `db:"biography"`
+	BirthYear  sql.NullInt64  `db:"birth_year"`
+	DeathYear  sql.NullInt64  `db:"death_year"`
+	Country    sql.NullString `db:"country"`
+}
+
+type sqlxAuthorAlias struct {
+	AuthorID uuid.UUID `db:"author_id"`
+	Alias    string    `db:"alias"`
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"strings"
+	"time"
 )
 
 type BookRepository struct {
@@ -27,16 +29,22 @@ func (repo *BookRepository) Store(book model.Book) error {
 			book (
 			      book_id,
 			      title,
+			      title_key,
 			      description,
 			      is_publish,
-			      cover_id
+			      cover_id,
+			      published_at,
+			      original_language
 			)
-		VALUES (?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 			title = VALUES(title),
+			title_key = VALUES(title_key),
 			description = VALUES(description),
 			is_publish = VALUES(is_publish),
-			cover_id = VALUES(cover_id)
+			cover_id = VALUES(cover_id),
+			published_at = VALUES(published_at),
+			original_language = VALUES(original_language)
 	`
 
 	binaryBookID, err := uuid.UUID(book.ID()).MarshalBinary()
@@ -56,19 +64,48 @@ func (repo *BookRepository) Store(book model.Book) error {
 		coverID = nil
 	}
 
+	var publishedAt sql.NullTime
+	if value, ok := book.PublishedAt().Get(); ok {
+		publishedAt = sql.NullTime{Time: value, Valid: true}
+	}
+
 	_, err = repo.connection.Exec(query,
 		binaryBookID,
 		book.Title(),
+		model.NormalizeBookTitle(book.Title()),
 		book.Description(),
 		book.IsPublished(),
 		coverID,
+		publishedAt,
+		nullString(book.OriginalLanguage()),
 	)
 
 	return err
 }
 
 func (repo *BookRepository) Delete(bookID model.BookID) error {
-	const query = `DELETE FROM book WHERE book_id = ?`
+	const query = `UPDATE book SET deleted_at = ? WHERE book_id = ? AND deleted_at IS NULL`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, time.Now(), binaryBookID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookNotFound
+	}
+
+	return err
+}
+
+func (repo *BookRepository) Restore(bookID model.BookID) error {
+	const query = `UPDATE book SET deleted_at = NULL WHERE book_id = ? AND deleted_at IS NOT NULL`
 
 	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
 	if err != nil {
@@ -88,14 +125,43 @@ func (repo *BookRepository) Delete(bookID model.BookID) error {
 	return err
 }
 
+// PurgeDeleted окончательно удаляет книги, вместе с оценками и комментариями, у которых нет каскадного удаления
+func (repo *BookRepository) PurgeDeleted(deletedBefore time.Time) (err error) {
+	tx, err := repo.connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	queries := []string{
+		`DELETE br FROM book_rating br INNER JOIN book b ON br.book_id = b.book_id WHERE b.deleted_at < ?`,
+		`DELETE bc FROM book_comment bc INNER JOIN book b ON bc.book_id = b.book_id WHERE b.deleted_at < ?`,
+		`DELETE FROM book WHERE deleted_at < ?`,
+	}
+	for _, query := range queries {
+		_, err = tx.Exec(query, deletedBefore)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (repo *BookRepository) FindByID(bookID model.BookID) (model.Book, error) {
 	const query = `
 		SELECT
 			description,
 			title,
-			is_publish
+			is_publish,
+			published_at,
+			original_language
 		FROM book
-		WHERE book_id = ?
+		WHERE book_id = ? AND deleted_at IS NULL
 `
 
 	var book sqlxBook
@@ -112,17 +178,233 @@ func (repo *BookRepository) FindByID(bookID model.BookID) (model.Book, error) {
 		return model.Book{}, err
 	}
 
+	publishedAt := maybe.Nothing[time.Time]()
+	if book.PublishedAt.Valid {
+		publishedAt = maybe.Just(book.PublishedAt.Time)
+	}
+
 	return model.NewBook(
 		bookID,
 		maybe.Nothing[model.ImageID](),
 		book.Title,
 		book.Description,
 		book.IsPublished,
+		publishedAt,
+		maybeString(book.OriginalLanguage),
 	), nil
 }
 
+func (repo *BookRepository) StoreLocalization(localization model.BookLocalization) error {
+	const query = `
+		INSERT INTO
+			book_localization (
+			      book_id,
+			      language,
+			      title,
+			      description
+			)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			title = VALUES(title),
+			description = VALUES(description)
+	`
+
+	binaryBookID, err := uuid.UUID(localization.BookID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryBookID,
+		localization.Language(),
+		localization.Title(),
+		localization.Description(),
+	)
+
+	return err
+}
+
+func (repo *BookRepository) DeleteLocalization(bookID model.BookID, language string) error {
+	const query = `DELETE FROM book_localization WHERE book_id = ? AND language = ?`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryBookID, language)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookLocalizationNotFound
+	}
+
+	return err
+}
+
+// bookDuplicateCandidatesLimit - сколько книг, отобранных по словам названия и общим авторам, сравнивается подробно
+const bookDuplicateCandidatesLimit = 200
+
+// FindFingerprint загружает название и авторов неудалённой книги
+func (repo *BookRepository) FindFingerprint(bookID model.BookID) (model.BookFingerprint, error) {
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return model.BookFingerprint{}, err
+	}
+
+	var book sqlxBookTitle
+	err = repo.connection.Get(&book, `SELECT book_id, title FROM book WHERE book_id = ? AND deleted_at IS NULL`, binaryBookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.BookFingerprint{}, model.ErrBookNotFound
+	}
+	if err != nil {
+		return model.BookFingerprint{}, err
+	}
+
+	fingerprints, err := repo.loadFingerprints([]sqlxBookTitle{book})
+	if err != nil {
+		return model.BookFingerprint{}, err
+	}
+
+	return fingerprints[0], nil
+}
+
+// FindDuplicateCandidates отбирает книги, среди которых может быть дубликат: со словами названия,
+// начинающимися так же, как слова названия книги, или с общими авторами. Неопубликованные книги видны
+// только переводчику, который отправлял их на проверку
+func (repo *BookRepository) FindDuplicateCandidates(
+	book model.BookFingerprint,
+	viewerID maybe.Maybe[model.UserID],
+) ([]model.BookFingerprint, error) {
+	binaryBookID, err := uuid.UUID(book.BookID()).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	titleKey := model.NormalizeBookTitle(book.Title())
+	words := strings.Fields(titleKey)
+	for i, word := range words {
+		words[i] = word + "*"
+	}
+	titleSearch := strings.Join(words, " ")
+
+	query := `
+		SELECT b.book_id, b.title
+		FROM book b
+		WHERE b.deleted_at IS NULL AND b.book_id <> ?
+	`
+	args := []interface{}{binaryBookID}
+
+	if value, ok := viewerID.Get(); ok {
+		binaryViewerID, err2 := uuid.UUID(value).MarshalBinary()
+		if err2 != nil {
+			return nil, err2
+		}
+		query += ` AND (b.is_publish = 1 OR EXISTS (
+			SELECT 1 FROM verify_book_request v WHERE v.book_id = b.book_id AND v.translator_id = ?
+		))`
+		args = append(args, binaryViewerID)
+	} else {
+		query += ` AND b.is_publish = 1`
+	}
+
+	// Полнотекстовый поиск пропускает слова короче innodb_ft_min_token_size, такие названия ищутся целиком
+	candidateConditions := []string{`MATCH(b.title_key) AGAINST(? IN BOOLEAN MODE)`, `b.title_key = ?`}
+	args = append(args, titleSearch, titleKey)
+
+	if len(book.AuthorIDs()) > 0 {
+		binaryAuthorIDs := make([][]byte, len(book.AuthorIDs()))
+		for i, authorID := range book.AuthorIDs() {
+			binaryAuthorID, err2 := uuid.UUID(authorID).MarshalBinary()
+			if err2 != nil {
+				return nil, err2
+			}
+			binaryAuthorIDs[i] = binaryAuthorID
+		}
+		condition, inArgs, err2 := sqlx.In(`b.book_id IN (SELECT ba.book_id FROM book_author ba WHERE ba.author_id IN (?))`, binaryAuthorIDs)
+		if err2 != nil {
+			return nil, err2
+		}
+		candidateConditions = append(candidateConditions, condition)
+		args = append(args, inArgs...)
+	}
+
+	query += ` AND (` + strings.Join(candidateConditions, " OR ") + `)
+		ORDER BY MATCH(b.title_key) AGAINST(? IN BOOLEAN MODE) DESC, b.book_id
+		LIMIT ?`
+	args = append(args, titleSearch, bookDuplicateCandidatesLimit)
+
+	var books []sqlxBookTitle
+	err = repo.connection.Select(&books, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.loadFingerprints(books)
+}
+
+func (repo *BookRepository) loadFingerprints(books []sqlxBookTitle) ([]model.BookFingerprint, error) {
+	if len(books) == 0 {
+		return nil, nil
+	}
+
+	binaryBookIDs := make([][]byte, len(books))
+	for i, book := range books {
+		binaryBookID, err := book.BookID.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		binaryBookIDs[i] = binaryBookID
+	}
+
+	authorsQuery, args, err := sqlx.In(`
+		SELECT
+			ba.book_id,
+			ba.author_id
+		FROM book_author ba
+		INNER JOIN author a ON a.author_id = ba.author_id
+		WHERE ba.book_id IN (?) AND a.deleted_at IS NULL
+	`, binaryBookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var bookAuthors []sqlxBookAuthorID
+	err = repo.connection.Select(&bookAuthors, authorsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	authorIDsByBookID := make(map[uuid.UUID][]model.AuthorID)
+	for _, bookAuthor := range bookAuthors {
+		authorIDsByBookID[bookAuthor.BookID] = append(authorIDsByBookID[bookAuthor.BookID], model.AuthorID(bookAuthor.AuthorID))
+	}
+
+	fingerprints := make([]model.BookFingerprint, len(books))
+	for i, book := range books {
+		fingerprints[i] = model.NewBookFingerprint(model.BookID(book.BookID), book.Title, authorIDsByBookID[book.BookID])
+	}
+
+	return fingerprints, nil
+}
+
+type sqlxBookTitle struct {
+	BookID uuid.UUID `db:"book_id"`
+	Title  string    `db:"title"`
+}
+
+type sqlxBookAuthorID struct {
+	BookID   uuid.UUID `db:"book_id"`
+	AuthorID uuid.UUID `db:"author_id"`
+}
+
 type sqlxBook struct {
-	Description string `db:"description"`
-	Title       string `db:"title"`
-	IsPublished bool   `db:"is_publish"`
+	Description      string         `db:"description"`
+	Title            string         `db:"title"`
+	IsPublished      bool           `db:"is_publish"`
+	PublishedAt      sql.NullTime   `db:"published_at"`
+	OriginalLanguage sql.NullString `db:"original_language"`
 }
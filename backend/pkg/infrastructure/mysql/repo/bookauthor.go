@@ -14,30 +14,55 @@ func NewBookAuthorRepository(connection *sqlx.DB) *bookAuthorRepository {
 	return &bookAuthorRepository{connection: connection}
 }
 
-func (repo *bookAuthorRepository) Store(bookAuthor model.BookAuthor) error {
-	const query = `
-		INSERT INTO book_author (
-			book_id,
-			author_id
-		)
-		VALUES (?, ?)
-		ON DUPLICATE KEY UPDATE
-			book_id = VALUES(book_id),
-			author_id = VALUES(author_id)
-	`
+const storeBookAuthorQuery = `
+	INSERT INTO book_author (
+		book_id,
+		author_id,
+		role,
+		position
+	)
+	VALUES (?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		role = VALUES(role),
+		position = VALUES(position)
+`
 
-	binaryBookID, err := uuid.UUID(bookAuthor.BookID()).MarshalBinary()
+func (repo *bookAuthorRepository) Store(bookAuthor model.BookAuthor) error {
+	args, err := bookAuthorArgs(bookAuthor)
 	if err != nil {
 		return err
 	}
 
-	binaryAuthorID, err := uuid.UUID(bookAuthor.Author()).MarshalBinary()
+	_, err = repo.connection.Exec(storeBookAuthorQuery, args...)
+	return err
+}
+
+// StoreAll сохраняет авторов книги в одной транзакции, чтобы порядок не оказался частично обновлённым
+func (repo *bookAuthorRepository) StoreAll(bookAuthors []model.BookAuthor) (err error) {
+	tx, err := repo.connection.Beginx()
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
 
-	_, err = repo.connection.Exec(query, binaryBookID, binaryAuthorID)
-	return err
+	for _, bookAuthor := range bookAuthors {
+		args, err2 := bookAuthorArgs(bookAuthor)
+		if err2 != nil {
+			err = err2
+			return err
+		}
+
+		_, err = tx.Exec(storeBookAuthorQuery, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (repo *bookAuthorRepository) Delete(bookID model.BookID, authorID model.AuthorID) error {
@@ -65,3 +90,58 @@ func (repo *bookAuthorRepository) Delete(bookID model.BookID, authorID model.Aut
 
 	return err
 }
+
+func (repo *bookAuthorRepository) FindByBookID(bookID model.BookID) ([]model.BookAuthor, error) {
+	const query = `
+		SELECT
+			author_id,
+			role,
+			position
+		FROM book_author
+		WHERE book_id = ?
+		ORDER BY position, author_id
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxBookAuthors []sqlxBookAuthor
+	err = repo.connection.Select(&sqlxBookAuthors, query, binaryBookID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookAuthors := make([]model.BookAuthor, len(sqlxBookAuthors))
+	for i, bookAuthor := range sqlxBookAuthors {
+		bookAuthors[i] = model.NewBookAuthor(
+			bookID,
+			model.AuthorID(bookAuthor.AuthorID),
+			model.BookAuthorRole(bookAuthor.Role),
+			bookAuthor.Position,
+		)
+	}
+
+	return bookAuthors, nil
+}
+
+func bookAuthorArgs(bookAuthor model.BookAuthor) ([]interface{}, error) {
+	binaryBookID, err := uuid.UUID(bookAuthor.BookID()).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	binaryAuthorID, err := uuid.UUID(bookAuthor.Author()).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{binaryBookID, binaryAuthorID, bookAuthor.Role(), bookAuthor.Position()}, nil
+}
+
+type sqlxBookAuthor struct {
+	AuthorID uuid.UUID `db:"author_id"`
+	Role     int       `db:"role"`
+	Position int       `db:"position"`
+}
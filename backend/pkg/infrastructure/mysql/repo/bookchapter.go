@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type BookChapterRepository struct {
@@ -56,7 +58,28 @@ func (repo *BookChapterRepository) Store(bookChapter model.BookChapter) error {
 }
 
 func (repo *BookChapterRepository) Delete(bookChapterID model.BookChapterID) error {
-	const query = `DELETE FROM book_chapter WHERE book_chapter_id = ?`
+	const query = `UPDATE book_chapter SET deleted_at = ? WHERE book_chapter_id = ? AND deleted_at IS NULL`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, time.Now(), binaryBookChapterID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookChapterNotFound
+	}
+
+	return err
+}
+
+func (repo *BookChapterRepository) Restore(bookChapterID model.BookChapterID) error {
+	const query = `UPDATE book_chapter SET deleted_at = NULL WHERE book_chapter_id = ? AND deleted_at IS NOT NULL`
 
 	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
 	if err != nil {
@@ -76,6 +99,14 @@ func (repo *BookChapterRepository) Delete(bookChapterID model.BookChapterID) err
 	return err
 }
 
+func (repo *BookChapterRepository) PurgeDeleted(deletedBefore time.Time) error {
+	const query = `DELETE FROM book_chapter WHERE deleted_at < ?`
+
+	_, err := repo.connection.Exec(query, deletedBefore)
+
+	return err
+}
+
 func (repo *BookChapterRepository) FindByID(bookChapterID model.BookChapterID) (model.BookChapter, error) {
 	const query = `
 		SELECT
@@ -83,7 +114,7 @@ func (repo *BookChapterRepository) FindByID(bookChapterID model.BookChapterID) (
 			chapter_index,
 			title
 		FROM book_chapter
-		WHERE book_chapter_id = ?
+		WHERE book_chapter_id = ? AND deleted_at IS NULL
 `
 
 	var bookChapter sqlxBookChapter
@@ -116,7 +147,7 @@ func (repo *BookChapterRepository) ListOrderIndexesByBookID(bookID model.BookID)
 			chapter_index,
 			title
 		FROM book_chapter
-		WHERE book_id = ?
+		WHERE book_id = ? AND deleted_at IS NULL
 		ORDER BY chapter_index ASC
 `
 
@@ -147,6 +178,40 @@ func (repo *BookChapterRepository) ListOrderIndexesByBookID(bookID model.BookID)
 	return bookChapters, nil
 }
 
+// StoreOriginalText хранит текст оригинала отдельно от Store, чтобы смена порядка глав не загружала тексты всех глав книги
+func (repo *BookChapterRepository) StoreOriginalText(bookChapterID model.BookChapterID, originalText maybe.Maybe[string]) error {
+	const query = `UPDATE book_chapter SET original_text = ? WHERE book_chapter_id = ? AND deleted_at IS NULL`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.connection.Exec(query, nullString(originalText), binaryBookChapterID)
+
+	return err
+}
+
+func (repo *BookChapterRepository) FindOriginalText(bookChapterID model.BookChapterID) (maybe.Maybe[string], error) {
+	const query = `SELECT original_text FROM book_chapter WHERE book_chapter_id = ? AND deleted_at IS NULL`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return maybe.Nothing[string](), err
+	}
+
+	var originalText sql.NullString
+	err = repo.connection.Get(&originalText, query, binaryBookChapterID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return maybe.Nothing[string](), model.ErrBookChapterNotFound
+	}
+	if err != nil {
+		return maybe.Nothing[string](), err
+	}
+
+	return maybeString(originalText), nil
+}
+
 type sqlxBookChapter struct {
 	BookChapterID uuid.UUID `db:"book_chapter_id"`
 	BookID        uuid.UUID `db:"book_id"`
@@ -1,9 +1,12 @@
 package repo
 
 import (
+	"database/sql"
+	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type BookChapterTranslationRepository struct {
@@ -20,11 +23,16 @@ func (repo *BookChapterTranslationRepository) Store(bookChapterTranslation model
 			book_chapter_translation (
 			      book_chapter_id,
 			      translator_id,
-			      text
+			      language,
+			      text,
+			      html,
+			      word_count
 			)
-		VALUES (?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
-			text = VALUES(text)
+			text = VALUES(text),
+			html = VALUES(html),
+			word_count = VALUES(word_count)
 	`
 
 	binaryBookChapterID, err := uuid.UUID(bookChapterTranslation.BookChapterID()).MarshalBinary()
@@ -39,8 +47,316 @@ func (repo *BookChapterTranslationRepository) Store(bookChapterTranslation model
 	_, err = repo.connection.Exec(query,
 		binaryBookChapterID,
 		binaryTranslatorID,
+		bookChapterTranslation.Language(),
 		bookChapterTranslation.Text(),
+		bookChapterTranslation.HTML(),
+		bookChapterTranslation.WordCount(),
 	)
 
 	return err
 }
+
+func (repo *BookChapterTranslationRepository) Find(
+	bookChapterID model.BookChapterID,
+	translatorID model.UserID,
+	language string,
+) (model.BookChapterTranslation, error) {
+	const query = `
+		SELECT bct.text
+		FROM book_chapter_translation bct
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		WHERE bct.book_chapter_id = ? AND bct.translator_id = ? AND bct.language = ? AND bc.deleted_at IS NULL
+	`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return model.BookChapterTranslation{}, err
+	}
+	binaryTranslatorID, err := uuid.UUID(translatorID).MarshalBinary()
+	if err != nil {
+		return model.BookChapterTranslation{}, err
+	}
+
+	var text string
+	err = repo.connection.Get(&text, query, binaryBookChapterID, binaryTranslatorID, language)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.BookChapterTranslation{}, model.ErrBookChapterTranslationNotFound
+	}
+	if err != nil {
+		return model.BookChapterTranslation{}, err
+	}
+
+	return model.NewBookChapterTranslation(bookChapterID, translatorID, language, text), nil
+}
+
+func (repo *BookChapterTranslationRepository) StoreVote(vote model.BookChapterTranslationVote) error {
+	const query = `
+		INSERT INTO
+			book_chapter_translation_vote (
+			      book_chapter_id,
+			      translator_id,
+			      language,
+			      user_id,
+			      value
+			)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			value = VALUES(value)
+	`
+
+	binaryBookChapterID, err := uuid.UUID(vote.BookChapterID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryTranslatorID, err := uuid.UUID(vote.TranslatorID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryUserID, err := uuid.UUID(vote.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryBookChapterID,
+		binaryTranslatorID,
+		vote.Language(),
+		binaryUserID,
+		vote.Value(),
+	)
+
+	return err
+}
+
+func (repo *BookChapterTranslationRepository) DeleteVote(
+	bookChapterID model.BookChapterID,
+	translatorID model.UserID,
+	language string,
+	userID model.UserID,
+) error {
+	const query = `
+		DELETE FROM book_chapter_translation_vote
+		WHERE book_chapter_id = ? AND translator_id = ? AND language = ? AND user_id = ?
+	`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryTranslatorID, err := uuid.UUID(translatorID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryBookChapterID, binaryTranslatorID, language, binaryUserID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookChapterTranslationVoteNotFound
+	}
+
+	return err
+}
+
+func (repo *BookChapterTranslationRepository) NextAnnotationID() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+func (repo *BookChapterTranslationRepository) StoreAnnotation(annotation model.BookChapterTranslationAnnotation) error {
+	const query = `
+		INSERT INTO
+			book_chapter_translation_annotation (
+			      annotation_id,
+			      book_chapter_id,
+			      translator_id,
+			      language,
+			      paragraph_index,
+			      char_offset,
+			      anchor_text,
+			      anchor_prefix,
+			      text,
+			      is_detached,
+			      created_at
+			)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			paragraph_index = VALUES(paragraph_index),
+			char_offset = VALUES(char_offset),
+			anchor_text = VALUES(anchor_text),
+			anchor_prefix = VALUES(anchor_prefix),
+			text = VALUES(text),
+			is_detached = VALUES(is_detached)
+	`
+
+	binaryAnnotationID, err := uuid.UUID(annotation.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryBookChapterID, err := uuid.UUID(annotation.BookChapterID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryTranslatorID, err := uuid.UUID(annotation.TranslatorID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	anchor := annotation.Anchor()
+	_, err = repo.connection.Exec(query,
+		binaryAnnotationID,
+		binaryBookChapterID,
+		binaryTranslatorID,
+		annotation.Language(),
+		anchor.ParagraphIndex,
+		anchor.Offset,
+		anchor.Text,
+		anchor.Prefix,
+		annotation.Text(),
+		annotation.Detached(),
+		annotation.CreatedAt(),
+	)
+
+	return err
+}
+
+func (repo *BookChapterTranslationRepository) FindAnnotation(
+	annotationID model.BookChapterTranslationAnnotationID,
+) (model.BookChapterTranslationAnnotation, error) {
+	const query = `
+		SELECT
+			annotation_id,
+			book_chapter_id,
+			translator_id,
+			language,
+			paragraph_index,
+			char_offset,
+			anchor_text,
+			anchor_prefix,
+			text,
+			is_detached,
+			created_at
+		FROM book_chapter_translation_annotation
+		WHERE annotation_id = ?
+	`
+
+	binaryAnnotationID, err := uuid.UUID(annotationID).MarshalBinary()
+	if err != nil {
+		return model.BookChapterTranslationAnnotation{}, err
+	}
+
+	var annotation sqlxBookChapterTranslationAnnotation
+	err = repo.connection.Get(&annotation, query, binaryAnnotationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.BookChapterTranslationAnnotation{}, model.ErrBookChapterTranslationAnnotationNotFound
+	}
+	if err != nil {
+		return model.BookChapterTranslationAnnotation{}, err
+	}
+
+	return convertSqlxBookChapterTranslationAnnotation(annotation), nil
+}
+
+func (repo *BookChapterTranslationRepository) ListAnnotations(
+	bookChapterID model.BookChapterID,
+	translatorID model.UserID,
+	language string,
+) ([]model.BookChapterTranslationAnnotation, error) {
+	const query = `
+		SELECT
+			annotation_id,
+			book_chapter_id,
+			translator_id,
+			language,
+			paragraph_index,
+			char_offset,
+			anchor_text,
+			anchor_prefix,
+			text,
+			is_detached,
+			created_at
+		FROM book_chapter_translation_annotation
+		WHERE book_chapter_id = ? AND translator_id = ? AND language = ?
+	`
+
+	binaryBookChapterID, err := uuid.UUID(bookChapterID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	binaryTranslatorID, err := uuid.UUID(translatorID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxAnnotations []sqlxBookChapterTranslationAnnotation
+	err = repo.connection.Select(&sqlxAnnotations, query, binaryBookChapterID, binaryTranslatorID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make([]model.BookChapterTranslationAnnotation, len(sqlxAnnotations))
+	for i, annotation := range sqlxAnnotations {
+		annotations[i] = convertSqlxBookChapterTranslationAnnotation(annotation)
+	}
+
+	return annotations, nil
+}
+
+func (repo *BookChapterTranslationRepository) DeleteAnnotation(annotationID model.BookChapterTranslationAnnotationID) error {
+	const query = `DELETE FROM book_chapter_translation_annotation WHERE annotation_id = ?`
+
+	binaryAnnotationID, err := uuid.UUID(annotationID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryAnnotationID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookChapterTranslationAnnotationNotFound
+	}
+
+	return err
+}
+
+func convertSqlxBookChapterTranslationAnnotation(annotation sqlxBookChapterTranslationAnnotation) model.BookChapterTranslationAnnotation {
+	return model.NewBookChapterTranslationAnnotation(
+		annotation.AnnotationID,
+		model.BookChapterID(annotation.BookChapterID),
+		model.UserID(annotation.TranslatorID),
+		annotation.Language,
+		model.TranslationAnnotationAnchor{
+			ParagraphIndex: annotation.ParagraphIndex,
+			Offset:         annotation.CharOffset,
+			Text:           annotation.AnchorText,
+			Prefix:         annotation.AnchorPrefix,
+		},
+		annotation.Text,
+		annotation.IsDetached,
+		annotation.CreatedAt,
+	)
+}
+
+type sqlxBookChapterTranslationAnnotation struct {
+	AnnotationID   uuid.UUID `db:"annotation_id"`
+	BookChapterID  uuid.UUID `db:"book_chapter_id"`
+	TranslatorID   uuid.UUID `db:"translator_id"`
+	Language       string    `db:"language"`
+	ParagraphIndex int       `db:"paragraph_index"`
+	CharOffset     int       `db:"char_offset"`
+	AnchorText     string    `db:"anchor_text"`
+	AnchorPrefix   string    `db:"anchor_prefix"`
+	Text           string    `db:"text"`
+	IsDetached     bool      `db:"is_detached"`
+	CreatedAt      time.Time `db:"created_at"`
+}
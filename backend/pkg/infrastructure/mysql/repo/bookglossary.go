@@ -0,0 +1,230 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type bookGlossaryRepository struct {
+	connection *sqlx.DB
+}
+
+func NewBookGlossaryRepository(connection *sqlx.DB) *bookGlossaryRepository {
+	return &bookGlossaryRepository{connection: connection}
+}
+
+func (repo *bookGlossaryRepository) NextID() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+// Store не использует ON DUPLICATE KEY UPDATE: при совпадении уникального индекса области термина
+// такой запрос молча изменил бы другой термин, а нужна ошибка ErrGlossaryTermExists
+func (repo *bookGlossaryRepository) Store(term model.BookGlossaryTerm) (err error) {
+	const existsQuery = `SELECT COUNT(*) FROM book_glossary_term WHERE glossary_term_id = ? FOR UPDATE`
+	const insertQuery = `
+		INSERT INTO
+			book_glossary_term (
+				glossary_term_id,
+				book_id,
+				language,
+				source_term,
+				target_term,
+				notes,
+				author_id,
+				is_shared,
+				created_at
+			)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	const updateQuery = `
+		UPDATE book_glossary_term
+		SET
+			source_term = ?,
+			target_term = ?,
+			notes = ?,
+			is_shared = ?
+		WHERE glossary_term_id = ?
+	`
+
+	binaryTermID, err := uuid.UUID(term.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	binaryBookID, err := uuid.UUID(term.BookID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	binaryAuthorID, err := uuid.UUID(term.AuthorID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	tx, err := repo.connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var count int
+	err = tx.Get(&count, existsQuery, binaryTermID)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		_, err = tx.Exec(insertQuery,
+			binaryTermID,
+			binaryBookID,
+			term.Language(),
+			term.SourceTerm(),
+			term.TargetTerm(),
+			nullString(term.Notes()),
+			binaryAuthorID,
+			term.IsShared(),
+			term.CreatedAt(),
+		)
+	} else {
+		_, err = tx.Exec(updateQuery,
+			term.SourceTerm(),
+			term.TargetTerm(),
+			nullString(term.Notes()),
+			term.IsShared(),
+			binaryTermID,
+		)
+	}
+	if isDuplicateEntry(err) {
+		err = model.ErrGlossaryTermExists
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *bookGlossaryRepository) FindByID(termID model.BookGlossaryTermID) (model.BookGlossaryTerm, error) {
+	const query = `
+		SELECT
+			glossary_term_id,
+			book_id,
+			language,
+			source_term,
+			target_term,
+			notes,
+			author_id,
+			is_shared,
+			created_at
+		FROM book_glossary_term
+		WHERE glossary_term_id = ?
+	`
+
+	binaryTermID, err := uuid.UUID(termID).MarshalBinary()
+	if err != nil {
+		return model.BookGlossaryTerm{}, err
+	}
+
+	var term sqlxBookGlossaryTerm
+	err = repo.connection.Get(&term, query, binaryTermID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.BookGlossaryTerm{}, model.ErrBookGlossaryTermNotFound
+	}
+	if err != nil {
+		return model.BookGlossaryTerm{}, err
+	}
+
+	return convertSqlxBookGlossaryTerm(term), nil
+}
+
+// ListByBookID возвращает все термины книги на языке перевода, и общие, и личные термины всех переводчиков
+func (repo *bookGlossaryRepository) ListByBookID(bookID model.BookID, language string) ([]model.BookGlossaryTerm, error) {
+	const query = `
+		SELECT
+			glossary_term_id,
+			book_id,
+			language,
+			source_term,
+			target_term,
+			notes,
+			author_id,
+			is_shared,
+			created_at
+		FROM book_glossary_term
+		WHERE book_id = ? AND language = ?
+		ORDER BY source_term, created_at
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxTerms []sqlxBookGlossaryTerm
+	err = repo.connection.Select(&sqlxTerms, query, binaryBookID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]model.BookGlossaryTerm, len(sqlxTerms))
+	for i, term := range sqlxTerms {
+		terms[i] = convertSqlxBookGlossaryTerm(term)
+	}
+
+	return terms, nil
+}
+
+func (repo *bookGlossaryRepository) Delete(termID model.BookGlossaryTermID) error {
+	const query = `DELETE FROM book_glossary_term WHERE glossary_term_id = ?`
+
+	binaryTermID, err := uuid.UUID(termID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryTermID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookGlossaryTermNotFound
+	}
+
+	return err
+}
+
+type sqlxBookGlossaryTerm struct {
+	GlossaryTermID uuid.UUID      `db:"glossary_term_id"`
+	BookID         uuid.UUID      `db:"book_id"`
+	Language       string         `db:"language"`
+	SourceTerm     string         `db:"source_term"`
+	TargetTerm     string         `db:"target_term"`
+	Notes          sql.NullString `db:"notes"`
+	AuthorID       uuid.UUID      `db:"author_id"`
+	IsShared       bool           `db:"is_shared"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func convertSqlxBookGlossaryTerm(term sqlxBookGlossaryTerm) model.BookGlossaryTerm {
+	return model.NewBookGlossaryTerm(
+		term.GlossaryTermID,
+		term.BookID,
+		term.Language,
+		term.SourceTerm,
+		term.TargetTerm,
+		maybeString(term.Notes),
+		model.UserID(term.AuthorID),
+		term.IsShared,
+		term.CreatedAt,
+	)
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
+	"time"
 )
 
 type GenreRepository struct {
@@ -25,10 +27,12 @@ func (repo *GenreRepository) Store(genre model.Genre) error {
 		INSERT INTO
 			genre (
 				genre_id,
+				parent_id,
 				name
 			)
-		VALUES (?, ?)
+		VALUES (?, ?, ?)
 		ON DUPLICATE KEY UPDATE
+			parent_id = VALUES(parent_id),
 			name = VALUES(name)
 	`
 
@@ -37,8 +41,17 @@ func (repo *GenreRepository) Store(genre model.Genre) error {
 		return err
 	}
 
+	var binaryParentID []byte
+	if parentID, ok := genre.ParentID().Get(); ok {
+		binaryParentID, err = uuid.UUID(parentID).MarshalBinary()
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = repo.connection.Exec(query,
 		binaryGenreID,
+		binaryParentID,
 		genre.Name(),
 	)
 
@@ -46,7 +59,28 @@ func (repo *GenreRepository) Store(genre model.Genre) error {
 }
 
 func (repo *GenreRepository) Delete(genreID model.GenreID) error {
-	const query = `DELETE FROM genre WHERE genre_id = ?`
+	const query = `UPDATE genre SET deleted_at = ? WHERE genre_id = ? AND deleted_at IS NULL`
+
+	binaryGenreID, err := uuid.UUID(genreID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, time.Now(), binaryGenreID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrGenreNotFound
+	}
+
+	return err
+}
+
+func (repo *GenreRepository) Restore(genreID model.GenreID) error {
+	const query = `UPDATE genre SET deleted_at = NULL WHERE genre_id = ? AND deleted_at IS NOT NULL`
 
 	binaryGenreID, err := uuid.UUID(genreID).MarshalBinary()
 	if err != nil {
@@ -66,21 +100,30 @@ func (repo *GenreRepository) Delete(genreID model.GenreID) error {
 	return err
 }
 
+func (repo *GenreRepository) PurgeDeleted(deletedBefore time.Time) error {
+	const query = `DELETE FROM genre WHERE deleted_at < ?`
+
+	_, err := repo.connection.Exec(query, deletedBefore)
+
+	return err
+}
+
 func (repo *GenreRepository) FindByID(genreID model.GenreID) (model.Genre, error) {
 	const query = `
 		SELECT
+			parent_id,
 			name
 		FROM genre
-		WHERE genre_id = ?
+		WHERE genre_id = ? AND deleted_at IS NULL
 	`
 
-	var name string
+	var genre sqlxGenre
 	binaryGenreID, err := uuid.UUID(genreID).MarshalBinary()
 	if err != nil {
 		return model.Genre{}, err
 	}
 
-	err = repo.connection.Get(&name, query, binaryGenreID)
+	err = repo.connection.Get(&genre, query, binaryGenreID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return model.Genre{}, model.ErrGenreNotFound
 	}
@@ -88,8 +131,19 @@ func (repo *GenreRepository) FindByID(genreID model.GenreID) (model.Genre, error
 		return model.Genre{}, err
 	}
 
+	parentID := maybe.Nothing[model.GenreID]()
+	if genre.ParentID.Valid {
+		parentID = maybe.Just(genre.ParentID.UUID)
+	}
+
 	return model.NewGenre(
 		genreID,
-		name,
+		parentID,
+		genre.Name,
 	), nil
 }
+
+type sqlxGenre struct {
+	ParentID uuid.NullUUID `db:"parent_id"`
+	Name     string        `db:"name"`
+}
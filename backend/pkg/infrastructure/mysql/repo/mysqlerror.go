@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry - код ошибки MySQL при нарушении уникального индекса
+const mysqlErrDuplicateEntry = 1062
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
 	"server/pkg/domain/model"
 )
 
@@ -50,3 +51,99 @@ func (repo *readingSessionRepository) Store(readingSession model.ReadingSession)
 
 	return err
 }
+
+func (repo *readingSessionRepository) StorePreferredTranslator(preferredTranslator model.PreferredTranslator) error {
+	const query = `
+		INSERT INTO
+			preferred_translator (
+			      user_id,
+			      book_id,
+			      translator_id,
+			      language
+			)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			translator_id = VALUES(translator_id),
+			language = VALUES(language)
+	`
+
+	binaryUserID, err := uuid.UUID(preferredTranslator.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryBookID, err := uuid.UUID(preferredTranslator.BookID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryTranslatorID, err := uuid.UUID(preferredTranslator.TranslatorID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryUserID,
+		binaryBookID,
+		binaryTranslatorID,
+		nullString(preferredTranslator.Language()),
+	)
+
+	return err
+}
+
+func (repo *readingSessionRepository) DeletePreferredTranslator(bookID model.BookID, userID model.UserID) error {
+	const query = `DELETE FROM preferred_translator WHERE book_id = ? AND user_id = ?`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryBookID, binaryUserID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrPreferredTranslatorNotFound
+	}
+
+	return err
+}
+
+func (repo *readingSessionRepository) CountTranslatedChapters(
+	bookID model.BookID,
+	translatorID model.UserID,
+	language maybe.Maybe[string],
+) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT bct.book_chapter_id)
+		FROM book_chapter_translation bct
+		INNER JOIN book_chapter bc ON bct.book_chapter_id = bc.book_chapter_id
+		WHERE bc.book_id = ? AND bct.translator_id = ? AND bc.deleted_at IS NULL
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	binaryTranslatorID, err := uuid.UUID(translatorID).MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	args := []interface{}{binaryBookID, binaryTranslatorID}
+	if value, ok := language.Get(); ok {
+		query += ` AND bct.language = ?`
+		args = append(args, value)
+	}
+
+	var count int
+	err = repo.connection.Get(&count, query, args...)
+
+	return count, err
+}
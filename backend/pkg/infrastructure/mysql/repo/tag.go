@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type tagRepository struct {
+	connection *sqlx.DB
+}
+
+func NewTagRepository(connection *sqlx.DB) *tagRepository {
+	return &tagRepository{connection: connection}
+}
+
+func (repo *tagRepository) NextID() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+func (repo *tagRepository) Store(tag model.Tag) error {
+	const query = `
+		INSERT INTO
+			tag (
+				tag_id,
+				name,
+				status,
+				suggested_by,
+				created_at
+			)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status)
+	`
+
+	binaryTagID, err := uuid.UUID(tag.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var binarySuggestedBy []byte
+	if suggestedBy, ok := tag.SuggestedBy().Get(); ok {
+		binarySuggestedBy, err = uuid.UUID(suggestedBy).MarshalBinary()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryTagID,
+		tag.Name(),
+		tag.Status(),
+		binarySuggestedBy,
+		tag.CreatedAt(),
+	)
+
+	return err
+}
+
+func (repo *tagRepository) FindByID(tagID model.TagID) (model.Tag, error) {
+	const query = `
+		SELECT
+			tag_id,
+			name,
+			status,
+			suggested_by,
+			created_at
+		FROM tag
+		WHERE tag_id = ?
+	`
+
+	binaryTagID, err := uuid.UUID(tagID).MarshalBinary()
+	if err != nil {
+		return model.Tag{}, err
+	}
+
+	return repo.find(query, binaryTagID)
+}
+
+func (repo *tagRepository) FindByName(name string) (model.Tag, error) {
+	const query = `
+		SELECT
+			tag_id,
+			name,
+			status,
+			suggested_by,
+			created_at
+		FROM tag
+		WHERE name = ?
+	`
+
+	return repo.find(query, name)
+}
+
+func (repo *tagRepository) find(query string, args ...interface{}) (model.Tag, error) {
+	var tag sqlxTag
+	err := repo.connection.Get(&tag, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Tag{}, model.ErrTagNotFound
+	}
+	if err != nil {
+		return model.Tag{}, err
+	}
+
+	suggestedBy := maybe.Nothing[model.UserID]()
+	if tag.SuggestedBy.Valid {
+		suggestedBy = maybe.Just(model.UserID(tag.SuggestedBy.UUID))
+	}
+
+	return model.NewTag(
+		tag.TagID,
+		tag.Name,
+		model.TagStatus(tag.Status),
+		suggestedBy,
+		tag.CreatedAt,
+	), nil
+}
+
+func (repo *tagRepository) StoreBookTag(bookTag model.BookTag) error {
+	const query = `
+		INSERT IGNORE INTO book_tag (
+			book_id,
+			tag_id,
+			user_id
+		)
+		VALUES (?, ?, ?)
+	`
+
+	binaryBookID, err := uuid.UUID(bookTag.BookID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	binaryTagID, err := uuid.UUID(bookTag.TagID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	binaryUserID, err := uuid.UUID(bookTag.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.connection.Exec(query, binaryBookID, binaryTagID, binaryUserID)
+	return err
+}
+
+func (repo *tagRepository) DeleteBookTag(bookTag model.BookTag) error {
+	const query = `DELETE FROM book_tag WHERE book_id = ? AND tag_id = ? AND user_id = ?`
+
+	binaryBookID, err := uuid.UUID(bookTag.BookID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	binaryTagID, err := uuid.UUID(bookTag.TagID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	binaryUserID, err := uuid.UUID(bookTag.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryBookID, binaryTagID, binaryUserID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrBookTagNotFound
+	}
+
+	return err
+}
+
+type sqlxTag struct {
+	TagID       uuid.UUID     `db:"tag_id"`
+	Name        string        `db:"name"`
+	Status      int           `db:"status"`
+	SuggestedBy uuid.NullUUID `db:"suggested_by"`
+	CreatedAt   time.Time     `db:"created_at"`
+}
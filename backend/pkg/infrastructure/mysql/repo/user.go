@@ -1,7 +1,9 @@
 package repo
 
 import (
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
@@ -70,27 +72,78 @@ func (repo *UserRepository) Store(user model.User) error {
 	return err
 }
 
-func (repo *UserRepository) Delete(userID model.UserID) error {
-	const query = `DELETE FROM user WHERE user_id = ?`
-
+// Delete удаляет персональные данные пользователя (оценки, сессии чтения, полки, личные термины глоссария, аватар),
+// а переводы, комментарии, общие термины глоссария и заявки на проверку переназначает на анонимного пользователя,
+// созданного для этого аккаунта. У каждого удалённого аккаунта свой анонимный пользователь, поэтому его переводы
+// не пересекаются по главе и языку с переводами других удалённых аккаунтов. Всё выполняется в одной транзакции
+func (repo *UserRepository) Delete(userID model.UserID) (err error) {
 	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
 	if err != nil {
 		return err
 	}
+	tombstoneID := repo.NextID()
+	binaryTombstoneID, err := tombstoneID.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	tombstonePassword, err := randomUserPassword()
+	if err != nil {
+		return err
+	}
 
-	result, err := repo.connection.Exec(query, binaryUserID)
+	tx, err := repo.connection.Beginx()
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
 
-	count, err := result.RowsAffected()
-	if count == 0 {
+	var avatarID []byte
+	err = tx.Get(&avatarID, `SELECT avatar_id FROM user WHERE user_id = ? AND role <> ? FOR UPDATE`, binaryUserID, model.Deleted)
+	if errors.Is(err, sql.ErrNoRows) {
 		return model.ErrUserNotFound
 	}
+	if err != nil {
+		return err
+	}
 
-	return err
+	statements := []userDeletionStatement{
+		// Вход под ролью Deleted запрещён, случайный пароль лишь не оставляет у записи пустого пароля
+		{`
+			INSERT INTO user (user_id, avatar_id, login, role, password, about_me)
+			VALUES (?, NULL, ?, ?, ?, 'Deleted account')
+		`, []interface{}{binaryTombstoneID, "deleted-" + hex.EncodeToString(binaryTombstoneID), model.Deleted, tombstonePassword}},
+		{`DELETE FROM book_rating WHERE user_id = ?`, []interface{}{binaryUserID}},
+		{`DELETE FROM reading_session WHERE user_id = ?`, []interface{}{binaryUserID}},
+		{`DELETE FROM preferred_translator WHERE user_id = ?`, []interface{}{binaryUserID}},
+		{`DELETE FROM user_book_favourites WHERE user_id = ?`, []interface{}{binaryUserID}},
+		{`UPDATE book_chapter_translation SET translator_id = ? WHERE translator_id = ?`, []interface{}{binaryTombstoneID, binaryUserID}},
+		{`UPDATE preferred_translator SET translator_id = ? WHERE translator_id = ?`, []interface{}{binaryTombstoneID, binaryUserID}},
+		{`UPDATE book_comment SET user_id = ? WHERE user_id = ?`, []interface{}{binaryTombstoneID, binaryUserID}},
+		{`DELETE FROM book_glossary_term WHERE author_id = ? AND is_shared = 0`, []interface{}{binaryUserID}},
+		{`UPDATE book_glossary_term SET author_id = ? WHERE author_id = ?`, []interface{}{binaryTombstoneID, binaryUserID}},
+		{`UPDATE verify_book_request SET translator_id = ? WHERE translator_id = ?`, []interface{}{binaryTombstoneID, binaryUserID}},
+		{`UPDATE user_restriction SET issued_by = ? WHERE issued_by = ?`, []interface{}{binaryTombstoneID, binaryUserID}},
+		{`DELETE FROM user WHERE user_id = ?`, []interface{}{binaryUserID}},
+	}
+	if avatarID != nil {
+		statements = append(statements, userDeletionStatement{`DELETE FROM image WHERE image_id = ?`, []interface{}{avatarID}})
+	}
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement.query, statement.args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
+// FindByID не находит анонимных пользователей удалённых аккаунтов, чтобы их нельзя было править, удалять и ограничивать
 func (repo *UserRepository) FindByID(userID model.UserID) (model.User, error) {
 	const query = `
 		SELECT
@@ -99,7 +152,7 @@ func (repo *UserRepository) FindByID(userID model.UserID) (model.User, error) {
 			password,
 			about_me
 		FROM user
-		WHERE user_id = ?
+		WHERE user_id = ? AND role <> ?
 `
 
 	var user sqlxUser
@@ -108,7 +161,7 @@ func (repo *UserRepository) FindByID(userID model.UserID) (model.User, error) {
 		return model.User{}, err
 	}
 
-	err = repo.connection.Get(&user, query, binaryUserID)
+	err = repo.connection.Get(&user, query, binaryUserID, model.Deleted)
 	if errors.Is(err, sql.ErrNoRows) {
 		return model.User{}, model.ErrUserNotFound
 	}
@@ -132,3 +185,19 @@ type sqlxUser struct {
 	Password string `db:"password"`
 	AboutMe  string `db:"about_me"`
 }
+
+type userDeletionStatement struct {
+	query string
+	args  []interface{}
+}
+
+// randomUserPassword возвращает 32 случайных байта в hex для пользователей, под которыми не входят
+func randomUserPassword() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type userDataExportRepository struct {
+	connection *sqlx.DB
+}
+
+func NewUserDataExportRepository(connection *sqlx.DB) *userDataExportRepository {
+	return &userDataExportRepository{connection: connection}
+}
+
+func (repo *userDataExportRepository) NextID() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+func (repo *userDataExportRepository) Store(userDataExport model.UserDataExport) error {
+	const query = `
+		INSERT INTO
+			user_data_export (
+			      user_data_export_id,
+			      user_id,
+			      status,
+			      created_at,
+			      completed_at
+			)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status),
+			completed_at = VALUES(completed_at)
+	`
+
+	binaryUserDataExportID, err := uuid.UUID(userDataExport.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryUserID, err := uuid.UUID(userDataExport.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var completedAt sql.NullTime
+	if value, ok := userDataExport.CompletedAt().Get(); ok {
+		completedAt = sql.NullTime{Time: value, Valid: true}
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryUserDataExportID,
+		binaryUserID,
+		userDataExport.Status(),
+		userDataExport.CreatedAt(),
+		completedAt,
+	)
+
+	return err
+}
+
+// StorePending добавляет выгрузку в очередь. Store здесь не подходит: ON DUPLICATE KEY UPDATE при второй
+// ожидающей выгрузке пользователя молча обновил бы первую, а нужна ошибка ErrUserDataExportPending
+func (repo *userDataExportRepository) StorePending(userDataExport model.UserDataExport) error {
+	const query = `
+		INSERT INTO
+			user_data_export (
+			      user_data_export_id,
+			      user_id,
+			      status,
+			      created_at
+			)
+		VALUES (?, ?, ?, ?)
+	`
+
+	binaryUserDataExportID, err := uuid.UUID(userDataExport.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryUserID, err := uuid.UUID(userDataExport.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryUserDataExportID,
+		binaryUserID,
+		model.UserDataExportPending,
+		userDataExport.CreatedAt(),
+	)
+	if isDuplicateEntry(err) {
+		return model.ErrUserDataExportPending
+	}
+
+	return err
+}
+
+// FailPending помечает неудачными все выгрузки в очереди
+func (repo *userDataExportRepository) FailPending(completedAt time.Time) error {
+	const query = `UPDATE user_data_export SET status = ?, completed_at = ? WHERE status = ?`
+
+	_, err := repo.connection.Exec(query, model.UserDataExportFailed, completedAt, model.UserDataExportPending)
+
+	return err
+}
+
+// PurgeCompleted удаляет готовые и неудачные выгрузки вместе с архивами
+func (repo *userDataExportRepository) PurgeCompleted(completedBefore time.Time) error {
+	const query = `DELETE FROM user_data_export WHERE completed_at < ?`
+
+	_, err := repo.connection.Exec(query, completedBefore)
+
+	return err
+}
+
+func (repo *userDataExportRepository) StoreArchive(userDataExportID model.UserDataExportID, archive []byte) error {
+	const query = `UPDATE user_data_export SET archive = ? WHERE user_data_export_id = ?`
+
+	binaryUserDataExportID, err := uuid.UUID(userDataExportID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, archive, binaryUserDataExportID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrUserDataExportNotFound
+	}
+
+	return err
+}
+
+func (repo *userDataExportRepository) FindByID(userDataExportID model.UserDataExportID) (model.UserDataExport, error) {
+	const query = `
+		SELECT
+			user_data_export_id,
+			user_id,
+			status,
+			created_at,
+			completed_at
+		FROM user_data_export
+		WHERE user_data_export_id = ?
+`
+
+	binaryUserDataExportID, err := uuid.UUID(userDataExportID).MarshalBinary()
+	if err != nil {
+		return model.UserDataExport{}, err
+	}
+
+	var userDataExport sqlxUserDataExport
+	err = repo.connection.Get(&userDataExport, query, binaryUserDataExportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UserDataExport{}, model.ErrUserDataExportNotFound
+	}
+	if err != nil {
+		return model.UserDataExport{}, err
+	}
+
+	return convertSqlxUserDataExport(userDataExport), nil
+}
+
+func (repo *userDataExportRepository) FindPendingByUserID(userID model.UserID) (model.UserDataExport, error) {
+	const query = `
+		SELECT
+			user_data_export_id,
+			user_id,
+			status,
+			created_at,
+			completed_at
+		FROM user_data_export
+		WHERE pending_user_id = ?
+`
+
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return model.UserDataExport{}, err
+	}
+
+	var userDataExport sqlxUserDataExport
+	err = repo.connection.Get(&userDataExport, query, binaryUserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UserDataExport{}, model.ErrUserDataExportNotFound
+	}
+	if err != nil {
+		return model.UserDataExport{}, err
+	}
+
+	return convertSqlxUserDataExport(userDataExport), nil
+}
+
+type sqlxUserDataExport struct {
+	UserDataExportID uuid.UUID    `db:"user_data_export_id"`
+	UserID           uuid.UUID    `db:"user_id"`
+	Status           int          `db:"status"`
+	CreatedAt        time.Time    `db:"created_at"`
+	CompletedAt      sql.NullTime `db:"completed_at"`
+}
+
+func convertSqlxUserDataExport(userDataExport sqlxUserDataExport) model.UserDataExport {
+	completedAt := maybe.Nothing[time.Time]()
+	if userDataExport.CompletedAt.Valid {
+		completedAt = maybe.Just(userDataExport.CompletedAt.Time)
+	}
+
+	return model.NewUserDataExport(
+		userDataExport.UserDataExportID,
+		model.UserID(userDataExport.UserID),
+		model.UserDataExportStatus(userDataExport.Status),
+		userDataExport.CreatedAt,
+		completedAt,
+	)
+}
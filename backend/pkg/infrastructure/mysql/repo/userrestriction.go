@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/mono83/maybe"
+	"server/pkg/domain/model"
+	"time"
+)
+
+type userRestrictionRepository struct {
+	connection *sqlx.DB
+}
+
+func NewUserRestrictionRepository(connection *sqlx.DB) *userRestrictionRepository {
+	return &userRestrictionRepository{connection: connection}
+}
+
+func (repo *userRestrictionRepository) NextID() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+func (repo *userRestrictionRepository) Store(userRestriction model.UserRestriction) error {
+	const query = `
+		INSERT INTO
+			user_restriction (
+			      user_restriction_id,
+			      user_id,
+			      issued_by,
+			      type,
+			      reason,
+			      created_at,
+			      expires_at
+			)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			type = VALUES(type),
+			reason = VALUES(reason),
+			expires_at = VALUES(expires_at)
+	`
+
+	binaryUserRestrictionID, err := uuid.UUID(userRestriction.ID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryUserID, err := uuid.UUID(userRestriction.UserID()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	binaryIssuedBy, err := uuid.UUID(userRestriction.IssuedBy()).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var expiresAt sql.NullTime
+	if value, ok := userRestriction.ExpiresAt().Get(); ok {
+		expiresAt = sql.NullTime{Time: value, Valid: true}
+	}
+
+	_, err = repo.connection.Exec(query,
+		binaryUserRestrictionID,
+		binaryUserID,
+		binaryIssuedBy,
+		userRestriction.Type(),
+		userRestriction.Reason(),
+		userRestriction.CreatedAt(),
+		expiresAt,
+	)
+
+	return err
+}
+
+func (repo *userRestrictionRepository) Delete(userRestrictionID model.UserRestrictionID) error {
+	const query = `DELETE FROM user_restriction WHERE user_restriction_id = ?`
+
+	binaryUserRestrictionID, err := uuid.UUID(userRestrictionID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.connection.Exec(query, binaryUserRestrictionID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if count == 0 {
+		return model.ErrUserRestrictionNotFound
+	}
+
+	return err
+}
+
+func (repo *userRestrictionRepository) FindByID(userRestrictionID model.UserRestrictionID) (model.UserRestriction, error) {
+	const query = `
+		SELECT
+			user_restriction_id,
+			user_id,
+			issued_by,
+			type,
+			reason,
+			created_at,
+			expires_at
+		FROM user_restriction
+		WHERE user_restriction_id = ?
+`
+
+	binaryUserRestrictionID, err := uuid.UUID(userRestrictionID).MarshalBinary()
+	if err != nil {
+		return model.UserRestriction{}, err
+	}
+
+	var userRestriction sqlxUserRestriction
+	err = repo.connection.Get(&userRestriction, query, binaryUserRestrictionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UserRestriction{}, model.ErrUserRestrictionNotFound
+	}
+	if err != nil {
+		return model.UserRestriction{}, err
+	}
+
+	return convertSqlxUserRestriction(userRestriction), nil
+}
+
+func (repo *userRestrictionRepository) ListByUserID(userID model.UserID) ([]model.UserRestriction, error) {
+	const query = `
+		SELECT
+			user_restriction_id,
+			user_id,
+			issued_by,
+			type,
+			reason,
+			created_at,
+			expires_at
+		FROM user_restriction
+		WHERE user_id = ?
+`
+
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlxUserRestrictions []sqlxUserRestriction
+	err = repo.connection.Select(&sqlxUserRestrictions, query, binaryUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRestrictions := make([]model.UserRestriction, len(sqlxUserRestrictions))
+	for i, r := range sqlxUserRestrictions {
+		userRestrictions[i] = convertSqlxUserRestriction(r)
+	}
+
+	return userRestrictions, nil
+}
+
+func convertSqlxUserRestriction(userRestriction sqlxUserRestriction) model.UserRestriction {
+	expiresAt := maybe.Nothing[time.Time]()
+	if userRestriction.ExpiresAt.Valid {
+		expiresAt = maybe.Just(userRestriction.ExpiresAt.Time)
+	}
+
+	return model.NewUserRestriction(
+		userRestriction.UserRestrictionID,
+		model.UserID(userRestriction.UserID),
+		model.UserID(userRestriction.IssuedBy),
+		model.UserRestrictionType(userRestriction.Type),
+		userRestriction.Reason,
+		userRestriction.CreatedAt,
+		expiresAt,
+	)
+}
+
+type sqlxUserRestriction struct {
+	UserRestrictionID uuid.UUID    `db:"user_restriction_id"`
+	UserID            uuid.UUID    `db:"user_id"`
+	IssuedBy          uuid.UUID    `db:"issued_by"`
+	Type              int          `db:"type"`
+	Reason            string       `db:"reason"`
+	CreatedAt         time.Time    `db:"created_at"`
+	ExpiresAt         sql.NullTime `db:"expires_at"`
+}
@@ -68,6 +68,64 @@ func (repo *verifyBookRequestRepository) Store(verifyBookRequest model.VerifyBoo
 	return err
 }
 
+// StoreDuplicates перезаписывает найденные для запроса похожие книги
+func (repo *verifyBookRequestRepository) StoreDuplicates(
+	verifyBookRequestID model.VerifyBookRequestID,
+	duplicates []model.BookDuplicate,
+) (err error) {
+	binaryVerifyBookRequestID, err := uuid.UUID(verifyBookRequestID).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	tx, err := repo.connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(`DELETE FROM verify_book_request_duplicate WHERE verify_book_request_id = ?`, binaryVerifyBookRequestID)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO
+			verify_book_request_duplicate (
+				verify_book_request_id,
+				book_id,
+				title_similarity,
+				author_overlap,
+				score
+			)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	for _, duplicate := range duplicates {
+		binaryBookID, err2 := uuid.UUID(duplicate.BookID()).MarshalBinary()
+		if err2 != nil {
+			err = err2
+			return err
+		}
+
+		_, err = tx.Exec(query,
+			binaryVerifyBookRequestID,
+			binaryBookID,
+			duplicate.TitleSimilarity(),
+			duplicate.AuthorOverlap(),
+			duplicate.Score(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (repo *verifyBookRequestRepository) Delete(verifyBookRequestID model.VerifyBookRequestID) error {
 	const query = `DELETE FROM verify_book_request WHERE verify_book_request_id = ?`
 
@@ -128,6 +186,31 @@ func (repo *verifyBookRequestRepository) FindByID(verifyBookRequestID model.Veri
 	), nil
 }
 
+// IsBookOwner - владельцем книги считается переводчик, чей запрос на проверку книги был одобрен
+func (repo *verifyBookRequestRepository) IsBookOwner(bookID model.BookID, userID model.UserID) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM verify_book_request
+			WHERE book_id = ? AND translator_id = ? AND is_verified = 1
+		)
+	`
+
+	binaryBookID, err := uuid.UUID(bookID).MarshalBinary()
+	if err != nil {
+		return false, err
+	}
+	binaryUserID, err := uuid.UUID(userID).MarshalBinary()
+	if err != nil {
+		return false, err
+	}
+
+	var isOwner bool
+	err = repo.connection.Get(&isOwner, query, binaryBookID, binaryUserID)
+
+	return isOwner, err
+}
+
 type sqlxVerifyBookRequest struct {
 	TranslatorID uuid.UUID    `db:"translator_id"`
 	BookID       uuid.UUID    `db:"book_id"`
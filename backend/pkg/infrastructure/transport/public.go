@@ -1,11 +1,13 @@
 package transport
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/mono83/maybe"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"log"
 	"math"
 	"net/http"
 	"server/api"
@@ -14,7 +16,11 @@ import (
 	"server/pkg/infrastructure/model"
 	"server/pkg/infrastructure/mysql/provider"
 	"server/pkg/infrastructure/mysql/query"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
@@ -22,6 +28,11 @@ import (
 
 var JwtKey = []byte("your_secret_key")
 
+const maxAuditUserAgentLength = 512
+
+// maxConcurrentUserDataExports - сколько архивов выгрузки данных собирается одновременно
+const maxConcurrentUserDataExports = 2
+
 type User struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
@@ -42,6 +53,11 @@ func NewPublicAPI(
 	bookAuthorService service.BookAuthorService,
 	genreService service.GenreService,
 	bookGenreService service.BookGenreService,
+	userRestrictionService service.UserRestrictionService,
+	userDataExportService service.UserDataExportService,
+	tagService service.TagService,
+	bookGlossaryService service.BookGlossaryService,
+	machineTranslationService service.MachineTranslationService,
 
 	userQueryService query.UserQueryService,
 	bookQueryService query.BookQueryService,
@@ -53,8 +69,17 @@ func NewPublicAPI(
 	userBookFavouritesQueryService query.UserBookFavouritesQueryService,
 	authorQueryService query.AuthorQueryService,
 	genreQueryService query.GenreQueryService,
+	userRestrictionQueryService query.UserRestrictionQueryService,
+	auditLogQueryService query.AuditLogQueryService,
+	userDataExportQueryService query.UserDataExportQueryService,
+	trashQueryService query.TrashQueryService,
+	bookCommentQueryService query.BookCommentQueryService,
+	tagQueryService query.TagQueryService,
+	translationStatsQueryService query.TranslationStatsQueryService,
+	bookGlossaryQueryService query.BookGlossaryQueryService,
 
 	verifyBookRequestProvider provider.VerifyBookRequestProvider,
+	userDataExportProvider provider.UserDataExportProvider,
 ) api.ServerInterface {
 	return &public{
 		userService:                   userService,
@@ -70,6 +95,11 @@ func NewPublicAPI(
 		bookAuthorService:             bookAuthorService,
 		genreService:                  genreService,
 		bookGenreService:              bookGenreService,
+		userRestrictionService:        userRestrictionService,
+		userDataExportService:         userDataExportService,
+		tagService:                    tagService,
+		bookGlossaryService:           bookGlossaryService,
+		machineTranslationService:     machineTranslationService,
 
 		userQueryService:                   userQueryService,
 		bookQueryService:                   bookQueryService,
@@ -81,8 +111,19 @@ func NewPublicAPI(
 		userBookFavouritesQueryService:     userBookFavouritesQueryService,
 		authorQueryService:                 authorQueryService,
 		genreQueryService:                  genreQueryService,
+		userRestrictionQueryService:        userRestrictionQueryService,
+		auditLogQueryService:               auditLogQueryService,
+		userDataExportQueryService:         userDataExportQueryService,
+		trashQueryService:                  trashQueryService,
+		bookCommentQueryService:            bookCommentQueryService,
+		tagQueryService:                    tagQueryService,
+		translationStatsQueryService:       translationStatsQueryService,
+		bookGlossaryQueryService:           bookGlossaryQueryService,
 
 		verifyBookRequestProvider: verifyBookRequestProvider,
+		userDataExportProvider:    userDataExportProvider,
+
+		userDataExportBuilds: make(chan struct{}, maxConcurrentUserDataExports),
 	}
 }
 
@@ -104,6 +145,11 @@ type public struct {
 	bookAuthorService             service.BookAuthorService
 	genreService                  service.GenreService
 	bookGenreService              service.BookGenreService
+	userRestrictionService        service.UserRestrictionService
+	userDataExportService         service.UserDataExportService
+	tagService                    service.TagService
+	bookGlossaryService           service.BookGlossaryService
+	machineTranslationService     service.MachineTranslationService
 
 	userQueryService                   query.UserQueryService
 	bookQueryService                   query.BookQueryService
@@ -115,8 +161,20 @@ type public struct {
 	userBookFavouritesQueryService     query.UserBookFavouritesQueryService
 	authorQueryService                 query.AuthorQueryService
 	genreQueryService                  query.GenreQueryService
+	userRestrictionQueryService        query.UserRestrictionQueryService
+	auditLogQueryService               query.AuditLogQueryService
+	userDataExportQueryService         query.UserDataExportQueryService
+	trashQueryService                  query.TrashQueryService
+	bookCommentQueryService            query.BookCommentQueryService
+	tagQueryService                    query.TagQueryService
+	translationStatsQueryService       query.TranslationStatsQueryService
+	bookGlossaryQueryService           query.BookGlossaryQueryService
 
 	verifyBookRequestProvider provider.VerifyBookRequestProvider
+	userDataExportProvider    provider.UserDataExportProvider
+
+	// userDataExportBuilds ограничивает число одновременно собираемых архивов выгрузки
+	userDataExportBuilds chan struct{}
 }
 
 func (p public) UpdateBookRating(ctx echo.Context, id string) error {
@@ -183,6 +241,14 @@ func (p public) LoginUser(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
 
+	err = p.userRestrictionService.CheckCanLogin(domainmodel.UserID(user.ID))
+	if errors.Is(err, domainmodel.ErrUserSuspended) {
+		return echo.NewHTTPError(http.StatusForbidden, "User suspended")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check user restrictions: %s", err))
+	}
+
 	accessToken, accessExpirationTime, err := createToken(user, 5*time.Hour)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Could not create access token")
@@ -223,6 +289,14 @@ func (p public) RefreshToken(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
 
+	err = p.userRestrictionService.CheckCanLogin(domainmodel.UserID(user.ID))
+	if errors.Is(err, domainmodel.ErrUserSuspended) {
+		return echo.NewHTTPError(http.StatusForbidden, "User suspended")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check user restrictions: %s", err))
+	}
+
 	accessToken, accessExpirationTime, err := createToken(user, 5*time.Hour)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Could not create access token")
@@ -236,7 +310,7 @@ func (p public) RefreshToken(ctx echo.Context) error {
 	})
 }
 
-func (p public) LogoutUser(ctx echo.Context) error {
+func (p public) Logout(ctx echo.Context) error {
 	deleteCookie(ctx, "access_token")
 	deleteCookie(ctx, "refresh_token")
 
@@ -283,6 +357,7 @@ func (p public) EditUser(ctx echo.Context) error {
 		AboutMe:  input.AboutMe,
 		Login:    input.Login,
 		Password: input.Password,
+		Metadata: extractAuditMetadataFromContext(ctx),
 	})
 	if errors.Is(err, domainmodel.ErrUserNotFound) {
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
@@ -304,7 +379,7 @@ func (p public) DeleteUser(ctx echo.Context) error {
 		})
 	}
 
-	err := p.userService.DeleteUser(domainmodel.UserID(input.Id))
+	err := p.userService.DeleteUser(domainmodel.UserID(input.Id), extractAuditMetadataFromContext(ctx))
 	if errors.Is(err, domainmodel.ErrUserNotFound) {
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
@@ -320,606 +395,731 @@ func (p public) DeleteUser(ctx echo.Context) error {
 	})
 }
 
-func (p public) GetUser(ctx echo.Context, id string) error {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (p public) CreateBook(ctx echo.Context) error {
-	var input api.CreateBookRequest
+func (p public) DeleteCurrentUser(ctx echo.Context) error {
+	var input api.DeleteCurrentUserRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	err := p.bookService.CreateBook(service.CreateBookInput{
-		Title:       input.Title,
-		Description: input.Description,
-	})
-
+	userID, err := extractUserIDFromContext(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book: %s", err))
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book created successfully"),
+	err = p.userService.DeleteCurrentUser(service.DeleteCurrentUserInput{
+		ID:       userID,
+		Password: input.Password,
+		Metadata: extractAuditMetadataFromContext(ctx),
 	})
-}
-
-func (p public) EditBook(ctx echo.Context) error {
-	var input api.EditBookRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+	if errors.Is(err, domainmodel.ErrUserNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
-
-	err := p.bookService.EditBook(service.EditBookInput{
-		ID:          domainmodel.BookID(input.Id),
-		Title:       input.Title,
-		Description: input.Description,
-	})
-	if errors.Is(err, domainmodel.ErrBookNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	if errors.Is(err, domainmodel.ErrInvalidPassword) {
+		return echo.NewHTTPError(http.StatusForbidden, "Invalid password")
+	}
+	if errors.Is(err, domainmodel.ErrNotDeleteAdmin) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit book: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete user: %s", err))
 	}
 
+	deleteCookie(ctx, "access_token")
+	deleteCookie(ctx, "refresh_token")
+
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book edited successfully"),
+		Message: ptr("User deleted successfully"),
 	})
 }
 
-func (p public) DeleteBook(ctx echo.Context) error {
-	var input api.DeleteBookRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func (p public) RequestUserDataExport(ctx echo.Context) error {
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 	}
 
-	err := p.bookService.DeleteBook(domainmodel.BookID(input.Id))
-	if errors.Is(err, domainmodel.ErrBookNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
-	}
+	request, err := p.userDataExportService.RequestUserDataExport(userID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to request user data export: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book deleted successfully"),
-	})
-}
+	if request.IsNew {
+		go p.buildUserDataExport(request.UserDataExportID, userID)
+	}
 
-func (p public) ListBook(ctx echo.Context, page int, size int) error {
-	bookOutputs, err := p.bookQueryService.List(page, size)
+	output, err := p.userDataExportQueryService.FindByID(request.UserDataExportID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get user data export: %s", err))
 	}
 
-	booksRespData := make([]api.Book, len(bookOutputs))
-	for i, b := range bookOutputs {
-		authors, err2 := p.authorQueryService.ListByBookID(b.BookID)
-		if err2 != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list author: %s", err2))
-		}
+	userDataExportAPI, err := convertUserDataExportOutputModelToAPI(output)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, userDataExportAPI)
+}
 
-		booksRespData[i] = convertBookOutputModelToAPI(b, authors)
+func (p public) GetUserDataExport(ctx echo.Context, id string) error {
+	output, err := p.findCurrentUserDataExport(ctx, id)
+	if err != nil {
+		return err
 	}
 
-	countBook, err := p.bookQueryService.CountBook(true)
+	userDataExportAPI, err := convertUserDataExportOutputModelToAPI(output)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
+		return err
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListBookResponse{
-		Books:      booksRespData,
-		CountPages: ptr(int(math.Ceil(float64(countBook) / float64(size)))),
-	})
+	return ctx.JSON(http.StatusOK, userDataExportAPI)
 }
 
-func (p public) GetBook(ctx echo.Context, id string) error {
-	var bookID uuid.UUID
-	err := bookID.Parse(id)
+func (p public) DownloadUserDataExport(ctx echo.Context, id string) error {
+	output, err := p.findCurrentUserDataExport(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	book, err := p.bookQueryService.FindByID(domainmodel.BookID(bookID))
+	archive, err := p.userDataExportQueryService.FindArchiveByID(output.UserDataExportID)
+	if errors.Is(err, domainmodel.ErrUserDataExportNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "User data export not found")
+	}
+	if errors.Is(err, domainmodel.ErrUserDataExportNotReady) {
+		return echo.NewHTTPError(http.StatusBadRequest, "User data export not ready")
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get user data export: %s", err))
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", "export-"+id+".zip"))
+
+	return ctx.Blob(http.StatusOK, "application/zip", archive)
+}
+
+// findCurrentUserDataExport не раскрывает чужие выгрузки, отвечая на них 404
+func (p public) findCurrentUserDataExport(ctx echo.Context, id string) (query.UserDataExportOutput, error) {
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return query.UserDataExportOutput{}, echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 	}
 
-	authors, err2 := p.authorQueryService.ListByBookID(book.BookID)
-	if err2 != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list author: %s", err2))
+	var userDataExportID uuid.UUID
+	err = userDataExportID.Parse(id)
+	if err != nil {
+		return query.UserDataExportOutput{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid id: %s", err))
 	}
 
-	bookRespData := convertBookOutputModelToAPI(book, authors)
+	output, err := p.userDataExportQueryService.FindByID(userDataExportID)
+	if errors.Is(err, domainmodel.ErrUserDataExportNotFound) || (err == nil && output.UserID != uuid.UUID(userID)) {
+		return query.UserDataExportOutput{}, echo.NewHTTPError(http.StatusNotFound, "User data export not found")
+	}
+	if err != nil {
+		return query.UserDataExportOutput{}, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get user data export: %s", err))
+	}
 
-	return ctx.JSON(http.StatusOK, api.GetBookResponse{
-		Book: bookRespData,
-	})
+	return output, nil
 }
 
-func (p public) CreateBookChapter(ctx echo.Context) error {
-	var input api.CreateBookChapterRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
-	}
+// buildUserDataExport собирает архив целиком в памяти, поэтому одновременно собирается не больше
+// maxConcurrentUserDataExports архивов, остальные выгрузки ждут своей очереди
+func (p public) buildUserDataExport(userDataExportID domainmodel.UserDataExportID, userID domainmodel.UserID) {
+	p.userDataExportBuilds <- struct{}{}
+	defer func() {
+		<-p.userDataExportBuilds
+	}()
 
-	err := p.bookChapterService.CreateBookChapter(service.CreateBookChapterInput{
-		BookID: domainmodel.BookID(input.BookId),
-		Title:  input.Title,
-	})
+	archive, err := p.userDataExportProvider.BuildArchive(userID)
+	if err != nil {
+		log.Printf("Failed to build user data export %s: %s", userDataExportID, err)
+
+		err = p.userDataExportService.FailUserDataExport(userDataExportID)
+		if err != nil {
+			log.Printf("Failed to mark user data export %s as failed: %s", userDataExportID, err)
+		}
+		return
+	}
 
+	err = p.userDataExportService.CompleteUserDataExport(userDataExportID, archive)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book chapter: %s", err))
+		log.Printf("Failed to complete user data export %s: %s", userDataExportID, err)
 	}
+}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book chapter created successfully"),
-	})
+func (p public) GetUser(ctx echo.Context, id string) error {
+	//TODO implement me
+	panic("implement me")
 }
 
-func (p public) EditBookChapter(ctx echo.Context) error {
-	var input api.EditBookChapterRequest
+func (p public) RestrictUser(ctx echo.Context) error {
+	var input api.RestrictUserRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	err := p.bookChapterService.EditBookChapter(service.EditBookChapterInput{
-		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
-		Title:         input.Title,
+	issuedBy, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	restrictionType, err := convertUserRestrictionTypeAPIToModel(input.Type)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := maybe.Nothing[time.Time]()
+	if input.ExpiresAtMilli != nil {
+		expiresAt = maybe.Just(time.UnixMilli(int64(*input.ExpiresAtMilli)))
+	}
+
+	err = p.userRestrictionService.RestrictUser(service.RestrictUserInput{
+		UserID:    domainmodel.UserID(input.UserId),
+		IssuedBy:  issuedBy,
+		Type:      restrictionType,
+		Reason:    input.Reason,
+		ExpiresAt: expiresAt,
+		Metadata:  extractAuditMetadataFromContext(ctx),
 	})
-	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	if errors.Is(err, domainmodel.ErrUserNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotRestrictAdmin) ||
+		errors.Is(err, domainmodel.ErrNotRestrictModerator) ||
+		errors.Is(err, domainmodel.ErrNotRestrictSelf) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book chapter: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to restrict user: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book chapter edited successfully"),
+		Message: ptr("User restricted successfully"),
 	})
 }
 
-func (p public) DeleteBookChapter(ctx echo.Context) error {
-	var input api.DeleteBookChapterRequest
+func (p public) LiftUserRestriction(ctx echo.Context) error {
+	var input api.LiftUserRestrictionRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	err := p.bookChapterService.DeleteBookChapter(domainmodel.BookChapterID(input.BookChapterId))
-	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	liftedBy, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.userRestrictionService.LiftUserRestriction(service.LiftUserRestrictionInput{
+		UserRestrictionID: domainmodel.UserRestrictionID(input.UserRestrictionId),
+		LiftedBy:          liftedBy,
+		Metadata:          extractAuditMetadataFromContext(ctx),
+	})
+	if errors.Is(err, domainmodel.ErrUserRestrictionNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "User restriction not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotRestrictAdmin) ||
+		errors.Is(err, domainmodel.ErrNotRestrictModerator) ||
+		errors.Is(err, domainmodel.ErrNotRestrictSelf) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book chapter: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to lift user restriction: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book chapter deleted successfully"),
+		Message: ptr("User restriction lifted successfully"),
 	})
 }
 
-func (p public) ListBookChapter(ctx echo.Context) error {
-	var input api.ListBookChapterRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func (p public) ListUserRestrictions(ctx echo.Context, id string) error {
+	var userID uuid.UUID
+	err := userID.Parse(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid user id: %s", err))
 	}
 
-	bookChaptersOutput, err := p.bookChapterQueryService.ListByBookID(domainmodel.BookID(input.BookId))
+	outputs, err := p.userRestrictionQueryService.ListByUserID(domainmodel.UserID(userID))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book chapter: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list user restrictions: %s", err))
 	}
 
-	bookChaptersRespData := make([]api.BookChapter, len(bookChaptersOutput))
-	for i, b := range bookChaptersOutput {
-		bookChaptersRespData[i] = api.BookChapter{
-			BookChapterId: openapi_types.UUID(b.BookChapterID),
-			Index:         b.Index,
-			Title:         b.Title,
+	restrictionsRespData := make([]api.UserRestriction, len(outputs))
+	for i, output := range outputs {
+		restriction, err2 := convertUserRestrictionOutputModelToAPI(output)
+		if err2 != nil {
+			return err2
 		}
+
+		restrictionsRespData[i] = restriction
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListBookChapterResponse{
-		BookChapters: ptr(bookChaptersRespData),
+	return ctx.JSON(http.StatusOK, api.ListUserRestrictionResponse{
+		Restrictions: restrictionsRespData,
 	})
 }
 
-func (p public) StoreBookChapterTranslation(ctx echo.Context) error {
-	var input api.StoreBookChapterTranslationRequest
+func (p public) GetTranslatorStats(ctx echo.Context, id string) error {
+	var translatorID uuid.UUID
+	err := translatorID.Parse(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid user id: %s", err))
+	}
+
+	output, err := p.translationStatsQueryService.GetTranslatorStats(domainmodel.UserID(translatorID))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get translator stats: %s", err))
+	}
+
+	books := make([]api.TranslatorBookStats, len(output.Books))
+	for i, book := range output.Books {
+		books[i] = api.TranslatorBookStats{
+			BookId:             openapi_types.UUID(book.BookID),
+			Title:              book.Title,
+			Language:           book.Language,
+			TotalChapters:      book.TotalChapters,
+			TranslatedChapters: book.TranslatedChapters,
+			WordCount:          book.WordCount,
+			LastUpdatedAtMilli: int(book.LastUpdatedAt.UnixMilli()),
+			Coverage:           float32(book.Coverage),
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.TranslatorStats{
+		TranslatedChapters: output.TranslatedChapters,
+		WordCount:          output.WordCount,
+		LastUpdatedAtMilli: convertOptionalTimeToMilliAPI(output.LastUpdatedAt),
+		Books:              books,
+	})
+}
+
+func (p public) CreateBook(ctx echo.Context) error {
+	var input api.CreateBookRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	translatorID, err := extractUserIDFromContext(ctx)
-	if err != nil {
-		return err
+	createdBy := maybe.Nothing[domainmodel.UserID]()
+	if userID, err := extractUserIDFromContext(ctx); err == nil {
+		createdBy = maybe.Just(userID)
 	}
 
-	err = p.bookChapterTranslationService.StoreBookChapterTranslation(service.StoreBookChapterTranslationInput{
-		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
-		TranslatorID:  translatorID,
-		Text:          input.Text,
+	output, err := p.bookService.CreateBook(service.CreateBookInput{
+		Title:            input.Title,
+		Description:      input.Description,
+		OriginalLanguage: convertOptionalAPIToModel(input.OriginalLanguage),
+		CreatedBy:        createdBy,
 	})
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book chapter translation: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book chapter translation stored successfully"),
+	return ctx.JSON(http.StatusOK, api.CreateBookResponse{
+		Message:    ptr("Book created successfully"),
+		BookId:     openapi_types.UUID(output.BookID),
+		Duplicates: convertBookDuplicatesModelToAPI(output.Duplicates),
 	})
 }
 
-func (p public) GetBookChapterTranslation(ctx echo.Context) error {
-	var input api.GetBookChapterTranslationRequest
+func (p public) EditBook(ctx echo.Context) error {
+	var input api.EditBookRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	bookChapterTranslation, err := p.bookChapterTranslationQueryService.GetByBookChapterIDAndTranslatorID(
-		domainmodel.BookChapterID(input.BookChapterId),
-		domainmodel.UserID(input.TranslatorId),
-	)
-	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	err := p.bookService.EditBook(service.EditBookInput{
+		ID:               domainmodel.BookID(input.Id),
+		Title:            input.Title,
+		Description:      input.Description,
+		OriginalLanguage: convertOptionalAPIToModel(input.OriginalLanguage),
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get book chapter: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit book: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.GetBookChapterTranslationResponse{
-		Text: bookChapterTranslation.Text,
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book edited successfully"),
 	})
 }
 
-func (p public) ListTranslatorsByBookChapterId(ctx echo.Context) error {
-	var input api.ListTranslatorsByBookChapterIdRequest
+func (p public) DeleteBook(ctx echo.Context) error {
+	var input api.DeleteBookRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	translatorsID, err := p.bookChapterTranslationQueryService.ListTranslatorsByBookChapterId(
-		domainmodel.BookChapterID(input.BookChapterId),
-	)
-	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	err := p.bookService.DeleteBook(domainmodel.BookID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list translators book chapter: %s", err))
-	}
-
-	translatorsRespID := make([]openapi_types.UUID, len(translatorsID))
-	for i, t := range translatorsID {
-		translatorsRespID[i] = openapi_types.UUID(t)
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListTranslatorsByBookChapterIdResponse{
-		TranslatorsId: translatorsRespID,
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book deleted successfully"),
 	})
 }
 
-func (p public) GetLastReadingSession(ctx echo.Context) error {
-	var input api.GetLastReadingSessionRequest
+func (p public) RestoreBook(ctx echo.Context) error {
+	var input api.RestoreBookRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	userID, err := extractUserIDFromContext(ctx)
+	err := p.bookService.RestoreBook(domainmodel.BookID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
 	if err != nil {
-		return err
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to restore book: %s", err))
 	}
 
-	lastReadingSession, err := p.readingSessionQueryService.GetLastReadingSession(domainmodel.BookID(input.BookId), userID)
-	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Reading session not found")
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book restored successfully"),
+	})
+}
+
+func (p public) ListBook(ctx echo.Context, page int, size int, params api.ListBookParams) error {
+	if err := checkPagination(page, size); err != nil {
+		return err
 	}
+
+	filter, err := convertListBookParamsToBookFilter(params)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get last reading session: %s", err))
+		return err
 	}
 
-	return ctx.JSON(http.StatusOK, api.GetLastReadingSessionResponse{
-		BookChapterId: openapi_types.UUID(lastReadingSession.BookChapterId),
-	})
-}
+	languages, err := convertPreferredLanguagesAPIToModel(params.Lang, params.AcceptLanguage)
+	if err != nil {
+		return err
+	}
 
-func (p public) StoreReadingSession(ctx echo.Context) error {
-	var input api.StoreReadingSessionRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+	sort, err := convertBookSortAPIToQuery(params.Sort)
+	if err != nil {
+		return err
 	}
 
-	userID, err := extractUserIDFromContext(ctx)
+	if params.Cursor != nil {
+		return p.listBookAfterCursor(ctx, filter, sort, languages, *params.Cursor, size)
+	}
+
+	bookOutputs, nextCursor, err := p.bookQueryService.List(filter, sort, page, size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
+	}
+
+	booksRespData, err := p.loadBooksAPI(bookOutputs, languages)
 	if err != nil {
 		return err
 	}
 
-	err = p.readingSession.StoreReadingSession(service.StoreReadingSessionInput{
-		BookID:        domainmodel.BookID(input.BookId),
-		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
-		UserID:        userID,
-	})
+	countBook, err := p.bookQueryService.CountBook(filter)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store reading session: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Reading session stored successfully"),
+	return ctx.JSON(http.StatusOK, api.ListBookResponse{
+		Books:      booksRespData,
+		CountPages: ptr(int(math.Ceil(float64(countBook) / float64(size)))),
+		NextCursor: convertCursorToAPI(nextCursor),
 	})
 }
 
-func (p public) ListVerifyBookRequest(ctx echo.Context) error {
-	verifyBooksRequests, err := p.verifyBookRequestQueryService.List()
+// listBookAfterCursor не считает общее число книг: при листании курсором количество страниц не нужно
+func (p public) listBookAfterCursor(
+	ctx echo.Context,
+	filter query.BookFilter,
+	sort query.BookSort,
+	languages []string,
+	cursor string,
+	size int,
+) error {
+	decodedCursor, err := query.DecodeCursor(cursor)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list verify book request session: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	verifyBookRespRequests := make([]api.VerifyBookRequest, len(verifyBooksRequests))
-	for i, v := range verifyBooksRequests {
-		isVerified, ok := v.IsVerified.Get()
-
-		sendDateMilli := int(v.SendDate.UnixNano() / int64(time.Millisecond))
-
-		verifyBookRespRequests[i] = api.VerifyBookRequest{
-			VerifyBookRequestId: openapi_types.UUID(v.VerifyBookRequestID),
-			TranslatorId:        openapi_types.UUID(v.TranslatorID),
-			BookId:              openapi_types.UUID(v.BookID),
-			IsVerified:          ptr(isVerified),
-			SendDateMilli:       sendDateMilli,
-		}
+	bookOutputs, nextCursor, err := p.bookQueryService.ListAfter(filter, sort, decodedCursor, size)
+	if errors.Is(err, query.ErrInvalidCursor) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
+	}
 
-		if !ok {
-			verifyBookRespRequests[i].IsVerified = nil
-		}
+	booksRespData, err := p.loadBooksAPI(bookOutputs, languages)
+	if err != nil {
+		return err
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListVerifyBookRequestResponse{
-		VerifyBookRequests: verifyBookRespRequests,
+	return ctx.JSON(http.StatusOK, api.ListBookResponse{
+		Books:      booksRespData,
+		NextCursor: convertCursorToAPI(nextCursor),
 	})
 }
 
-func (p public) CreateVerifyBookRequest(ctx echo.Context) error {
-	var input api.CreateVerifyBookRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func (p public) ListBookComments(ctx echo.Context, id openapi_types.UUID, params api.ListBookCommentsParams) error {
+	if params.Size <= 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "size must be positive")
 	}
 
-	translatorID, err := extractUserIDFromContext(ctx)
+	cursor, err := convertCursorAPIToQuery(params.Cursor)
 	if err != nil {
 		return err
 	}
 
-	err = p.verifyBookRequestService.CreateVerifyBookRequest(service.CreateVerifyBookRequestInput{
-		TranslatorID: translatorID,
-		BookID:       domainmodel.BookID(input.BookId),
-	})
+	bookCommentOutputs, nextCursor, err := p.bookCommentQueryService.ListByBookID(domainmodel.BookID(id), cursor, params.Size)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create verify book request: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book comments: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Verify book request created successfully"),
+	comments := make([]api.BookComment, len(bookCommentOutputs))
+	for i, c := range bookCommentOutputs {
+		comments[i] = api.BookComment{
+			Id:             openapi_types.UUID(c.BookCommentID),
+			UserId:         openapi_types.UUID(c.UserID),
+			UserLogin:      c.UserLogin,
+			Comment:        c.Comment,
+			CreatedAtMilli: c.CreatedAt.UnixMilli(),
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListBookCommentsResponse{
+		Comments:   comments,
+		NextCursor: convertCursorToAPI(nextCursor),
 	})
 }
 
-func (p public) DeleteVerifyBookRequest(ctx echo.Context) error {
-	var input api.DeleteVerifyBookRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func (p public) GetBook(ctx echo.Context, id string, params api.GetBookParams) error {
+	var bookID uuid.UUID
+	err := bookID.Parse(id)
+	if err != nil {
+		return err
 	}
 
-	err := p.verifyBookRequestService.DeleteVerifyBookRequest(domainmodel.VerifyBookRequestID(input.VerifyBookRequestId))
-	if errors.Is(err, domainmodel.ErrVerifyBookRequestNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Verify book request not found")
+	languages, err := convertPreferredLanguagesAPIToModel(params.Lang, params.AcceptLanguage)
+	if err != nil {
+		return err
 	}
+
+	book, err := p.bookQueryService.FindByID(domainmodel.BookID(bookID))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete verify book request: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Verify book request deleted successfully"),
+	booksRespData, err := p.loadBooksAPI([]query.BookOutput{book}, languages)
+	if err != nil {
+		return err
+	}
+
+	translationStats, err := p.translationStatsQueryService.GetBookStats(domainmodel.BookID(bookID))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get book translation stats: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.GetBookResponse{
+		Book:             booksRespData[0],
+		TranslationStats: convertBookTranslationStatsOutputToAPI(translationStats),
 	})
 }
 
-func (p public) AcceptVerifyBookRequest(ctx echo.Context) error {
-	var input api.AcceptBookChapterRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+// loadBooksAPI дополняет книги авторами, жанрами, оценками и числом глав за постоянное число запросов.
+// Название и описание берутся на первом из языков languages, на котором они есть
+func (p public) loadBooksAPI(bookOutputs []query.BookOutput, languages []string) ([]api.Book, error) {
+	bookIDs := make([]domainmodel.BookID, len(bookOutputs))
+	for i, b := range bookOutputs {
+		bookIDs[i] = b.BookID
 	}
 
-	err := p.verifyBookRequestService.AcceptVerifyBookRequest(service.AcceptVerifyBookRequestInput{
-		VerifyBookRequestID: domainmodel.VerifyBookRequestID(input.VerifyBookRequestId),
-		Accept:              input.Accept,
-	})
-	if errors.Is(err, domainmodel.ErrVerifyBookRequestNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Verify book request not found")
+	authorsByBookID, err := p.authorQueryService.ListByBookIDs(bookIDs)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list author: %s", err))
 	}
+
+	genresByBookID, err := p.genreQueryService.ListByBookIDs(bookIDs)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to accept verify book request: %s", err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list genre: %s", err))
 	}
 
-	bookID, err := p.verifyBookRequestProvider.FindBookIDByVerifyBookRequestID(domainmodel.VerifyBookRequestID(input.VerifyBookRequestId))
-	if errors.Is(err, domainmodel.ErrVerifyBookRequestNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Verify book request not found")
+	ratingStatsByBookID, err := p.bookQueryService.ListRatingStatsByBookIDs(bookIDs)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get book rating: %s", err))
 	}
+
+	chapterCountByBookID, err := p.bookChapterQueryService.CountByBookIDs(bookIDs)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find book to verify: %s", err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to count book chapter: %s", err))
 	}
 
-	err = p.bookService.PublishBook(service.PublishBookInput{
-		ID:          bookID,
-		IsPublished: input.Accept,
-	})
-	if errors.Is(err, domainmodel.ErrBookNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	localizationsByBookID := make(map[uuid.UUID][]query.BookLocalizationOutput)
+	if len(languages) > 0 {
+		localizationsByBookID, err = p.bookQueryService.ListLocalizationsByBookIDs(bookIDs)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book localization: %s", err))
+		}
 	}
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find book to verify: %s", err))
+
+	booksAPI := make([]api.Book, len(bookOutputs))
+	for i, b := range bookOutputs {
+		booksAPI[i], err = convertBookOutputModelToAPI(
+			b,
+			selectBookLocalization(b, localizationsByBookID[b.BookID], languages),
+			authorsByBookID[b.BookID],
+			genresByBookID[b.BookID],
+			ratingStatsByBookID[b.BookID],
+			chapterCountByBookID[b.BookID],
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Verify book request verified successfully"),
-	})
+	return booksAPI, nil
 }
 
-func (p public) GetImage(ctx echo.Context) error {
-	var input api.GetImageRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func (p public) ListBookLocalizations(ctx echo.Context, id openapi_types.UUID) error {
+	localizationsByBookID, err := p.bookQueryService.ListLocalizationsByBookIDs([]domainmodel.BookID{domainmodel.BookID(id)})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book localization: %s", err))
 	}
 
-	imageData, err := p.imageQueryService.FindByID(domainmodel.ImageID(input.ImageId))
-	if errors.Is(err, domainmodel.ErrImageNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Image not found")
-	}
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get image: %s", err))
+	localizations := localizationsByBookID[uuid.UUID(id)]
+	localizationsAPI := make([]api.BookLocalization, len(localizations))
+	for i, localization := range localizations {
+		localizationsAPI[i] = api.BookLocalization{
+			Language:    localization.Language,
+			Title:       localization.Title,
+			Description: localization.Description,
+		}
 	}
 
-	return ctx.JSON(http.StatusOK, api.GetImageResponse{
-		ImageData: imageData,
+	return ctx.JSON(http.StatusOK, api.ListBookLocalizationsResponse{
+		Localizations: localizationsAPI,
 	})
 }
 
-func (p public) DeleteImage(ctx echo.Context) error {
-	var input api.DeleteImageRequest
+func (p public) StoreBookLocalization(ctx echo.Context) error {
+	var input api.StoreBookLocalizationRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	err := p.imageService.DeleteImage(domainmodel.ImageID(input.ImageId))
-	if errors.Is(err, domainmodel.ErrImageNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Reading image not found")
+	err := p.bookService.StoreBookLocalization(service.StoreBookLocalizationInput{
+		BookID:      domainmodel.BookID(input.BookId),
+		Language:    input.Language,
+		Title:       input.Title,
+		Description: input.Description,
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get last reading session: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book localization: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Image deleted successfully"),
+		Message: ptr("Book localization stored successfully"),
 	})
 }
 
-func (p public) StoreImageBook(ctx echo.Context) error {
-	var input api.StoreBookImageRequest
+func (p public) DeleteBookLocalization(ctx echo.Context) error {
+	var input api.DeleteBookLocalizationRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	imageID, err := p.imageService.StoreImage(input.ImageData)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image book: %s", err))
+	err := p.bookService.DeleteBookLocalization(domainmodel.BookID(input.BookId), input.Language)
+	if errors.Is(err, domainmodel.ErrBookLocalizationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book localization not found")
 	}
-
-	err = p.bookService.EditBookImage(service.EditBookImageInput{
-		ID:      domainmodel.BookID(input.BookId),
-		ImageID: imageID,
-	})
-	if errors.Is(err, domainmodel.ErrBookNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image book: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book localization: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Store image book successfully"),
+		Message: ptr("Book localization deleted successfully"),
 	})
 }
 
-func (p public) StoreImageUser(ctx echo.Context) error {
-	var input api.StoreUserImageRequest
+func (p public) CreateBookChapter(ctx echo.Context) error {
+	var input api.CreateBookChapterRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	userID, err := extractUserIDFromContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	imageID, err := p.imageService.StoreImage(input.ImageData)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image user: %s", err))
-	}
-
-	err = p.userService.EditImageUser(service.EditUserImageInput{
-		ID:      userID,
-		ImageID: imageID,
+	err := p.bookChapterService.CreateBookChapter(service.CreateBookChapterInput{
+		BookID: domainmodel.BookID(input.BookId),
+		Title:  input.Title,
 	})
+
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image user: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book chapter: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Store image user successfully"),
+		Message: ptr("Book chapter created successfully"),
 	})
 }
 
-func (p public) StoreImageAuthor(ctx echo.Context) error {
-	var input api.StoreAuthorImageRequest
+func (p public) EditBookChapter(ctx echo.Context) error {
+	var input api.EditBookChapterRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	imageID, err := p.imageService.StoreImage(input.ImageData)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image author: %s", err))
-	}
-
-	err = p.authorService.EditAuthorAvatar(service.EditAuthorAvatarInput{
-		ID:      domainmodel.AuthorID(input.AuthorId),
-		ImageID: imageID,
+	err := p.bookChapterService.EditBookChapter(service.EditBookChapterInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		Title:         input.Title,
 	})
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image author: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book chapter: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Store author user successfully"),
+		Message: ptr("Book chapter edited successfully"),
 	})
 }
 
-func (p public) ListUserBookFavouritesByBook(ctx echo.Context) error {
-	var input api.ListUserBookFavouritesByBookRequest
+func (p public) EditBookChapterOriginalText(ctx echo.Context) error {
+	var input api.EditBookChapterOriginalTextRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
@@ -931,513 +1131,3065 @@ func (p public) ListUserBookFavouritesByBook(ctx echo.Context) error {
 		return err
 	}
 
-	modelType, err := p.userBookFavouritesQueryService.ListUserBookFavouritesByBook(userID, domainmodel.BookID(input.BookId))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list user book favourites: %s", err))
-	}
-
-	apiType, err := convertUserBookFavouritesTypeModelToAPI(modelType)
+	err = p.checkCanPublish(userID)
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListUserBookFavouritesByBookResponse{
-		Type: apiType,
+	err = p.bookChapterService.EditBookChapterOriginalText(service.EditBookChapterOriginalTextInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		UserID:        userID,
+		Text:          input.Text,
 	})
-}
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	}
+	if errors.Is(err, domainmodel.ErrUserNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotBookOwner) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit book chapter original text: %s", err))
+	}
 
-func (p public) StoreUserBookFavourites(ctx echo.Context) error {
-	var input api.StoreUserBookFavouritesRequest
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book chapter original text edited successfully"),
+	})
+}
+
+func (p public) DeleteBookChapter(ctx echo.Context) error {
+	var input api.DeleteBookChapterRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	userID, err := extractUserIDFromContext(ctx)
-	if err != nil {
-		return err
+	err := p.bookChapterService.DeleteBookChapter(domainmodel.BookChapterID(input.BookChapterId))
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
 	}
-
-	modelType, err := convertUserBookFavouritesTypeAPIToModel(input.Type)
 	if err != nil {
-		return err
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book chapter: %s", err))
 	}
 
-	err = p.userBookFavouritesService.StoreUserBookFavourites(service.StoreUserBookFavouritesInput{
-		UserID: userID,
-		BookID: domainmodel.BookID(input.BookId),
-		Type:   modelType,
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book chapter deleted successfully"),
 	})
+}
+
+func (p public) RestoreBookChapter(ctx echo.Context) error {
+	var input api.RestoreBookChapterRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.bookChapterService.RestoreBookChapter(domainmodel.BookChapterID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store user book favourites: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to restore book chapter: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Store user book favourites successfully"),
+		Message: ptr("Book chapter restored successfully"),
 	})
 }
 
-func (p public) DeleteUserBookFavourites(ctx echo.Context) error {
-	var input api.DeleteUserBookFavouritesRequest
+func (p public) ListBookChapter(ctx echo.Context) error {
+	var input api.ListBookChapterRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	userID, err := extractUserIDFromContext(ctx)
+	var bookChaptersOutput []query.BookChapterOutput
+	nextCursor := maybe.Nothing[query.Cursor]()
+	if input.Size != nil {
+		if *input.Size <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "size must be positive")
+		}
+
+		cursor, err := convertCursorAPIToQuery(input.Cursor)
+		if err != nil {
+			return err
+		}
+
+		bookChaptersOutput, nextCursor, err = p.bookChapterQueryService.ListPageByBookID(domainmodel.BookID(input.BookId), cursor, *input.Size)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book chapter: %s", err))
+		}
+	} else {
+		var err error
+		bookChaptersOutput, err = p.bookChapterQueryService.ListByBookID(domainmodel.BookID(input.BookId))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book chapter: %s", err))
+		}
+	}
+
+	bookChaptersRespData := make([]api.BookChapter, len(bookChaptersOutput))
+	for i, b := range bookChaptersOutput {
+		bookChaptersRespData[i] = convertBookChapterOutputToAPI(b)
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListBookChapterResponse{
+		BookChapters: ptr(bookChaptersRespData),
+		NextCursor:   convertCursorToAPI(nextCursor),
+	})
+}
+
+func (p public) StoreBookChapterTranslation(ctx echo.Context) error {
+	var input api.StoreBookChapterTranslationRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	translatorID, err := extractUserIDFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = p.userBookFavouritesService.DeleteUserBookFavourites(service.DeleteUserBookFavouritesInput{
-		UserID: userID,
-		BookID: domainmodel.BookID(input.BookId),
+	err = p.checkCanPublish(translatorID)
+	if err != nil {
+		return err
+	}
+
+	err = p.bookChapterTranslationService.StoreBookChapterTranslation(service.StoreBookChapterTranslationInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		TranslatorID:  translatorID,
+		Language:      convertTranslationLanguageAPIToModel(input.Language),
+		Text:          input.Text,
 	})
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) || errors.Is(err, domainmodel.ErrInvalidTranslationMarkup) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domainmodel.ErrUnreviewedMachineDraft) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Remove the machine draft notice after editing the translation")
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete user book favourites: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book chapter translation: %s", err))
 	}
 
 	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Delete user book favourites successfully"),
+		Message: ptr("Book chapter translation stored successfully"),
 	})
 }
 
-func (p public) ListBookByUserBookFavourites(ctx echo.Context) error {
-	var input api.ListBookByUserBookFavouritesRequest
+func (p public) GetBookChapterTranslation(ctx echo.Context) error {
+	var input api.GetBookChapterTranslationRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	userID, err := extractUserIDFromContext(ctx)
+	language, err := convertLanguageAPIToModel(input.Language)
 	if err != nil {
 		return err
 	}
 
-	modelTypes := make([]domainmodel.UserBookFavouritesType, len(input.Types))
-	for i, t := range input.Types {
-		modelType, err2 := convertUserBookFavouritesTypeAPIToModel(t)
-		if err2 != nil {
-			return err2
-		}
+	format, err := convertTranslationTextFormatAPI(input.Format)
+	if err != nil {
+		return err
+	}
 
-		modelTypes[i] = modelType
+	bookChapterTranslation, err := p.bookChapterTranslationQueryService.GetByBookChapterIDAndTranslatorID(
+		domainmodel.BookChapterID(input.BookChapterId),
+		domainmodel.UserID(input.TranslatorId),
+		language.OrElse(domainmodel.DefaultTranslationLanguage),
+	)
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get book chapter: %s", err))
 	}
 
-	outputs, err := p.userBookFavouritesQueryService.ListBookByUserBookFavourites(userID, modelTypes)
+	return ctx.JSON(http.StatusOK, convertBookChapterTranslationOutputToAPI(bookChapterTranslation, format))
+}
+
+func (p public) GetDefaultBookChapterTranslation(ctx echo.Context, params api.GetDefaultBookChapterTranslationParams) error {
+	language, err := convertLanguageAPIToModel(params.Language)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list user book favourites: %s", err))
+		return err
 	}
 
-	userBookFavouritesBooks := make([]api.UserBookFavouritesBooks, len(outputs))
-	for i, output := range outputs {
-		apiType, err2 := convertUserBookFavouritesTypeModelToAPI(output.Type)
-		if err2 != nil {
-			return err2
-		}
+	format, err := convertTranslationTextFormatAPI(params.Format)
+	if err != nil {
+		return err
+	}
 
-		books := make([]api.Book, len(output.Books))
-		for j, book := range output.Books {
-			authors, err3 := p.authorQueryService.ListByBookID(book.BookID)
-			if err3 != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list author: %s", err3))
-			}
+	bookChapterTranslation, err := p.bookChapterTranslationQueryService.GetDefaultByBookChapterID(
+		domainmodel.BookChapterID(params.BookChapterId),
+		language,
+	)
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get default book chapter translation: %s", err))
+	}
 
-			books[j] = convertBookOutputModelToAPI(book, authors)
-		}
+	return ctx.JSON(http.StatusOK, convertBookChapterTranslationOutputToAPI(bookChapterTranslation, format))
+}
 
-		userBookFavouritesBooks[i] = api.UserBookFavouritesBooks{
-			Type:  apiType,
-			Books: books,
-		}
+func (p public) VoteBookChapterTranslation(ctx echo.Context) error {
+	var input api.VoteBookChapterTranslationRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListBookByUserBookFavouritesResponse{
-		UserBookFavouritesBooks: userBookFavouritesBooks,
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.bookChapterTranslationService.VoteBookChapterTranslation(service.VoteBookChapterTranslationInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		TranslatorID:  domainmodel.UserID(input.TranslatorId),
+		Language:      convertTranslationLanguageAPIToModel(input.Language),
+		UserID:        userID,
+		Value:         input.Value,
+	})
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidTranslationVote) ||
+		errors.Is(err, domainmodel.ErrVoteOwnTranslation) ||
+		errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to vote book chapter translation: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book chapter translation vote stored successfully"),
 	})
 }
 
-func (p public) ListAuthors(ctx echo.Context) error {
-	outputs, err := p.authorQueryService.List()
+func (p public) DeleteBookChapterTranslationVote(ctx echo.Context) error {
+	var input api.DeleteBookChapterTranslationVoteRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list authors: %s", err))
+		return err
 	}
 
-	authorsRespData := make([]api.Author, len(outputs))
-	for i, a := range outputs {
-		authorsRespData[i] = convertAuthorOutputModelToAPI(a)
+	err = p.bookChapterTranslationService.DeleteBookChapterTranslationVote(service.DeleteBookChapterTranslationVoteInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		TranslatorID:  domainmodel.UserID(input.TranslatorId),
+		Language:      convertTranslationLanguageAPIToModel(input.Language),
+		UserID:        userID,
+	})
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationVoteNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation vote not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book chapter translation vote: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListAuthorResponse{
-		Authors: authorsRespData,
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book chapter translation vote deleted successfully"),
 	})
 }
 
-func (p public) CreateAuthor(ctx echo.Context) error {
-	var input api.CreateAuthorRequest
+func (p public) CreateMachineDraftTranslation(ctx echo.Context) error {
+	var input api.CreateMachineDraftTranslationRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	middleName := maybe.Nothing[string]()
-	if input.MiddleName != nil {
-		middleName = maybe.Just(*input.MiddleName)
+	translatorID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
 	}
 
-	nickName := maybe.Nothing[string]()
-	if input.MiddleName != nil {
-		nickName = maybe.Just(*input.NickName)
+	err = p.checkCanPublish(translatorID)
+	if err != nil {
+		return err
 	}
 
-	err := p.authorService.CreateAuthor(service.CreateAuthorInput{
-		FirstName:  input.FirstName,
-		SecondName: input.SecondName,
-		MiddleName: middleName,
-		Nickname:   nickName,
+	output, err := p.machineTranslationService.CreateDraft(ctx.Request().Context(), service.CreateMachineDraftInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		Language:      convertTranslationLanguageAPIToModel(input.Language),
 	})
-
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	}
+	if errors.Is(err, domainmodel.ErrBookChapterOriginalTextNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter original text not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) || errors.Is(err, domainmodel.ErrMachineTranslationSameLanguage) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domainmodel.ErrMachineTranslationFailed) {
+		// Ответ движка может содержать его адрес и внутренние подробности, поэтому клиенту они не отдаются
+		log.Printf("Failed to create machine draft of book chapter %s: %s", input.BookChapterId, err)
+		return echo.NewHTTPError(http.StatusBadGateway, "Machine translation engine is unavailable")
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create author: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create machine draft translation: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Author created successfully"),
+	return ctx.JSON(http.StatusOK, api.CreateMachineDraftTranslationResponse{
+		Text:           output.Text,
+		Provider:       output.Provider,
+		SourceLanguage: convertOptionalModelToAPI(output.SourceLanguage),
+		Language:       output.Language,
+		IsMachineDraft: true,
 	})
 }
 
-func (p public) EditAuthor(ctx echo.Context) error {
-	var input api.EditAuthorRequest
+func (p public) CreateBookChapterTranslationAnnotation(ctx echo.Context) error {
+	var input api.CreateBookChapterTranslationAnnotationRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	middleName := maybe.Nothing[string]()
-	if input.MiddleName != nil {
-		middleName = maybe.Just(*input.MiddleName)
+	translatorID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
 	}
 
-	nickName := maybe.Nothing[string]()
-	if input.MiddleName != nil {
-		nickName = maybe.Just(*input.NickName)
+	err = p.checkCanPublish(translatorID)
+	if err != nil {
+		return err
 	}
 
-	err := p.authorService.EditAuthor(service.EditAuthorInput{
-		ID:         domainmodel.AuthorID(input.Id),
-		FirstName:  input.FirstName,
-		SecondName: input.SecondName,
-		MiddleName: middleName,
-		Nickname:   nickName,
+	annotationID, err := p.bookChapterTranslationService.CreateAnnotation(service.CreateBookChapterTranslationAnnotationInput{
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		TranslatorID:  translatorID,
+		Language:      convertTranslationLanguageAPIToModel(input.Language),
+		Anchor:        convertAnnotationAnchorAPIToModel(input.Anchor),
+		Text:          input.Text,
 	})
-
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) ||
+		errors.Is(err, domainmodel.ErrInvalidAnnotationAnchor) ||
+		errors.Is(err, domainmodel.ErrEmptyAnnotationText) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit author: %s", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book chapter translation annotation: %s", err))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Author edited successfully"),
+	return ctx.JSON(http.StatusOK, api.CreateBookChapterTranslationAnnotationResponse{
+		Message:      ptr("Book chapter translation annotation created successfully"),
+		AnnotationId: openapi_types.UUID(annotationID),
 	})
 }
 
-func (p public) DeleteAuthor(ctx echo.Context) error {
-	var input api.DeleteAuthorRequest
+func (p public) EditBookChapterTranslationAnnotation(ctx echo.Context) error {
+	var input api.EditBookChapterTranslationAnnotationRequest
 	if err := ctx.Bind(&input); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
 			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
 		})
 	}
 
-	err := p.authorService.DeleteAuthor(domainmodel.AuthorID(input.Id))
+	translatorID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(translatorID)
+	if err != nil {
+		return err
+	}
+
+	anchor := maybe.Nothing[service.AnnotationAnchorInput]()
+	if input.Anchor != nil {
+		anchor = maybe.Just(convertAnnotationAnchorAPIToModel(*input.Anchor))
+	}
+
+	err = p.bookChapterTranslationService.EditAnnotation(service.EditBookChapterTranslationAnnotationInput{
+		AnnotationID: domainmodel.BookChapterTranslationAnnotationID(input.AnnotationId),
+		TranslatorID: translatorID,
+		Text:         convertOptionalAPIToModel(input.Text),
+		Anchor:       anchor,
+	})
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationAnnotationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation annotation not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotAnnotationTranslator) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidAnnotationAnchor) || errors.Is(err, domainmodel.ErrEmptyAnnotationText) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit book chapter translation annotation: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book chapter translation annotation edited successfully"),
+	})
+}
+
+func (p public) DeleteBookChapterTranslationAnnotation(ctx echo.Context) error {
+	var input api.DeleteBookChapterTranslationAnnotationRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	translatorID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(translatorID)
+	if err != nil {
+		return err
+	}
+
+	err = p.bookChapterTranslationService.DeleteAnnotation(domainmodel.BookChapterTranslationAnnotationID(input.AnnotationId), translatorID)
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationAnnotationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation annotation not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotAnnotationTranslator) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book chapter translation annotation: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book chapter translation annotation deleted successfully"),
+	})
+}
+
+func (p public) GetAlignedBookChapterTranslation(ctx echo.Context, params api.GetAlignedBookChapterTranslationParams) error {
+	language, err := convertLanguageAPIToModel(params.Language)
+	if err != nil {
+		return err
+	}
+
+	output, err := p.bookChapterTranslationQueryService.GetAlignedByBookChapterIDAndTranslatorID(
+		domainmodel.BookChapterID(params.BookChapterId),
+		domainmodel.UserID(params.TranslatorId),
+		language.OrElse(domainmodel.DefaultTranslationLanguage),
+	)
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	}
+	if errors.Is(err, domainmodel.ErrBookChapterOriginalTextNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter original text not found")
+	}
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get aligned book chapter translation: %s", err))
+	}
+
+	paragraphsRespData := make([]api.AlignedParagraphs, len(output.Paragraphs))
+	for i, paragraphs := range output.Paragraphs {
+		paragraphsRespData[i] = api.AlignedParagraphs{
+			Original:    paragraphs.Original,
+			Translation: paragraphs.Translation,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.GetAlignedBookChapterTranslationResponse{
+		TranslatorId:     openapi_types.UUID(output.TranslatorID),
+		Language:         output.Language,
+		OriginalLanguage: convertOptionalModelToAPI(output.OriginalLanguage),
+		Paragraphs:       paragraphsRespData,
+	})
+}
+
+func (p public) ListBookGlossary(ctx echo.Context, id openapi_types.UUID, params api.ListBookGlossaryParams) error {
+	language, err := convertLanguageAPIToModel(params.Language)
+	if err != nil {
+		return err
+	}
+
+	userID := maybe.Nothing[domainmodel.UserID]()
+	if currentUserID, err2 := extractUserIDFromContext(ctx); err2 == nil {
+		userID = maybe.Just(currentUserID)
+	}
+
+	outputs, err := p.bookGlossaryQueryService.ListByBookID(domainmodel.BookID(id), language, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book glossary: %s", err))
+	}
+
+	termsRespData := make([]api.BookGlossaryTerm, len(outputs))
+	for i, output := range outputs {
+		termsRespData[i] = api.BookGlossaryTerm{
+			TermId:         openapi_types.UUID(output.TermID),
+			Language:       output.Language,
+			SourceTerm:     output.SourceTerm,
+			TargetTerm:     output.TargetTerm,
+			Notes:          convertOptionalModelToAPI(output.Notes),
+			AuthorId:       openapi_types.UUID(output.AuthorID),
+			AuthorLogin:    output.AuthorLogin,
+			IsShared:       output.IsShared,
+			CreatedAtMilli: int(output.CreatedAt.UnixMilli()),
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListBookGlossaryResponse{
+		Terms: termsRespData,
+	})
+}
+
+func (p public) CheckBookGlossaryConsistency(ctx echo.Context, id openapi_types.UUID) error {
+	var input api.CheckBookGlossaryConsistencyRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	translatorID := maybe.Nothing[domainmodel.UserID]()
+	if userID, err := extractUserIDFromContext(ctx); err == nil {
+		translatorID = maybe.Just(userID)
+	}
+
+	issues, err := p.bookGlossaryService.CheckConsistency(service.CheckGlossaryConsistencyInput{
+		BookID:       domainmodel.BookID(id),
+		Language:     convertTranslationLanguageAPIToModel(input.Language),
+		TranslatorID: translatorID,
+		Text:         input.Text,
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check book glossary consistency: %s", err))
+	}
+
+	issuesRespData := make([]api.GlossaryIssue, len(issues))
+	for i, issue := range issues {
+		issueType := api.VARIANT
+		if issue.Type == domainmodel.GlossaryUntranslated {
+			issueType = api.UNTRANSLATED
+		}
+
+		issuesRespData[i] = api.GlossaryIssue{
+			TermId:         openapi_types.UUID(issue.Term.ID()),
+			SourceTerm:     issue.Term.SourceTerm(),
+			TargetTerm:     issue.Term.TargetTerm(),
+			Type:           issueType,
+			Found:          issue.Found,
+			Count:          issue.Count,
+			ParagraphIndex: issue.ParagraphIndex,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.CheckBookGlossaryConsistencyResponse{
+		Issues: issuesRespData,
+	})
+}
+
+func (p public) CreateBookGlossaryTerm(ctx echo.Context) error {
+	var input api.CreateBookGlossaryTermRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(userID)
+	if err != nil {
+		return err
+	}
+
+	termID, err := p.bookGlossaryService.CreateTerm(service.CreateBookGlossaryTermInput{
+		BookID:     domainmodel.BookID(input.BookId),
+		AuthorID:   userID,
+		Language:   convertTranslationLanguageAPIToModel(input.Language),
+		SourceTerm: input.SourceTerm,
+		TargetTerm: input.TargetTerm,
+		Notes:      convertOptionalAPIToModel(input.Notes),
+		IsShared:   input.IsShared,
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidGlossaryTerm) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Glossary term must contain from 1 to %d characters", domainmodel.MaxGlossaryTermLength))
+	}
+	if errors.Is(err, domainmodel.ErrGlossaryTermExists) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Glossary already contains this term")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create book glossary term: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.CreateBookGlossaryTermResponse{
+		Message: ptr("Book glossary term created successfully"),
+		TermId:  openapi_types.UUID(termID),
+	})
+}
+
+func (p public) EditBookGlossaryTerm(ctx echo.Context) error {
+	var input api.EditBookGlossaryTermRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(userID)
+	if err != nil {
+		return err
+	}
+
+	err = p.bookGlossaryService.EditTerm(service.EditBookGlossaryTermInput{
+		TermID:     domainmodel.BookGlossaryTermID(input.TermId),
+		UserID:     userID,
+		SourceTerm: convertOptionalAPIToModel(input.SourceTerm),
+		TargetTerm: convertOptionalAPIToModel(input.TargetTerm),
+		Notes:      convertOptionalAPIToModel(input.Notes),
+		IsShared:   convertOptionalAPIToModel(input.IsShared),
+	})
+	if errors.Is(err, domainmodel.ErrBookGlossaryTermNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book glossary term not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotGlossaryTermAuthor) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidGlossaryTerm) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Glossary term must contain from 1 to %d characters", domainmodel.MaxGlossaryTermLength))
+	}
+	if errors.Is(err, domainmodel.ErrGlossaryTermExists) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Glossary already contains this term")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit book glossary term: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book glossary term edited successfully"),
+	})
+}
+
+func (p public) DeleteBookGlossaryTerm(ctx echo.Context) error {
+	var input api.DeleteBookGlossaryTermRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(userID)
+	if err != nil {
+		return err
+	}
+
+	err = p.bookGlossaryService.DeleteTerm(domainmodel.BookGlossaryTermID(input.TermId), userID)
+	if errors.Is(err, domainmodel.ErrBookGlossaryTermNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book glossary term not found")
+	}
+	if errors.Is(err, domainmodel.ErrNotGlossaryTermAuthor) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book glossary term: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book glossary term deleted successfully"),
+	})
+}
+
+func (p public) ListTranslatorsByBookChapterId(ctx echo.Context) error {
+	var input api.ListTranslatorsByBookChapterIdRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	language, err := convertLanguageAPIToModel(input.Language)
+	if err != nil {
+		return err
+	}
+
+	translators, err := p.bookChapterTranslationQueryService.ListTranslatorsByBookChapterId(
+		domainmodel.BookChapterID(input.BookChapterId),
+		language,
+	)
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list translators book chapter: %s", err))
+	}
+
+	// Переводчик с переводами на несколько языков попадает в translatorsId один раз
+	translatorsRespID := make([]openapi_types.UUID, 0, len(translators))
+	translationsResp := make([]api.BookChapterTranslator, len(translators))
+	seen := make(map[uuid.UUID]struct{}, len(translators))
+	for i, t := range translators {
+		translationsResp[i] = api.BookChapterTranslator{
+			TranslatorId: openapi_types.UUID(t.TranslatorID),
+			Language:     t.Language,
+			Score:        t.Score,
+		}
+		if _, ok := seen[t.TranslatorID]; ok {
+			continue
+		}
+		seen[t.TranslatorID] = struct{}{}
+		translatorsRespID = append(translatorsRespID, openapi_types.UUID(t.TranslatorID))
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListTranslatorsByBookChapterIdResponse{
+		TranslatorsId: translatorsRespID,
+		Translations:  translationsResp,
+	})
+}
+
+func (p public) GetLastReadingSession(ctx echo.Context) error {
+	var input api.GetLastReadingSessionRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	lastReadingSession, err := p.readingSessionQueryService.GetLastReadingSession(domainmodel.BookID(input.BookId), userID)
+	if errors.Is(err, domainmodel.ErrBookChapterTranslationNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Reading session not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get last reading session: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.GetLastReadingSessionResponse{
+		BookChapterId: openapi_types.UUID(lastReadingSession.BookChapterId),
+	})
+}
+
+func (p public) StoreReadingSession(ctx echo.Context) error {
+	var input api.StoreReadingSessionRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.readingSession.StoreReadingSession(service.StoreReadingSessionInput{
+		BookID:        domainmodel.BookID(input.BookId),
+		BookChapterID: domainmodel.BookChapterID(input.BookChapterId),
+		UserID:        userID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store reading session: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Reading session stored successfully"),
+	})
+}
+
+func (p public) GetPreferredTranslator(ctx echo.Context, params api.GetPreferredTranslatorParams) error {
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	preferredTranslator, err := p.readingSessionQueryService.GetPreferredTranslator(domainmodel.BookID(params.BookId), userID)
+	if errors.Is(err, domainmodel.ErrPreferredTranslatorNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Preferred translator not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get preferred translator: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.GetPreferredTranslatorResponse{
+		TranslatorId: openapi_types.UUID(preferredTranslator.TranslatorID),
+		Language:     convertOptionalModelToAPI(preferredTranslator.Language),
+	})
+}
+
+func (p public) StorePreferredTranslator(ctx echo.Context) error {
+	var input api.StorePreferredTranslatorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.readingSession.StorePreferredTranslator(service.StorePreferredTranslatorInput{
+		BookID:       domainmodel.BookID(input.BookId),
+		UserID:       userID,
+		TranslatorID: domainmodel.UserID(input.TranslatorId),
+		Language:     convertOptionalAPIToModel(input.Language),
+	})
+	if errors.Is(err, domainmodel.ErrTranslatorNotTranslatingBook) {
+		return echo.NewHTTPError(http.StatusNotFound, "Translator has no translations of the book")
+	}
+	if errors.Is(err, domainmodel.ErrInvalidLanguageCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store preferred translator: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Preferred translator stored successfully"),
+	})
+}
+
+func (p public) DeletePreferredTranslator(ctx echo.Context) error {
+	var input api.DeletePreferredTranslatorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.readingSession.DeletePreferredTranslator(domainmodel.BookID(input.BookId), userID)
+	if errors.Is(err, domainmodel.ErrPreferredTranslatorNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Preferred translator not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete preferred translator: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Preferred translator deleted successfully"),
+	})
+}
+
+// ReadBookChapter показывает перевод выбранного читателем переводчика, а если его нет или читатель
+// не авторизован - перевод с наибольшим числом голосов
+func (p public) ReadBookChapter(ctx echo.Context, params api.ReadBookChapterParams) error {
+	language, err := convertLanguageAPIToModel(params.Language)
+	if err != nil {
+		return err
+	}
+
+	format, err := convertTranslationTextFormatAPI(params.Format)
+	if err != nil {
+		return err
+	}
+
+	bookChapter, err := p.bookChapterQueryService.FindByID(domainmodel.BookChapterID(params.BookChapterId))
+	if errors.Is(err, domainmodel.ErrBookChapterNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book chapter not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get book chapter: %s", err))
+	}
+
+	preferredTranslator := maybe.Nothing[query.PreferredTranslatorOutput]()
+	if userID, err2 := extractUserIDFromContext(ctx); err2 == nil {
+		output, err3 := p.readingSessionQueryService.GetPreferredTranslator(domainmodel.BookID(bookChapter.BookID), userID)
+		if err3 != nil && !errors.Is(err3, domainmodel.ErrPreferredTranslatorNotFound) {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get preferred translator: %s", err3))
+		}
+		if err3 == nil {
+			preferredTranslator = maybe.Just(output)
+		}
+	}
+
+	response := api.ReadBookChapterResponse{
+		BookChapterId:        openapi_types.UUID(bookChapter.BookChapterID),
+		UntranslatedChapters: []api.BookChapter{},
+	}
+
+	if preferred, ok := preferredTranslator.Get(); ok {
+		preferredLanguage := language.OrElse(preferred.Language.OrElse(domainmodel.DefaultTranslationLanguage))
+
+		translation, err2 := p.bookChapterTranslationQueryService.GetByBookChapterIDAndTranslatorID(
+			domainmodel.BookChapterID(bookChapter.BookChapterID),
+			domainmodel.UserID(preferred.TranslatorID),
+			preferredLanguage,
+		)
+		if err2 != nil && !errors.Is(err2, domainmodel.ErrBookChapterTranslationNotFound) {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get book chapter translation: %s", err2))
+		}
+		if err2 == nil {
+			response.Translation = convertBookChapterTranslationOutputToAPI(translation, format)
+			response.IsPreferredTranslation = true
+		}
+
+		untranslatedChapters, err2 := p.bookChapterTranslationQueryService.ListChaptersNotTranslatedBy(
+			domainmodel.BookID(bookChapter.BookID),
+			domainmodel.UserID(preferred.TranslatorID),
+			maybe.Just(preferredLanguage),
+		)
+		if err2 != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list untranslated book chapters: %s", err2))
+		}
+		for _, chapter := range untranslatedChapters {
+			response.UntranslatedChapters = append(response.UntranslatedChapters, convertBookChapterOutputToAPI(chapter))
+		}
+		response.PreferredTranslatorId = ptr(openapi_types.UUID(preferred.TranslatorID))
+	}
+
+	if !response.IsPreferredTranslation {
+		translation, err2 := p.bookChapterTranslationQueryService.GetDefaultByBookChapterID(
+			domainmodel.BookChapterID(bookChapter.BookChapterID),
+			language,
+		)
+		if errors.Is(err2, domainmodel.ErrBookChapterTranslationNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Book chapter translation not found")
+		}
+		if err2 != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get default book chapter translation: %s", err2))
+		}
+		response.Translation = convertBookChapterTranslationOutputToAPI(translation, format)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (p public) ListVerifyBookRequest(ctx echo.Context) error {
+	verifyBooksRequests, err := p.verifyBookRequestQueryService.List()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list verify book request session: %s", err))
+	}
+
+	verifyBookRespRequests := make([]api.VerifyBookRequest, len(verifyBooksRequests))
+	for i, v := range verifyBooksRequests {
+		isVerified, ok := v.IsVerified.Get()
+
+		sendDateMilli := int(v.SendDate.UnixNano() / int64(time.Millisecond))
+
+		verifyBookRespRequests[i] = api.VerifyBookRequest{
+			VerifyBookRequestId: openapi_types.UUID(v.VerifyBookRequestID),
+			TranslatorId:        openapi_types.UUID(v.TranslatorID),
+			BookId:              openapi_types.UUID(v.BookID),
+			IsVerified:          ptr(isVerified),
+			SendDateMilli:       sendDateMilli,
+			Duplicates:          make([]api.BookDuplicate, len(v.Duplicates)),
+		}
+		for j, d := range v.Duplicates {
+			verifyBookRespRequests[i].Duplicates[j] = api.BookDuplicate{
+				BookId:          openapi_types.UUID(d.BookID),
+				Title:           d.Title,
+				TitleSimilarity: d.TitleSimilarity,
+				AuthorOverlap:   d.AuthorOverlap,
+				Score:           d.Score,
+			}
+		}
+
+		if !ok {
+			verifyBookRespRequests[i].IsVerified = nil
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListVerifyBookRequestResponse{
+		VerifyBookRequests: verifyBookRespRequests,
+	})
+}
+
+func (p public) CreateVerifyBookRequest(ctx echo.Context) error {
+	var input api.CreateVerifyBookRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	translatorID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(translatorID)
+	if err != nil {
+		return err
+	}
+
+	output, err := p.verifyBookRequestService.CreateVerifyBookRequest(service.CreateVerifyBookRequestInput{
+		TranslatorID: translatorID,
+		BookID:       domainmodel.BookID(input.BookId),
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create verify book request: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.CreateVerifyBookResponse{
+		Message:             ptr("Verify book request created successfully"),
+		VerifyBookRequestId: openapi_types.UUID(output.VerifyBookRequestID),
+		Duplicates:          convertBookDuplicatesModelToAPI(output.Duplicates),
+	})
+}
+
+func (p public) DeleteVerifyBookRequest(ctx echo.Context) error {
+	var input api.DeleteVerifyBookRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.verifyBookRequestService.DeleteVerifyBookRequest(domainmodel.VerifyBookRequestID(input.VerifyBookRequestId))
+	if errors.Is(err, domainmodel.ErrVerifyBookRequestNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Verify book request not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete verify book request: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Verify book request deleted successfully"),
+	})
+}
+
+func (p public) AcceptVerifyBookRequest(ctx echo.Context) error {
+	var input api.AcceptBookChapterRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	metadata := extractAuditMetadataFromContext(ctx)
+
+	err := p.verifyBookRequestService.AcceptVerifyBookRequest(service.AcceptVerifyBookRequestInput{
+		VerifyBookRequestID: domainmodel.VerifyBookRequestID(input.VerifyBookRequestId),
+		Accept:              input.Accept,
+		Metadata:            metadata,
+	})
+	if errors.Is(err, domainmodel.ErrVerifyBookRequestNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Verify book request not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to accept verify book request: %s", err))
+	}
+
+	bookID, err := p.verifyBookRequestProvider.FindBookIDByVerifyBookRequestID(domainmodel.VerifyBookRequestID(input.VerifyBookRequestId))
+	if errors.Is(err, domainmodel.ErrVerifyBookRequestNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Verify book request not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find book to verify: %s", err))
+	}
+
+	err = p.bookService.PublishBook(service.PublishBookInput{
+		ID:          bookID,
+		IsPublished: input.Accept,
+		Metadata:    metadata,
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find book to verify: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Verify book request verified successfully"),
+	})
+}
+
+func (p public) GetImage(ctx echo.Context) error {
+	var input api.GetImageRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	imageData, err := p.imageQueryService.FindByID(domainmodel.ImageID(input.ImageId))
+	if errors.Is(err, domainmodel.ErrImageNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get image: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.GetImageResponse{
+		ImageData: imageData,
+	})
+}
+
+func (p public) DeleteImage(ctx echo.Context) error {
+	var input api.DeleteImageRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.imageService.DeleteImage(domainmodel.ImageID(input.ImageId))
+	if errors.Is(err, domainmodel.ErrImageNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Reading image not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get last reading session: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Image deleted successfully"),
+	})
+}
+
+func (p public) StoreImageBook(ctx echo.Context) error {
+	var input api.StoreBookImageRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	imageID, err := p.imageService.StoreImage(input.ImageData)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image book: %s", err))
+	}
+
+	err = p.bookService.EditBookImage(service.EditBookImageInput{
+		ID:      domainmodel.BookID(input.BookId),
+		ImageID: imageID,
+	})
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image book: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Store image book successfully"),
+	})
+}
+
+func (p public) StoreImageUser(ctx echo.Context) error {
+	var input api.StoreUserImageRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	imageID, err := p.imageService.StoreImage(input.ImageData)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image user: %s", err))
+	}
+
+	err = p.userService.EditImageUser(service.EditUserImageInput{
+		ID:      userID,
+		ImageID: imageID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image user: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Store image user successfully"),
+	})
+}
+
+func (p public) StoreImageAuthor(ctx echo.Context) error {
+	var input api.StoreAuthorImageRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	imageID, err := p.imageService.StoreImage(input.ImageData)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image author: %s", err))
+	}
+
+	err = p.authorService.EditAuthorAvatar(service.EditAuthorAvatarInput{
+		ID:      domainmodel.AuthorID(input.AuthorId),
+		ImageID: imageID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store image author: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Store author user successfully"),
+	})
+}
+
+func (p public) ListUserBookFavouritesByBook(ctx echo.Context) error {
+	var input api.ListUserBookFavouritesByBookRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	modelType, err := p.userBookFavouritesQueryService.ListUserBookFavouritesByBook(userID, domainmodel.BookID(input.BookId))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list user book favourites: %s", err))
+	}
+
+	apiType, err := convertUserBookFavouritesTypeModelToAPI(modelType)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListUserBookFavouritesByBookResponse{
+		Type: apiType,
+	})
+}
+
+func (p public) StoreUserBookFavourites(ctx echo.Context) error {
+	var input api.StoreUserBookFavouritesRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	modelType, err := convertUserBookFavouritesTypeAPIToModel(input.Type)
+	if err != nil {
+		return err
+	}
+
+	err = p.userBookFavouritesService.StoreUserBookFavourites(service.StoreUserBookFavouritesInput{
+		UserID: userID,
+		BookID: domainmodel.BookID(input.BookId),
+		Type:   modelType,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store user book favourites: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Store user book favourites successfully"),
+	})
+}
+
+func (p public) DeleteUserBookFavourites(ctx echo.Context) error {
+	var input api.DeleteUserBookFavouritesRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.userBookFavouritesService.DeleteUserBookFavourites(service.DeleteUserBookFavouritesInput{
+		UserID: userID,
+		BookID: domainmodel.BookID(input.BookId),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete user book favourites: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Delete user book favourites successfully"),
+	})
+}
+
+func (p public) ListBookByUserBookFavourites(ctx echo.Context) error {
+	var input api.ListBookByUserBookFavouritesRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	modelTypes := make([]domainmodel.UserBookFavouritesType, len(input.Types))
+	for i, t := range input.Types {
+		modelType, err2 := convertUserBookFavouritesTypeAPIToModel(t)
+		if err2 != nil {
+			return err2
+		}
+
+		modelTypes[i] = modelType
+	}
+
+	outputs, err := p.userBookFavouritesQueryService.ListBookByUserBookFavourites(userID, modelTypes)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list user book favourites: %s", err))
+	}
+
+	var bookOutputs []query.BookOutput
+	for _, output := range outputs {
+		bookOutputs = append(bookOutputs, output.Books...)
+	}
+
+	booksAPI, err := p.loadBooksAPI(bookOutputs, nil)
+	if err != nil {
+		return err
+	}
+
+	bookIndex := 0
+	userBookFavouritesBooks := make([]api.UserBookFavouritesBooks, len(outputs))
+	for i, output := range outputs {
+		apiType, err2 := convertUserBookFavouritesTypeModelToAPI(output.Type)
+		if err2 != nil {
+			return err2
+		}
+
+		userBookFavouritesBooks[i] = api.UserBookFavouritesBooks{
+			Type:  apiType,
+			Books: booksAPI[bookIndex : bookIndex+len(output.Books)],
+		}
+		bookIndex += len(output.Books)
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListBookByUserBookFavouritesResponse{
+		UserBookFavouritesBooks: userBookFavouritesBooks,
+	})
+}
+
+func (p public) ListAuthors(ctx echo.Context, params api.ListAuthorsParams) error {
+	if err := checkPagination(params.Page, params.Size); err != nil {
+		return err
+	}
+
+	var name string
+	if params.Name != nil {
+		name = *params.Name
+	}
+
+	outputs, err := p.authorQueryService.Search(name, params.Page, params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list authors: %s", err))
+	}
+
+	countAuthors, err := p.authorQueryService.CountSearch(name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list authors: %s", err))
+	}
+
+	authorsRespData := make([]api.Author, len(outputs))
+	for i, a := range outputs {
+		authorsRespData[i] = convertAuthorOutputModelToAPI(a)
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListAuthorResponse{
+		Authors:    authorsRespData,
+		CountPages: ptr(int(math.Ceil(float64(countAuthors) / float64(params.Size)))),
+	})
+}
+func (p public) CreateAuthor(ctx echo.Context) error {
+	var input api.CreateAuthorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	middleName := maybe.Nothing[string]()
+	if input.MiddleName != nil {
+		middleName = maybe.Just(*input.MiddleName)
+	}
+
+	nickName := maybe.Nothing[string]()
+	if input.NickName != nil {
+		nickName = maybe.Just(*input.NickName)
+	}
+
+	var aliases []string
+	if input.Aliases != nil {
+		aliases = *input.Aliases
+	}
+
+	err := p.authorService.CreateAuthor(service.CreateAuthorInput{
+		FirstName:  input.FirstName,
+		SecondName: input.SecondName,
+		MiddleName: middleName,
+		Nickname:   nickName,
+		Biography:  convertOptionalAPIToModel(input.Biography),
+		BirthYear:  convertOptionalAPIToModel(input.BirthYear),
+		DeathYear:  convertOptionalAPIToModel(input.DeathYear),
+		Country:    convertOptionalAPIToModel(input.Country),
+		Aliases:    aliases,
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domainmodel.ErrInvalidAuthorLifeYears):
+			return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+				Message: ptr("Death year must not be earlier than birth year"),
+			})
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create author: %s", err))
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Author created successfully"),
+	})
+}
+
+func (p public) EditAuthor(ctx echo.Context) error {
+	var input api.EditAuthorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	middleName := maybe.Nothing[string]()
+	if input.MiddleName != nil {
+		middleName = maybe.Just(*input.MiddleName)
+	}
+
+	nickName := maybe.Nothing[string]()
+	if input.NickName != nil {
+		nickName = maybe.Just(*input.NickName)
+	}
+
+	var aliases []string
+	if input.Aliases != nil {
+		aliases = *input.Aliases
+	}
+
+	err := p.authorService.EditAuthor(service.EditAuthorInput{
+		ID:         domainmodel.AuthorID(input.Id),
+		FirstName:  input.FirstName,
+		SecondName: input.SecondName,
+		MiddleName: middleName,
+		Nickname:   nickName,
+		Biography:  convertOptionalAPIToModel(input.Biography),
+		BirthYear:  convertOptionalAPIToModel(input.BirthYear),
+		DeathYear:  convertOptionalAPIToModel(input.DeathYear),
+		Country:    convertOptionalAPIToModel(input.Country),
+		Aliases:    aliases,
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domainmodel.ErrInvalidAuthorLifeYears):
+			return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+				Message: ptr("Death year must not be earlier than birth year"),
+			})
+		case errors.Is(err, domainmodel.ErrAuthorNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Author not found")
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit author: %s", err))
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Author edited successfully"),
+	})
+}
+
+func (p public) DeleteAuthor(ctx echo.Context) error {
+	var input api.DeleteAuthorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.authorService.DeleteAuthor(domainmodel.AuthorID(input.Id), extractAuditMetadataFromContext(ctx))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete author: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Author deleted successfully"),
+	})
+}
+
+func (p public) RestoreAuthor(ctx echo.Context) error {
+	var input api.RestoreAuthorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.authorService.RestoreAuthor(domainmodel.AuthorID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrAuthorNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Author not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to restore author: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Author restored successfully"),
+	})
+}
+
+func (p public) ListAuthorDuplicates(ctx echo.Context, params api.ListAuthorDuplicatesParams) error {
+	minSimilarity := domainmodel.DefaultAuthorNameSimilarity
+	if params.MinSimilarity != nil {
+		minSimilarity = *params.MinSimilarity
+	}
+	if minSimilarity < 0 || minSimilarity > 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr("minSimilarity must be between 0 and 1"),
+		})
+	}
+
+	duplicates, err := p.authorService.FindDuplicateAuthors(minSimilarity)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find duplicate authors: %s", err))
+	}
+
+	duplicatesAPI := make([]api.AuthorDuplicate, len(duplicates))
+	for i, duplicate := range duplicates {
+		duplicatesAPI[i] = api.AuthorDuplicate{
+			First:      convertAuthorModelToAPI(duplicate.First),
+			Second:     convertAuthorModelToAPI(duplicate.Second),
+			Similarity: duplicate.Similarity,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListAuthorDuplicatesResponse{
+		Duplicates: duplicatesAPI,
+	})
+}
+
+func (p public) MergeAuthors(ctx echo.Context) error {
+	var input api.MergeAuthorsRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.authorService.MergeAuthors(service.MergeAuthorsInput{
+		SurvivorID:  domainmodel.AuthorID(input.SurvivorId),
+		DuplicateID: domainmodel.AuthorID(input.DuplicateId),
+		Metadata:    extractAuditMetadataFromContext(ctx),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domainmodel.ErrMergeAuthorIntoItself):
+			return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+				Message: ptr("Author cannot be merged into itself"),
+			})
+		case errors.Is(err, domainmodel.ErrAuthorNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Author not found")
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to merge authors: %s", err))
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Authors merged successfully"),
+	})
+}
+
+func (p public) GetAuthor(ctx echo.Context, id string) error {
+	uid, err := uuid.FromString(id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get author: %s", err))
+	}
+
+	output, err := p.authorQueryService.FindDetailByID(domainmodel.AuthorID(uid))
+	if err != nil {
+		if errors.Is(err, domainmodel.ErrAuthorNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Author not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get author: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, convertAuthorDetailOutputModelToAPI(output))
+}
+
+func (p public) StoreBookAuthor(ctx echo.Context) error {
+	var input api.StoreBookAuthorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	role := domainmodel.BookAuthorRoleAuthor
+	if input.Role != nil {
+		var err error
+		role, err = convertBookAuthorRoleAPIToModel(*input.Role)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := p.bookAuthorService.StoreBookAuthor(service.StoreBookAuthorInput{
+		BookID:   domainmodel.BookID(input.BookId),
+		AuthorID: domainmodel.AuthorID(input.AuthorId),
+		Role:     role,
+		Position: convertOptionalAPIToModel(input.Position),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book author: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book author stored successfully"),
+	})
+}
+
+func (p public) ReorderBookAuthors(ctx echo.Context) error {
+	var input api.ReorderBookAuthorsRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	authorIDs := make([]domainmodel.AuthorID, len(input.AuthorIds))
+	for i, authorID := range input.AuthorIds {
+		authorIDs[i] = domainmodel.AuthorID(authorID)
+	}
+
+	err := p.bookAuthorService.ReorderBookAuthors(domainmodel.BookID(input.BookId), authorIDs)
+	if errors.Is(err, domainmodel.ErrInvalidBookAuthorOrder) {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr("Order must list every author of the book exactly once"),
+		})
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to reorder book authors: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book authors reordered successfully"),
+	})
+}
+
+func (p public) ListBookAuthors(ctx echo.Context, id openapi_types.UUID) error {
+	outputs, err := p.authorQueryService.ListByBookID(domainmodel.BookID(id))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list authors: %s", err))
+	}
+
+	authorsRespData, err := convertBookAuthorOutputsModelToAPI(outputs)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListAuthorResponse{
+		Authors: authorsRespData,
+	})
+}
+
+func (p public) DeleteBookAuthor(ctx echo.Context) error {
+	var input api.DeleteBookAuthorRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.bookAuthorService.DeleteBookAuthor(domainmodel.BookID(input.BookId), domainmodel.AuthorID(input.AuthorId))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book author: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book author deleted successfully"),
+	})
+}
+
+func (p public) ListGenres(ctx echo.Context) error {
+	outputs, err := p.genreQueryService.List()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list genres: %s", err))
+	}
+
+	genresRespData := make([]api.Genre, len(outputs))
+	for i, g := range outputs {
+		genresRespData[i] = convertGenreOutputModelToAPI(g)
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListGenreResponse{
+		Genres: genresRespData,
+	})
+}
+
+func (p public) ListBookGenres(ctx echo.Context, id openapi_types.UUID) error {
+	outputs, err := p.genreQueryService.ListByBookID(domainmodel.BookID(id))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list genres: %s", err))
+	}
+
+	genresRespData := make([]api.Genre, len(outputs))
+	for i, g := range outputs {
+		genresRespData[i] = convertGenreOutputModelToAPI(g)
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListGenreResponse{
+		Genres: genresRespData,
+	})
+}
+
+func (p public) ListGenreCatalog(ctx echo.Context) error {
+	outputs, err := p.genreQueryService.ListWithBookCount()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list genres: %s", err))
+	}
+
+	catalogRespData := make([]api.GenreCatalogItem, len(outputs))
+	for i, g := range outputs {
+		catalogRespData[i] = api.GenreCatalogItem{
+			Genre:     convertGenreOutputModelToAPI(g.GenreOutput),
+			BookCount: g.BookCount,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListGenreCatalogResponse{
+		Genres: catalogRespData,
+	})
+}
+
+func (p public) CreateGenre(ctx echo.Context) error {
+	var input api.CreateGenreRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.genreService.CreateGenre(service.CreateGenreInput{
+		ParentID: convertOptionalGenreIDAPIToModel(input.ParentId),
+		Name:     input.Name,
+	})
+	if errors.Is(err, domainmodel.ErrGenreNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Parent genre not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create genre: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Genre created successfully"),
+	})
+}
+
+func (p public) EditGenre(ctx echo.Context) error {
+	var input api.EditGenreRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.genreService.EditGenre(service.EditGenreInput{
+		ID:       domainmodel.GenreID(input.Id),
+		ParentID: convertOptionalGenreIDAPIToModel(input.ParentId),
+		Name:     input.Name,
+	})
+	if errors.Is(err, domainmodel.ErrGenreNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Genre not found")
+	}
+	if errors.Is(err, domainmodel.ErrGenreParentCycle) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Genre can not be a descendant of itself")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit genre: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Genre edited successfully"),
+	})
+}
+
+func (p public) DeleteGenre(ctx echo.Context) error {
+	var input api.DeleteGenreRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.genreService.DeleteGenre(domainmodel.GenreID(input.Id), extractAuditMetadataFromContext(ctx))
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete genre: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Genre deleted successfully"),
+	})
+}
+
+func (p public) RestoreGenre(ctx echo.Context) error {
+	var input api.RestoreGenreRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.genreService.RestoreGenre(domainmodel.GenreID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrGenreNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Genre not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to restore genre: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Genre restored successfully"),
+	})
+}
+
+func (p public) StoreBookGenre(ctx echo.Context) error {
+	var input api.StoreBookGenreRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.bookGenreService.StoreBookGenre(domainmodel.BookID(input.BookId), domainmodel.GenreID(input.GenreId))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book genre: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book genre stored successfully"),
+	})
+}
+
+func (p public) DeleteBookGenre(ctx echo.Context) error {
+	var input api.DeleteBookGenreRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.bookGenreService.DeleteBookGenre(domainmodel.BookID(input.BookId), domainmodel.GenreID(input.GenreId))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book genre: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book genre deleted successfully"),
+	})
+}
+
+func (p public) ListAuditLog(ctx echo.Context, params api.ListAuditLogParams) error {
+	if err := checkPagination(params.Page, params.Size); err != nil {
+		return err
+	}
+
+	filter := query.AuditLogFilter{}
+	if params.ActorId != nil {
+		filter.ActorID = maybe.Just(domainmodel.UserID(*params.ActorId))
+	}
+	if params.EntityType != nil {
+		entityType, err := convertAuditEntityTypeAPIToModel(*params.EntityType)
+		if err != nil {
+			return err
+		}
+		filter.EntityType = maybe.Just(entityType)
+	}
+	if params.EntityId != nil {
+		filter.EntityID = maybe.Just(uuid.UUID(*params.EntityId))
+	}
+	if params.FromMilli != nil {
+		filter.From = maybe.Just(time.UnixMilli(int64(*params.FromMilli)))
+	}
+	if params.ToMilli != nil {
+		filter.To = maybe.Just(time.UnixMilli(int64(*params.ToMilli)))
+	}
+
+	outputs, err := p.auditLogQueryService.List(filter, params.Page, params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list audit log: %s", err))
+	}
+
+	auditLogsRespData := make([]api.AuditLog, len(outputs))
+	for i, output := range outputs {
+		auditLog, err2 := convertAuditLogOutputModelToAPI(output)
+		if err2 != nil {
+			return err2
+		}
+
+		auditLogsRespData[i] = auditLog
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListAuditLogResponse{
+		AuditLogs: auditLogsRespData,
+	})
+}
+
+func (p public) ListTrash(ctx echo.Context, params api.ListTrashParams) error {
+	if err := checkPagination(params.Page, params.Size); err != nil {
+		return err
+	}
+
+	entityType := maybe.Nothing[domainmodel.TrashEntityType]()
+	if params.Type != nil {
+		modelType, err := convertTrashEntityTypeAPIToModel(*params.Type)
+		if err != nil {
+			return err
+		}
+		entityType = maybe.Just(modelType)
+	}
+
+	outputs, err := p.trashQueryService.List(entityType, params.Page, params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list trash: %s", err))
+	}
+
+	trashItemsRespData := make([]api.TrashItem, len(outputs))
+	for i, output := range outputs {
+		trashItemType, err2 := convertTrashEntityTypeModelToAPI(output.EntityType)
+		if err2 != nil {
+			return err2
+		}
+
+		trashItemsRespData[i] = api.TrashItem{
+			Id:             openapi_types.UUID(output.ID),
+			Type:           trashItemType,
+			Title:          output.Title,
+			DeletedAtMilli: int(output.DeletedAt.UnixMilli()),
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListTrashResponse{
+		Items: trashItemsRespData,
+	})
+}
+
+func (p public) SearchTags(ctx echo.Context, params api.SearchTagsParams) error {
+	if err := checkPagination(params.Page, params.Size); err != nil {
+		return err
+	}
+
+	outputs, err := p.tagQueryService.Search(params.Name, params.Page, params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to search tags: %s", err))
+	}
+
+	tagsRespData, err := convertTagOutputsModelToAPI(outputs)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListTagResponse{
+		Tags: tagsRespData,
+	})
+}
+
+func (p public) ListPendingTags(ctx echo.Context, params api.ListPendingTagsParams) error {
+	if err := checkPagination(params.Page, params.Size); err != nil {
+		return err
+	}
+
+	outputs, err := p.tagQueryService.ListByStatus(domainmodel.TagPending, params.Page, params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list tags: %s", err))
+	}
+
+	tagsRespData, err := convertTagOutputsModelToAPI(outputs)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListTagResponse{
+		Tags: tagsRespData,
+	})
+}
+
+func (p public) ApproveTag(ctx echo.Context) error {
+	var input api.ModerateTagRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.tagService.ApproveTag(domainmodel.TagID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrTagNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to approve tag: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Tag approved successfully"),
+	})
+}
+
+func (p public) RejectTag(ctx echo.Context) error {
+	var input api.ModerateTagRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	err := p.tagService.RejectTag(domainmodel.TagID(input.Id), extractAuditMetadataFromContext(ctx))
+	if errors.Is(err, domainmodel.ErrTagNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to reject tag: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Tag rejected successfully"),
+	})
+}
+
+func (p public) ListBookTagCloud(ctx echo.Context, id openapi_types.UUID) error {
+	outputs, err := p.tagQueryService.ListCloudByBookID(domainmodel.BookID(id))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list book tags: %s", err))
+	}
+
+	tagsRespData := make([]api.TagCloudItem, len(outputs))
+	for i, output := range outputs {
+		tagsRespData[i] = api.TagCloudItem{
+			Id:     openapi_types.UUID(output.TagID),
+			Name:   output.Name,
+			Weight: output.Weight,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, api.ListBookTagCloudResponse{
+		Tags: tagsRespData,
+	})
+}
+
+func (p public) SuggestBookTag(ctx echo.Context, id openapi_types.UUID) error {
+	var input api.SuggestBookTagRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.checkCanPublish(userID)
+	if err != nil {
+		return err
+	}
+
+	tagID, err := p.tagService.SuggestBookTag(service.SuggestBookTagInput{
+		BookID: domainmodel.BookID(id),
+		UserID: userID,
+		Name:   input.Name,
+	})
+	if errors.Is(err, domainmodel.ErrInvalidTagName) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Tag name must contain from 1 to %d characters", domainmodel.MaxTagNameLength))
+	}
+	if errors.Is(err, domainmodel.ErrTagRejected) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Tag was rejected by moderators")
+	}
+	if errors.Is(err, domainmodel.ErrBookNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to tag book: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuggestBookTagResponse{
+		TagId: openapi_types.UUID(tagID),
+	})
+}
+
+func (p public) DeleteBookTag(ctx echo.Context, id openapi_types.UUID) error {
+	var input api.DeleteBookTagRequest
+	if err := ctx.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
+			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
+		})
+	}
+
+	userID, err := extractUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.tagService.DeleteBookTag(domainmodel.NewBookTag(domainmodel.BookID(id), domainmodel.TagID(input.TagId), userID))
+	if errors.Is(err, domainmodel.ErrBookTagNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Book tag not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book tag: %s", err))
+	}
+
+	return ctx.JSON(http.StatusOK, api.SuccessResponse{
+		Message: ptr("Book tag deleted successfully"),
+	})
+}
+
+func ptr[T any](s T) *T {
+	return &s
+}
+
+// checkPagination не пропускает в запросы нулевой размер страницы, на который делится число страниц,
+// и номер страницы меньше единицы, дающий отрицательное смещение выборки
+func checkPagination(page, size int) error {
+	if page < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, "page must be positive")
+	}
+	if size < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, "size must be positive")
+	}
+
+	return nil
+}
+
+func extractUserIDFromContext(ctx echo.Context) (domainmodel.UserID, error) {
+	tokenString := ctx.Request().Header.Get("Authorization")
+	if tokenString == "" {
+		return domainmodel.UserID{}, echo.NewHTTPError(http.StatusUnauthorized, "Missing token")
+	}
+
+	claims := &model.Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return JwtKey, nil
+	})
+
+	if err != nil || !token.Valid {
+		return domainmodel.UserID{}, echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+	}
+
+	var userID uuid.UUID
+	err = userID.Parse(claims.UserID)
+	return domainmodel.UserID(userID), err
+}
+
+// checkCanPublish закрывает заглушённым и заблокированным пользователям всё, что видят другие читатели:
+// оригинал и переводы глав, машинные черновики, примечания, глоссарий, предложенные теги и запросы на публикацию книг.
+// Новые обработчики, публикующие что-либо от имени пользователя, должны вызывать эту проверку
+func (p public) checkCanPublish(userID domainmodel.UserID) error {
+	err := p.userRestrictionService.CheckCanPublish(userID)
+	if errors.Is(err, domainmodel.ErrUserSuspended) || errors.Is(err, domainmodel.ErrUserMuted) {
+		return echo.NewHTTPError(http.StatusForbidden, "User is not allowed to publish")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check user restrictions: %s", err))
+	}
+
+	return nil
+}
+
+func extractAuditMetadataFromContext(ctx echo.Context) service.AuditMetadata {
+	actorID := maybe.Nothing[domainmodel.UserID]()
+	if userID, err := extractUserIDFromContext(ctx); err == nil {
+		actorID = maybe.Just(userID)
+	}
+
+	userAgent := []rune(ctx.Request().UserAgent())
+	if len(userAgent) > maxAuditUserAgentLength {
+		userAgent = userAgent[:maxAuditUserAgentLength]
+	}
+
+	return service.AuditMetadata{
+		ActorID:   actorID,
+		IP:        ctx.RealIP(),
+		UserAgent: string(userAgent),
+	}
+}
+
+func convertUserBookFavouritesTypeAPIToModel(apiType api.UserBookFavouritesType) (domainmodel.UserBookFavouritesType, error) {
+	switch apiType {
+	case api.READING:
+		return domainmodel.READING, nil
+	case api.PLANNED:
+		return domainmodel.PLANNED, nil
+	case api.DEFERRED:
+		return domainmodel.DEFERRED, nil
+	case api.READ:
+		return domainmodel.READ, nil
+	case api.DROPPED:
+		return domainmodel.DROPPED, nil
+	case api.FAVORITE:
+		return domainmodel.FAVORITE, nil
+	default:
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown UserBookFavouritesType "+apiType)
+	}
+}
+
+func convertUserBookFavouritesTypeModelToAPI(modelType domainmodel.UserBookFavouritesType) (api.UserBookFavouritesType, error) {
+	switch modelType {
+	case domainmodel.READING:
+		return api.READING, nil
+	case domainmodel.PLANNED:
+		return api.PLANNED, nil
+	case domainmodel.DEFERRED:
+		return api.DEFERRED, nil
+	case domainmodel.READ:
+		return api.READ, nil
+	case domainmodel.DROPPED:
+		return api.DROPPED, nil
+	case domainmodel.FAVORITE:
+		return api.FAVORITE, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown UserBookFavouritesType "+fmt.Sprint(modelType))
+	}
+}
+
+func convertUserRestrictionTypeAPIToModel(apiType api.UserRestrictionType) (domainmodel.UserRestrictionType, error) {
+	switch apiType {
+	case api.SUSPENSION:
+		return domainmodel.SUSPENSION, nil
+	case api.MUTE:
+		return domainmodel.MUTE, nil
+	default:
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown UserRestrictionType "+apiType)
+	}
+}
+
+func convertUserRestrictionTypeModelToAPI(modelType domainmodel.UserRestrictionType) (api.UserRestrictionType, error) {
+	switch modelType {
+	case domainmodel.SUSPENSION:
+		return api.SUSPENSION, nil
+	case domainmodel.MUTE:
+		return api.MUTE, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown UserRestrictionType "+fmt.Sprint(modelType))
+	}
+}
+
+func convertUserRestrictionOutputModelToAPI(output query.UserRestrictionOutput) (api.UserRestriction, error) {
+	apiType, err := convertUserRestrictionTypeModelToAPI(output.Type)
+	if err != nil {
+		return api.UserRestriction{}, err
+	}
+
+	var expiresAtMilli *int
+	if expiresAt, ok := output.ExpiresAt.Get(); ok {
+		expiresAtMilli = ptr(int(expiresAt.UnixMilli()))
+	}
+
+	return api.UserRestriction{
+		UserRestrictionId: openapi_types.UUID(output.UserRestrictionID),
+		UserId:            openapi_types.UUID(output.UserID),
+		IssuedBy:          openapi_types.UUID(output.IssuedBy),
+		Type:              apiType,
+		Reason:            output.Reason,
+		CreatedAtMilli:    int(output.CreatedAt.UnixMilli()),
+		ExpiresAtMilli:    expiresAtMilli,
+	}, nil
+}
+
+func convertUserDataExportStatusModelToAPI(modelStatus domainmodel.UserDataExportStatus) (api.UserDataExportStatus, error) {
+	switch modelStatus {
+	case domainmodel.UserDataExportPending:
+		return api.UserDataExportStatusPENDING, nil
+	case domainmodel.UserDataExportReady:
+		return api.UserDataExportStatusREADY, nil
+	case domainmodel.UserDataExportFailed:
+		return api.UserDataExportStatusFAILED, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown UserDataExportStatus "+fmt.Sprint(modelStatus))
+	}
+}
+
+func convertUserDataExportOutputModelToAPI(output query.UserDataExportOutput) (api.UserDataExport, error) {
+	status, err := convertUserDataExportStatusModelToAPI(output.Status)
+	if err != nil {
+		return api.UserDataExport{}, err
+	}
+
+	userDataExportAPI := api.UserDataExport{
+		UserDataExportId: openapi_types.UUID(output.UserDataExportID),
+		Status:           status,
+		CreatedAtMilli:   int(output.CreatedAt.UnixMilli()),
+	}
+	if completedAt, ok := output.CompletedAt.Get(); ok {
+		userDataExportAPI.CompletedAtMilli = ptr(int(completedAt.UnixMilli()))
+	}
+
+	return userDataExportAPI, nil
+}
+
+func convertAuditActionModelToAPI(modelAction domainmodel.AuditAction) (api.AuditAction, error) {
+	switch modelAction {
+	case domainmodel.AuditActionPublishBook:
+		return api.PUBLISHBOOK, nil
+	case domainmodel.AuditActionDeleteBook:
+		return api.DELETEBOOK, nil
+	case domainmodel.AuditActionDeleteAuthor:
+		return api.DELETEAUTHOR, nil
+	case domainmodel.AuditActionDeleteGenre:
+		return api.DELETEGENRE, nil
+	case domainmodel.AuditActionEditUser:
+		return api.EDITUSER, nil
+	case domainmodel.AuditActionDeleteUser:
+		return api.DELETEUSER, nil
+	case domainmodel.AuditActionAcceptVerifyBookRequest:
+		return api.ACCEPTVERIFYBOOKREQUEST, nil
+	case domainmodel.AuditActionRestrictUser:
+		return api.RESTRICTUSER, nil
+	case domainmodel.AuditActionLiftUserRestriction:
+		return api.LIFTUSERRESTRICTION, nil
+	case domainmodel.AuditActionRestoreBook:
+		return api.RESTOREBOOK, nil
+	case domainmodel.AuditActionRestoreAuthor:
+		return api.RESTOREAUTHOR, nil
+	case domainmodel.AuditActionRestoreGenre:
+		return api.RESTOREGENRE, nil
+	case domainmodel.AuditActionApproveTag:
+		return api.APPROVETAG, nil
+	case domainmodel.AuditActionRejectTag:
+		return api.REJECTTAG, nil
+	case domainmodel.AuditActionMergeAuthor:
+		return api.MERGEAUTHOR, nil
+	case domainmodel.AuditActionRestoreBookChapter:
+		return api.RESTOREBOOKCHAPTER, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown AuditAction "+fmt.Sprint(modelAction))
+	}
+}
+
+func convertAuditEntityTypeAPIToModel(apiType api.AuditEntityType) (domainmodel.AuditEntityType, error) {
+	switch apiType {
+	case api.AuditEntityTypeUSER:
+		return domainmodel.AuditEntityUser, nil
+	case api.AuditEntityTypeBOOK:
+		return domainmodel.AuditEntityBook, nil
+	case api.AuditEntityTypeAUTHOR:
+		return domainmodel.AuditEntityAuthor, nil
+	case api.AuditEntityTypeGENRE:
+		return domainmodel.AuditEntityGenre, nil
+	case api.AuditEntityTypeVERIFYBOOKREQUEST:
+		return domainmodel.AuditEntityVerifyBookRequest, nil
+	case api.AuditEntityTypeUSERRESTRICTION:
+		return domainmodel.AuditEntityUserRestriction, nil
+	case api.AuditEntityTypeTAG:
+		return domainmodel.AuditEntityTag, nil
+	case api.AuditEntityTypeBOOKCHAPTER:
+		return domainmodel.AuditEntityBookChapter, nil
+	default:
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown AuditEntityType "+apiType)
+	}
+}
+
+func convertAuditEntityTypeModelToAPI(modelType domainmodel.AuditEntityType) (api.AuditEntityType, error) {
+	switch modelType {
+	case domainmodel.AuditEntityUser:
+		return api.AuditEntityTypeUSER, nil
+	case domainmodel.AuditEntityBook:
+		return api.AuditEntityTypeBOOK, nil
+	case domainmodel.AuditEntityAuthor:
+		return api.AuditEntityTypeAUTHOR, nil
+	case domainmodel.AuditEntityGenre:
+		return api.AuditEntityTypeGENRE, nil
+	case domainmodel.AuditEntityVerifyBookRequest:
+		return api.AuditEntityTypeVERIFYBOOKREQUEST, nil
+	case domainmodel.AuditEntityUserRestriction:
+		return api.AuditEntityTypeUSERRESTRICTION, nil
+	case domainmodel.AuditEntityTag:
+		return api.AuditEntityTypeTAG, nil
+	case domainmodel.AuditEntityBookChapter:
+		return api.AuditEntityTypeBOOKCHAPTER, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown AuditEntityType "+fmt.Sprint(modelType))
+	}
+}
+
+func convertTrashEntityTypeAPIToModel(apiType api.TrashEntityType) (domainmodel.TrashEntityType, error) {
+	switch apiType {
+	case api.BOOK:
+		return domainmodel.TrashEntityBook, nil
+	case api.BOOKCHAPTER:
+		return domainmodel.TrashEntityBookChapter, nil
+	case api.AUTHOR:
+		return domainmodel.TrashEntityAuthor, nil
+	case api.GENRE:
+		return domainmodel.TrashEntityGenre, nil
+	default:
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown TrashEntityType "+apiType)
+	}
+}
+
+func convertTrashEntityTypeModelToAPI(modelType domainmodel.TrashEntityType) (api.TrashEntityType, error) {
+	switch modelType {
+	case domainmodel.TrashEntityBook:
+		return api.BOOK, nil
+	case domainmodel.TrashEntityBookChapter:
+		return api.BOOKCHAPTER, nil
+	case domainmodel.TrashEntityAuthor:
+		return api.AUTHOR, nil
+	case domainmodel.TrashEntityGenre:
+		return api.GENRE, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown TrashEntityType "+fmt.Sprint(modelType))
+	}
+}
+
+func convertAuditLogOutputModelToAPI(output query.AuditLogOutput) (api.AuditLog, error) {
+	action, err := convertAuditActionModelToAPI(output.Action)
+	if err != nil {
+		return api.AuditLog{}, err
+	}
+
+	entityType, err := convertAuditEntityTypeModelToAPI(output.EntityType)
+	if err != nil {
+		return api.AuditLog{}, err
+	}
+
+	auditLogAPI := api.AuditLog{
+		AuditLogId:     openapi_types.UUID(output.AuditLogID),
+		Action:         action,
+		EntityType:     entityType,
+		EntityId:       openapi_types.UUID(output.EntityID),
+		Ip:             output.IP,
+		UserAgent:      output.UserAgent,
+		CreatedAtMilli: int(output.CreatedAt.UnixMilli()),
+	}
+
+	if actorID, ok := output.ActorID.Get(); ok {
+		auditLogAPI.ActorId = ptr(openapi_types.UUID(actorID))
+	}
+
+	if before, ok := output.Before.Get(); ok {
+		var snapshot map[string]interface{}
+		if err = json.Unmarshal([]byte(before), &snapshot); err != nil {
+			return api.AuditLog{}, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Invalid audit log snapshot: %s", err))
+		}
+		auditLogAPI.Before = &snapshot
+	}
+
+	if after, ok := output.After.Get(); ok {
+		var snapshot map[string]interface{}
+		if err = json.Unmarshal([]byte(after), &snapshot); err != nil {
+			return api.AuditLog{}, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Invalid audit log snapshot: %s", err))
+		}
+		auditLogAPI.After = &snapshot
+	}
+
+	return auditLogAPI, nil
+}
+
+func convertListBookParamsToBookFilter(params api.ListBookParams) (query.BookFilter, error) {
+	filter := query.BookFilter{}
+
+	if params.GenreIds != nil {
+		for _, genreID := range *params.GenreIds {
+			filter.GenreIDs = append(filter.GenreIDs, domainmodel.GenreID(genreID))
+		}
+	}
+	if params.GenreMatch != nil {
+		switch *params.GenreMatch {
+		case api.ANY:
+			filter.GenreMatchAll = false
+		case api.ALL:
+			filter.GenreMatchAll = true
+		default:
+			return query.BookFilter{}, echo.NewHTTPError(http.StatusBadRequest, "Unknown GenreMatch "+*params.GenreMatch)
+		}
+	}
+	if params.AuthorIds != nil {
+		for _, authorID := range *params.AuthorIds {
+			filter.AuthorIDs = append(filter.AuthorIDs, domainmodel.AuthorID(authorID))
+		}
+	}
+	if params.MinRating != nil {
+		filter.MinRating = maybe.Just(float64(*params.MinRating))
+	}
+	if params.HasTranslation != nil {
+		filter.HasTranslation = maybe.Just(*params.HasTranslation)
+	}
+	if params.MinChapters != nil {
+		filter.MinChapterCount = maybe.Just(*params.MinChapters)
+	}
+	if params.MaxChapters != nil {
+		filter.MaxChapterCount = maybe.Just(*params.MaxChapters)
+	}
+	if params.MinChapters != nil && params.MaxChapters != nil && *params.MinChapters > *params.MaxChapters {
+		return query.BookFilter{}, echo.NewHTTPError(http.StatusBadRequest, "minChapters must not exceed maxChapters")
+	}
+
+	var err error
+	filter.OriginalLanguage, err = convertLanguageAPIToModel(params.Language)
+	if err != nil {
+		return query.BookFilter{}, err
+	}
+	filter.TranslationLanguage, err = convertLanguageAPIToModel(params.TranslationLanguage)
+	if err != nil {
+		return query.BookFilter{}, err
+	}
+	filter.Title = convertOptionalAPIToModel(params.Title)
+
+	return filter, nil
+}
+
+func convertBookSortAPIToQuery(sort *api.BookSort) (query.BookSort, error) {
+	if sort == nil {
+		return query.BookSortTitle, nil
+	}
+
+	switch *sort {
+	case api.TITLE:
+		return query.BookSortTitle, nil
+	case api.RATING:
+		return query.BookSortRating, nil
+	case api.RATINGCOUNT:
+		return query.BookSortRatingCount, nil
+	case api.READERS:
+		return query.BookSortReaders, nil
+	case api.PUBLISHEDAT:
+		return query.BookSortPublishedAt, nil
+	case api.LASTTRANSLATEDAT:
+		return query.BookSortLastTranslatedAt, nil
+	default:
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown BookSort "+*sort)
+	}
+}
+
+func convertCursorAPIToQuery(cursor *string) (maybe.Maybe[query.Cursor], error) {
+	if cursor == nil {
+		return maybe.Nothing[query.Cursor](), nil
+	}
+
+	decodedCursor, err := query.DecodeCursor(*cursor)
+	if err != nil {
+		return maybe.Nothing[query.Cursor](), echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return maybe.Just(decodedCursor), nil
+}
 
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete author: %s", err))
+func convertCursorToAPI(cursor maybe.Maybe[query.Cursor]) *string {
+	if value, ok := cursor.Get(); ok {
+		return ptr(query.EncodeCursor(value))
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Author deleted successfully"),
-	})
+	return nil
 }
 
-func (p public) GetAuthor(ctx echo.Context, id string) error {
-	uid, err := uuid.FromString(id)
+func convertBookOutputModelToAPI(
+	bookOutput query.BookOutput,
+	localization bookLocalization,
+	authors []query.BookAuthorOutput,
+	genres []query.GenreOutput,
+	ratingStats query.BookRatingStatsOutput,
+	chapterCount int,
+) (api.Book, error) {
+	authorsAPI, err := convertBookAuthorOutputsModelToAPI(authors)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get author: %s", err))
+		return api.Book{}, err
 	}
 
-	output, err := p.authorQueryService.FindByID(domainmodel.AuthorID(uid))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get author: %s", err))
+	genresAPI := make([]api.Genre, len(genres))
+	for i, genre := range genres {
+		genresAPI[i] = convertGenreOutputModelToAPI(genre)
 	}
 
-	return ctx.JSON(http.StatusOK, convertAuthorOutputModelToAPI(output))
-}
+	cover, ok := bookOutput.Cover.Get()
 
-func (p public) StoreBookAuthor(ctx echo.Context) error {
-	var input api.StoreBookAuthorRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+	bookAPI := api.Book{
+		BookId:        openapi_types.UUID(bookOutput.BookID),
+		Cover:         ptr(cover),
+		Title:         localization.title,
+		Description:   localization.description,
+		OriginalTitle: bookOutput.Title,
+		Language:      convertOptionalModelToAPI(localization.language),
+		Authors:       authorsAPI,
+		Genres:        genresAPI,
+		Rating: api.BookRatingSummary{
+			Average: float32(ratingStats.Average),
+			Count:   ratingStats.Count,
+		},
+		ChapterCount:     chapterCount,
+		OriginalLanguage: convertOptionalModelToAPI(bookOutput.OriginalLanguage),
 	}
 
-	err := p.bookAuthorService.StoreBookAuthor(domainmodel.BookID(input.BookId), domainmodel.GenreID(input.AuthorId))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book author: %s", err))
+	if !ok {
+		bookAPI.Cover = nil
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book author stored successfully"),
-	})
+	return bookAPI, nil
 }
 
-func (p public) DeleteBookAuthor(ctx echo.Context) error {
-	var input api.DeleteBookAuthorRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func convertAuthorOutputModelToAPI(output query.AuthorOutput) api.Author {
+	var middleName *string
+	if middleNameValue, ok := output.MiddleName.Get(); ok {
+		middleName = &middleNameValue
 	}
 
-	err := p.bookAuthorService.DeleteBookAuthor(domainmodel.BookID(input.BookId), domainmodel.GenreID(input.AuthorId))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book author: %s", err))
+	var nickname *string
+	if nicknameValue, ok := output.Nickname.Get(); ok {
+		nickname = &nicknameValue
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book author deleted successfully"),
-	})
+	return api.Author{
+		Id:         openapi_types.UUID(output.AuthorID),
+		FirstName:  output.FirstName,
+		SecondName: output.SecondName,
+		MiddleName: middleName,
+		Nickname:   nickname,
+	}
 }
 
-func (p public) ListGenres(ctx echo.Context) error {
-	outputs, err := p.genreQueryService.List()
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list genres: %s", err))
+func convertBookDuplicatesModelToAPI(duplicates []domainmodel.BookDuplicate) []api.BookDuplicate {
+	duplicatesAPI := make([]api.BookDuplicate, len(duplicates))
+	for i, d := range duplicates {
+		duplicatesAPI[i] = api.BookDuplicate{
+			BookId:          openapi_types.UUID(d.BookID()),
+			Title:           d.Title(),
+			TitleSimilarity: d.TitleSimilarity(),
+			AuthorOverlap:   d.AuthorOverlap(),
+			Score:           d.Score(),
+		}
 	}
 
-	genresRespData := make([]api.Genre, len(outputs))
-	for i, g := range outputs {
-		genresRespData[i] = convertGenreOutputModelToAPI(g)
+	return duplicatesAPI
+}
+
+func convertBookAuthorOutputsModelToAPI(outputs []query.BookAuthorOutput) ([]api.Author, error) {
+	authorsAPI := make([]api.Author, len(outputs))
+	for i, output := range outputs {
+		role, err := convertBookAuthorRoleModelToAPI(output.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		authorsAPI[i] = convertAuthorOutputModelToAPI(output.AuthorOutput)
+		authorsAPI[i].Role = &role
 	}
 
-	return ctx.JSON(http.StatusOK, api.ListGenreResponse{
-		Genres: genresRespData,
-	})
+	return authorsAPI, nil
 }
 
-func (p public) CreateGenre(ctx echo.Context) error {
-	var input api.CreateGenreRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func convertBookAuthorRoleAPIToModel(apiRole api.BookAuthorRole) (domainmodel.BookAuthorRole, error) {
+	switch apiRole {
+	case api.BookAuthorRoleAUTHOR:
+		return domainmodel.BookAuthorRoleAuthor, nil
+	case api.BookAuthorRoleCOAUTHOR:
+		return domainmodel.BookAuthorRoleCoAuthor, nil
+	case api.BookAuthorRoleILLUSTRATOR:
+		return domainmodel.BookAuthorRoleIllustrator, nil
+	case api.BookAuthorRoleEDITOR:
+		return domainmodel.BookAuthorRoleEditor, nil
+	case api.BookAuthorRoleORIGINALTRANSLATOR:
+		return domainmodel.BookAuthorRoleOriginalTranslator, nil
+	default:
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown BookAuthorRole "+apiRole)
 	}
+}
 
-	err := p.genreService.CreateGenre(service.CreateGenreInput{
-		Name: input.Name,
-	})
+func convertBookAuthorRoleModelToAPI(role domainmodel.BookAuthorRole) (api.BookAuthorRole, error) {
+	switch role {
+	case domainmodel.BookAuthorRoleAuthor:
+		return api.BookAuthorRoleAUTHOR, nil
+	case domainmodel.BookAuthorRoleCoAuthor:
+		return api.BookAuthorRoleCOAUTHOR, nil
+	case domainmodel.BookAuthorRoleIllustrator:
+		return api.BookAuthorRoleILLUSTRATOR, nil
+	case domainmodel.BookAuthorRoleEditor:
+		return api.BookAuthorRoleEDITOR, nil
+	case domainmodel.BookAuthorRoleOriginalTranslator:
+		return api.BookAuthorRoleORIGINALTRANSLATOR, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown BookAuthorRole "+fmt.Sprint(role))
+	}
+}
 
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create genre: %s", err))
+func convertAuthorModelToAPI(author domainmodel.Author) api.Author {
+	authorAPI := api.Author{
+		Id:         openapi_types.UUID(author.ID()),
+		FirstName:  author.FirstName(),
+		SecondName: author.SecondName(),
+	}
+	if middleName, ok := author.MiddleName().Get(); ok {
+		authorAPI.MiddleName = &middleName
+	}
+	if nickname, ok := author.Nickname().Get(); ok {
+		authorAPI.Nickname = &nickname
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Genre created successfully"),
-	})
+	return authorAPI
 }
 
-func (p public) EditGenre(ctx echo.Context) error {
-	var input api.EditGenreRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
-	}
+func convertAuthorDetailOutputModelToAPI(output query.AuthorDetailOutput) api.AuthorDetail {
+	author := convertAuthorOutputModelToAPI(output.AuthorOutput)
 
-	err := p.genreService.EditGenre(service.EditGenreInput{
-		ID:   domainmodel.GenreID(input.Id),
-		Name: input.Name,
-	})
+	books := make([]api.AuthorBook, len(output.Books))
+	for i, b := range output.Books {
+		books[i] = api.AuthorBook{
+			Id:    openapi_types.UUID(b.BookID),
+			Title: b.Title,
+		}
+		if publishedAt, ok := b.PublishedAt.Get(); ok {
+			books[i].PublishedAtMilli = ptr(publishedAt.UnixMilli())
+		}
+	}
 
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to edit genre: %s", err))
+	detail := api.AuthorDetail{
+		Id:         author.Id,
+		FirstName:  author.FirstName,
+		SecondName: author.SecondName,
+		MiddleName: author.MiddleName,
+		Nickname:   author.Nickname,
+		Aliases:    output.Aliases,
+		Books:      books,
+	}
+	if biography, ok := output.Biography.Get(); ok {
+		detail.Biography = &biography
+	}
+	if birthYear, ok := output.BirthYear.Get(); ok {
+		detail.BirthYear = &birthYear
+	}
+	if deathYear, ok := output.DeathYear.Get(); ok {
+		detail.DeathYear = &deathYear
+	}
+	if country, ok := output.Country.Get(); ok {
+		detail.Country = &country
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Genre edited successfully"),
-	})
+	return detail
 }
 
-func (p public) DeleteGenre(ctx echo.Context) error {
-	var input api.DeleteGenreRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func convertTagStatusModelToAPI(modelStatus domainmodel.TagStatus) (api.TagStatus, error) {
+	switch modelStatus {
+	case domainmodel.TagPending:
+		return api.TagStatusPENDING, nil
+	case domainmodel.TagApproved:
+		return api.TagStatusAPPROVED, nil
+	case domainmodel.TagRejected:
+		return api.TagStatusREJECTED, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown TagStatus "+fmt.Sprint(modelStatus))
 	}
+}
 
-	err := p.genreService.DeleteGenre(domainmodel.GenreID(input.Id))
+func convertTagOutputsModelToAPI(outputs []query.TagOutput) ([]api.Tag, error) {
+	tagsAPI := make([]api.Tag, len(outputs))
+	for i, output := range outputs {
+		status, err := convertTagStatusModelToAPI(output.Status)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete genre: %s", err))
+		tagsAPI[i] = api.Tag{
+			Id:             openapi_types.UUID(output.TagID),
+			Name:           output.Name,
+			Status:         status,
+			CreatedAtMilli: output.CreatedAt.UnixMilli(),
+			BookCount:      output.BookCount,
+		}
+		if suggestedBy, ok := output.SuggestedBy.Get(); ok {
+			tagsAPI[i].SuggestedBy = ptr(openapi_types.UUID(suggestedBy))
+		}
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Genre deleted successfully"),
-	})
+	return tagsAPI, nil
 }
 
-func (p public) StoreBookGenre(ctx echo.Context) error {
-	var input api.StoreBookGenreRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func convertOptionalAPIToModel[T any](value *T) maybe.Maybe[T] {
+	if value == nil {
+		return maybe.Nothing[T]()
 	}
 
-	err := p.bookGenreService.StoreBookGenre(domainmodel.BookID(input.BookId), domainmodel.GenreID(input.GenreId))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to store book genre: %s", err))
+	return maybe.Just(*value)
+}
+
+func convertOptionalModelToAPI[T any](value maybe.Maybe[T]) *T {
+	if v, ok := value.Get(); ok {
+		return &v
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book genre stored successfully"),
-	})
+	return nil
 }
 
-func (p public) DeleteBookGenre(ctx echo.Context) error {
-	var input api.DeleteBookGenreRequest
-	if err := ctx.Bind(&input); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, api.BadRequestResponse{
-			Message: ptr(fmt.Sprintf("Invalid request: %s", err)),
-		})
+func convertLanguageAPIToModel(language *string) (maybe.Maybe[string], error) {
+	if language == nil {
+		return maybe.Nothing[string](), nil
 	}
 
-	err := p.bookGenreService.DeleteBookGenre(domainmodel.BookID(input.BookId), domainmodel.GenreID(input.GenreId))
+	normalized, err := domainmodel.NormalizeLanguageCode(*language)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete book genre: %s", err))
+		return maybe.Nothing[string](), echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, api.SuccessResponse{
-		Message: ptr("Book genre deleted successfully"),
-	})
+	return maybe.Just(normalized), nil
 }
 
-func ptr[T any](s T) *T {
-	return &s
+// convertPreferredLanguagesAPIToModel возвращает языки в порядке предпочтения: сначала lang, затем языки из Accept-Language
+func convertPreferredLanguagesAPIToModel(lang *string, acceptLanguage *string) ([]string, error) {
+	var languages []string
+	if lang != nil {
+		language, err := domainmodel.NormalizeLanguageCode(*lang)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		languages = append(languages, language)
+	}
+	if acceptLanguage != nil {
+		languages = append(languages, parseAcceptLanguage(*acceptLanguage)...)
+	}
+
+	return languages, nil
 }
 
-func extractUserIDFromContext(ctx echo.Context) (domainmodel.UserID, error) {
-	tokenString := ctx.Request().Header.Get("Authorization")
-	if tokenString == "" {
-		return domainmodel.UserID{}, echo.NewHTTPError(http.StatusUnauthorized, "Missing token")
+// parseAcceptLanguage разбирает заголовок вида "ru-RU,ru;q=0.9,en;q=0.8" в коды ISO 639-1 по убыванию веса.
+// Неизвестные языки и "*" пропускаются
+func parseAcceptLanguage(header string) []string {
+	type weightedLanguage struct {
+		code   string
+		weight float64
+	}
+
+	var weighted []weightedLanguage
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		primary, _, _ := strings.Cut(tag, "-")
+		code, err := domainmodel.NormalizeLanguageCode(primary)
+		if err != nil {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || name != "q" {
+				continue
+			}
+			parsed, err2 := strconv.ParseFloat(value, 64)
+			if err2 == nil {
+				weight = parsed
+			}
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		weighted = append(weighted, weightedLanguage{code: code, weight: weight})
 	}
 
-	claims := &model.Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return JwtKey, nil
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].weight > weighted[j].weight
 	})
 
-	if err != nil || !token.Valid {
-		return domainmodel.UserID{}, echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+	languages := make([]string, 0, len(weighted))
+	seen := make(map[string]struct{}, len(weighted))
+	for _, language := range weighted {
+		if _, ok := seen[language.code]; ok {
+			continue
+		}
+		seen[language.code] = struct{}{}
+		languages = append(languages, language.code)
 	}
 
-	var userID uuid.UUID
-	err = userID.Parse(claims.UserID)
-	return domainmodel.UserID(userID), err
+	return languages
 }
 
-func convertUserBookFavouritesTypeAPIToModel(apiType api.UserBookFavouritesType) (domainmodel.UserBookFavouritesType, error) {
-	switch apiType {
-	case api.READING:
-		return domainmodel.READING, nil
-	case api.PLANNED:
-		return domainmodel.PLANNED, nil
-	case api.DEFERRED:
-		return domainmodel.DEFERRED, nil
-	case api.READ:
-		return domainmodel.READ, nil
-	case api.DROPPED:
-		return domainmodel.DROPPED, nil
-	case api.FAVORITE:
-		return domainmodel.FAVORITE, nil
-	default:
-		return 0, echo.NewHTTPError(http.StatusBadRequest, "Unknown UserBookFavouritesType "+apiType)
+type bookLocalization struct {
+	title       string
+	description string
+	language    maybe.Maybe[string]
+}
+
+// selectBookLocalization выбирает первый из предпочитаемых языков, на котором есть название книги.
+// Язык оригинала тоже подходит, поэтому при "en,ru" у английской книги останется оригинальное название
+func selectBookLocalization(book query.BookOutput, localizations []query.BookLocalizationOutput, languages []string) bookLocalization {
+	original := bookLocalization{
+		title:       book.Title,
+		description: book.Description,
+		language:    book.OriginalLanguage,
+	}
+
+	for _, language := range languages {
+		if originalLanguage, ok := book.OriginalLanguage.Get(); ok && originalLanguage == language {
+			return original
+		}
+		for _, localization := range localizations {
+			if localization.Language == language {
+				return bookLocalization{
+					title:       localization.Title,
+					description: localization.Description,
+					language:    maybe.Just(localization.Language),
+				}
+			}
+		}
 	}
+
+	return original
 }
 
-func convertUserBookFavouritesTypeModelToAPI(modelType domainmodel.UserBookFavouritesType) (api.UserBookFavouritesType, error) {
-	switch modelType {
-	case domainmodel.READING:
-		return api.READING, nil
-	case domainmodel.PLANNED:
-		return api.PLANNED, nil
-	case domainmodel.DEFERRED:
-		return api.DEFERRED, nil
-	case domainmodel.READ:
-		return api.READ, nil
-	case domainmodel.DROPPED:
-		return api.DROPPED, nil
-	case domainmodel.FAVORITE:
-		return api.FAVORITE, nil
-	default:
-		return "", echo.NewHTTPError(http.StatusInternalServerError, "Unknown UserBookFavouritesType "+fmt.Sprint(modelType))
+// convertTranslationLanguageAPIToModel подставляет язык, на который переводились главы до появления языка у переводов
+func convertTranslationLanguageAPIToModel(language *string) string {
+	if language == nil {
+		return domainmodel.DefaultTranslationLanguage
 	}
+
+	return *language
 }
 
-func convertBookOutputModelToAPI(bookOutput query.BookOutput, authors []query.AuthorOutput) api.Book {
-	authorsAPI := make([]api.Author, len(authors))
-	for i, author := range authors {
-		authorsAPI[i] = convertAuthorOutputModelToAPI(author)
+func convertBookChapterOutputToAPI(output query.BookChapterOutput) api.BookChapter {
+	return api.BookChapter{
+		BookChapterId: openapi_types.UUID(output.BookChapterID),
+		Index:         output.Index,
+		Title:         output.Title,
 	}
+}
 
-	cover, ok := bookOutput.Cover.Get()
+func convertBookChapterTranslationOutputToAPI(
+	output query.BookChapterTranslationOutput,
+	format api.TranslationTextFormat,
+) api.GetBookChapterTranslationResponse {
+	text := output.Text
+	if format == api.HTML {
+		text = output.HTML
+	}
+
+	annotations := make([]api.BookChapterTranslationAnnotation, len(output.Annotations))
+	for i, annotation := range output.Annotations {
+		annotations[i] = api.BookChapterTranslationAnnotation{
+			AnnotationId:   openapi_types.UUID(annotation.AnnotationID),
+			ParagraphIndex: annotation.ParagraphIndex,
+			Offset:         annotation.Offset,
+			Length:         utf8.RuneCountInString(annotation.AnchorText),
+			AnchorText:     annotation.AnchorText,
+			Text:           annotation.Text,
+			IsDetached:     annotation.IsDetached,
+			CreatedAtMilli: int(annotation.CreatedAt.UnixMilli()),
+		}
+	}
 
-	bookAPI := api.Book{
-		BookId:      openapi_types.UUID(bookOutput.BookID),
-		Cover:       ptr(cover),
-		Title:       bookOutput.Title,
-		Description: bookOutput.Description,
-		Authors:     authorsAPI,
+	return api.GetBookChapterTranslationResponse{
+		TranslatorId: openapi_types.UUID(output.TranslatorID),
+		Language:     output.Language,
+		Text:         text,
+		Format:       format,
+		Score:        output.Score,
+		Annotations:  annotations,
 	}
+}
 
-	if !ok {
-		bookAPI.Cover = nil
+func convertAnnotationAnchorAPIToModel(anchor api.AnnotationAnchor) service.AnnotationAnchorInput {
+	length := 0
+	if anchor.Length != nil {
+		length = *anchor.Length
 	}
 
-	return bookAPI
+	return service.AnnotationAnchorInput{
+		ParagraphIndex: anchor.ParagraphIndex,
+		Offset:         anchor.Offset,
+		Length:         length,
+	}
 }
 
-func convertAuthorOutputModelToAPI(output query.AuthorOutput) api.Author {
-	var middleName *string
-	if middleNameValue, ok := output.MiddleName.Get(); ok {
-		middleName = &middleNameValue
+func convertTranslationTextFormatAPI(format *api.TranslationTextFormat) (api.TranslationTextFormat, error) {
+	if format == nil {
+		return api.MARKDOWN, nil
 	}
 
-	var nickname *string
-	if nicknameValue, ok := output.Nickname.Get(); ok {
-		nickname = &nicknameValue
+	switch *format {
+	case api.MARKDOWN, api.HTML:
+		return *format, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Unknown TranslationTextFormat "+string(*format))
 	}
+}
 
-	return api.Author{
-		Id:         openapi_types.UUID(output.AuthorID),
-		FirstName:  output.FirstName,
-		SecondName: output.SecondName,
-		MiddleName: middleName,
-		Nickname:   nickname,
+func convertOptionalGenreIDAPIToModel(genreID *openapi_types.UUID) maybe.Maybe[domainmodel.GenreID] {
+	if genreID == nil {
+		return maybe.Nothing[domainmodel.GenreID]()
 	}
+
+	return maybe.Just(domainmodel.GenreID(*genreID))
 }
 
 func convertGenreOutputModelToAPI(output query.GenreOutput) api.Genre {
-	return api.Genre{
+	genreAPI := api.Genre{
 		Id:   openapi_types.UUID(output.GenreID),
 		Name: output.Name,
 	}
+	if parentID, ok := output.ParentID.Get(); ok {
+		genreAPI.ParentId = ptr(openapi_types.UUID(parentID))
+	}
+
+	return genreAPI
 }
 
 func createToken(user model.User, expirationTimeDur time.Duration) (string, time.Time, error) {
@@ -1483,3 +4235,34 @@ func deleteCookie(ctx echo.Context, name string) {
 	}
 	ctx.SetCookie(cookie)
 }
+
+func convertBookTranslationStatsOutputToAPI(output query.BookTranslationStatsOutput) api.BookTranslationStats {
+	translators := make([]api.BookTranslatorStats, len(output.Translators))
+	for i, translator := range output.Translators {
+		translators[i] = api.BookTranslatorStats{
+			TranslatorId:       openapi_types.UUID(translator.TranslatorID),
+			Login:              translator.Login,
+			Language:           translator.Language,
+			TranslatedChapters: translator.TranslatedChapters,
+			WordCount:          translator.WordCount,
+			LastUpdatedAtMilli: int(translator.LastUpdatedAt.UnixMilli()),
+			Coverage:           float32(translator.Coverage),
+		}
+	}
+
+	return api.BookTranslationStats{
+		TotalChapters:      output.TotalChapters,
+		TranslatedChapters: output.TranslatedChapters,
+		WordCount:          output.WordCount,
+		LastUpdatedAtMilli: convertOptionalTimeToMilliAPI(output.LastUpdatedAt),
+		Coverage:           float32(output.Coverage),
+		Translators:        translators,
+	}
+}
+
+func convertOptionalTimeToMilliAPI(value maybe.Maybe[time.Time]) *int {
+	if t, ok := value.Get(); ok {
+		return ptr(int(t.UnixMilli()))
+	}
+	return nil
+}